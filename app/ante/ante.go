@@ -29,7 +29,8 @@ const (
 // NewAnteHandler returns an ante handler responsible for attempting to route an
 // Ethereum or SDK transaction to an internal ante handler for performing
 // transaction-level processing (e.g. fee payment, signature verification) before
-// being passed onto it's respective handler.
+// being passed onto it's respective handler. The impersonator is only set on development chains, it
+// accepts the unsigned Ethereum transactions of the impersonated accounts.
 func NewAnteHandler(
 	ak evmtypes.AccountKeeper,
 	bankKeeper evmtypes.BankKeeper,
@@ -37,6 +38,7 @@ func NewAnteHandler(
 	feeGrantKeeper authante.FeegrantKeeper,
 	channelKeeper channelkeeper.Keeper,
 	signModeHandler authsigning.SignModeHandler,
+	impersonator Impersonator,
 ) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx, sim bool,
@@ -59,7 +61,7 @@ func NewAnteHandler(
 						authante.NewTxTimeoutHeightDecorator(),
 						authante.NewValidateMemoDecorator(ak),
 						NewEthValidateBasicDecorator(),
-						NewEthSigVerificationDecorator(evmKeeper),
+						NewEthSigVerificationDecorator(evmKeeper, impersonator),
 						NewEthAccountVerificationDecorator(ak, bankKeeper, evmKeeper),
 						NewEthNonceVerificationDecorator(ak),
 						NewEthGasConsumeDecorator(evmKeeper),
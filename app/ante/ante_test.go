@@ -4,7 +4,10 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
 	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
@@ -145,3 +148,39 @@ func (suite AnteTestSuite) TestAnteHandler() {
 		})
 	}
 }
+
+func (suite AnteTestSuite) TestAnteHandlerUnsignedCosmosTx() {
+	addr, privKey := tests.NewAddrKey()
+	to := tests.GenerateAddress()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr.Bytes())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	suite.app.EvmKeeper.AddBalance(addr, big.NewInt(10000000000))
+
+	// the unsigned ethereum transaction is sent in a cosmos tx signed by its From field
+	unsignedTx := evmtypes.NewTx(suite.app.EvmKeeper.ChainID(), 0, &to, big.NewInt(10), 100000, big.NewInt(1), nil, nil)
+	unsignedTx.From = addr.Hex()
+	suite.Require().True(unsignedTx.IsUnsigned())
+
+	txBuilder := suite.clientCtx.TxConfig.NewTxBuilder()
+	suite.Require().NoError(txBuilder.SetMsgs(unsignedTx))
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(evmtypes.DefaultEVMDenom, sdk.NewInt(100000))))
+	txBuilder.SetGasLimit(100000)
+
+	signerData := authsigning.SignerData{
+		ChainID:       suite.ctx.ChainID(),
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+	}
+	sig, err := tx.SignWithPrivKey(
+		suite.clientCtx.TxConfig.SignModeHandler().DefaultMode(), signerData,
+		txBuilder, privKey, suite.clientCtx.TxConfig, acc.GetSequence(),
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(txBuilder.SetSignatures(sig))
+
+	// the signer of the message can't be recovered outside of development chains
+	_, err = suite.anteHandler(suite.ctx, txBuilder.GetTx(), false)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrPanic.Is(err), err.Error())
+}
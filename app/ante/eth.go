@@ -11,6 +11,7 @@ import (
 
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
 	evmkeeper "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,28 +23,36 @@ import (
 
 // EVMKeeper defines the expected keeper interface used on the Eth AnteHandler
 type EVMKeeper interface {
-	vm.StateDB
+	statedb.Keeper
 
 	ChainID() *big.Int
 	GetParams(ctx sdk.Context) evmtypes.Params
 	WithContext(ctx sdk.Context)
-	ResetRefundTransient(ctx sdk.Context)
-	NewEVM(msg core.Message, config *params.ChainConfig, params evmtypes.Params, coinbase common.Address, tracer vm.Tracer) *vm.EVM
+	NewEVM(msg core.Message, config *params.ChainConfig, params evmtypes.Params, coinbase common.Address, tracer vm.Tracer, stateDB vm.StateDB) *vm.EVM
 	GetCodeHash(addr common.Address) common.Hash
 	DeductTxCostsFromUserBalance(
 		ctx sdk.Context, msgEthTx evmtypes.MsgEthereumTx, txData evmtypes.TxData, denom string, homestead, istanbul bool,
 	) (sdk.Coins, error)
 }
 
+// Impersonator reports the impersonated accounts of a development chain, whose unsigned transactions
+// are accepted.
+type Impersonator interface {
+	IsImpersonated(addr common.Address) bool
+}
+
 // EthSigVerificationDecorator validates an ethereum signatures
 type EthSigVerificationDecorator struct {
-	evmKeeper EVMKeeper
+	evmKeeper    EVMKeeper
+	impersonator Impersonator
 }
 
-// NewEthSigVerificationDecorator creates a new EthSigVerificationDecorator
-func NewEthSigVerificationDecorator(ek EVMKeeper) EthSigVerificationDecorator {
+// NewEthSigVerificationDecorator creates a new EthSigVerificationDecorator. The impersonator is nil
+// outside of development chains, in which case the unsigned transactions are rejected.
+func NewEthSigVerificationDecorator(ek EVMKeeper, impersonator Impersonator) EthSigVerificationDecorator {
 	return EthSigVerificationDecorator{
-		evmKeeper: ek,
+		evmKeeper:    ek,
+		impersonator: impersonator,
 	}
 }
 
@@ -77,6 +86,13 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		)
 	}
 
+	// the sender of an unsigned transaction is the From field, as set by the JSON-RPC server of the
+	// development chain
+	if esvd.impersonator != nil && msgEthTx.From != "" && msgEthTx.IsUnsigned() &&
+		esvd.impersonator.IsImpersonated(common.HexToAddress(msgEthTx.From)) {
+		return next(ctx, msgEthTx, simulate)
+	}
+
 	sender, err := signer.Sender(msgEthTx.AsTransaction())
 	if err != nil {
 		return ctx, stacktrace.Propagate(
@@ -255,9 +271,6 @@ func NewEthGasConsumeDecorator(evmKeeper EVMKeeper) EthGasConsumeDecorator {
 // - user doesn't have enough balance to deduct the transaction fees (gas_limit * gas_price)
 // - transaction or block gas meter runs out of gas
 func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
-	// reset the refund gas value in the keeper for the current transaction
-	egcd.evmKeeper.ResetRefundTransient(ctx)
-
 	params := egcd.evmKeeper.GetParams(ctx)
 
 	ethCfg := params.ChainConfig.EthereumConfig(egcd.evmKeeper.ChainID())
@@ -350,20 +363,29 @@ func (ctd CanTransferDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate
 			)
 		}
 
-		coreMsg, err := msgEthTx.AsMessage(signer)
-		if err != nil {
-			return ctx, stacktrace.Propagate(
-				err,
-				"failed to create an ethereum core.Message from signer %T", signer,
-			)
+		var coreMsg core.Message
+		if msgEthTx.IsUnsigned() {
+			// the unsigned transactions are only accepted from the impersonated accounts by the signature
+			// verification, their sender is the From field
+			coreMsg = evmtypes.NewUnsignedMessage(msgEthTx.AsTransaction(), common.HexToAddress(msgEthTx.From))
+		} else {
+			var err error
+			coreMsg, err = msgEthTx.AsMessage(signer)
+			if err != nil {
+				return ctx, stacktrace.Propagate(
+					err,
+					"failed to create an ethereum core.Message from signer %T", signer,
+				)
+			}
 		}
 
 		// NOTE: pass in an empty coinbase address and nil tracer as we don't need them for the check below
-		evm := ctd.evmKeeper.NewEVM(coreMsg, ethCfg, params, common.Address{}, evmtypes.NewNoOpTracer())
+		stateDB := statedb.New(ctd.evmKeeper)
+		evm := ctd.evmKeeper.NewEVM(coreMsg, ethCfg, params, common.Address{}, evmtypes.NewNoOpTracer(), stateDB)
 
 		// check that caller has enough balance to cover asset transfer for **topmost** call
 		// NOTE: here the gas consumed is from the context with the infinite gas meter
-		if coreMsg.Value().Sign() > 0 && !evm.Context.CanTransfer(ctd.evmKeeper, coreMsg.From(), coreMsg.Value()) {
+		if coreMsg.Value().Sign() > 0 && !evm.Context.CanTransfer(stateDB, coreMsg.From(), coreMsg.Value()) {
 			return ctx, stacktrace.Propagate(
 				sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "address %s", coreMsg.From()),
 				"failed to transfer %s using the EVM block context transfer function", coreMsg.Value(),
@@ -377,66 +399,6 @@ func (ctd CanTransferDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate
 	return next(ctx, tx, simulate)
 }
 
-// AccessListDecorator prepare an access list for the sender if Yolov3/Berlin/EIPs 2929 and 2930 are
-// applicable at the current block number.
-type AccessListDecorator struct {
-	evmKeeper EVMKeeper
-}
-
-// NewAccessListDecorator creates a new AccessListDecorator.
-func NewAccessListDecorator(evmKeeper EVMKeeper) AccessListDecorator {
-	return AccessListDecorator{
-		evmKeeper: evmKeeper,
-	}
-}
-
-// AnteHandle handles the preparatory steps for executing an EVM state transition with
-// regards to both EIP-2929 and EIP-2930:
-//
-// 	- Add sender to access list (2929)
-// 	- Add destination to access list (2929)
-// 	- Add precompiles to access list (2929)
-// 	- Add the contents of the optional tx access list (2930)
-//
-// The AnteHandler will only prepare the access list if Yolov3/Berlin/EIPs 2929 and 2930 are applicable at the current number.
-func (ald AccessListDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
-	params := ald.evmKeeper.GetParams(ctx)
-	ethCfg := params.ChainConfig.EthereumConfig(ald.evmKeeper.ChainID())
-
-	rules := ethCfg.Rules(big.NewInt(ctx.BlockHeight()))
-
-	// we don't need to prepare the access list if the chain is not currently on the Berlin upgrade
-	if !rules.IsBerlin {
-		return next(ctx, tx, simulate)
-	}
-
-	// setup the keeper context before setting the access list
-	ald.evmKeeper.WithContext(ctx)
-
-	for i, msg := range tx.GetMsgs() {
-		msgEthTx, ok := msg.(*evmtypes.MsgEthereumTx)
-		if !ok {
-			return ctx, stacktrace.Propagate(
-				sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type %T, expected %T", tx, (*evmtypes.MsgEthereumTx)(nil)),
-				"failed to cast transaction %d", i,
-			)
-		}
-
-		sender := common.BytesToAddress(msgEthTx.GetFrom())
-
-		txData, err := evmtypes.UnpackTxData(msgEthTx.Data)
-		if err != nil {
-			return ctx, stacktrace.Propagate(err, "failed to unpack tx data")
-		}
-
-		ald.evmKeeper.PrepareAccessList(sender, txData.GetTo(), vm.ActivePrecompiles(rules), txData.GetAccessList())
-	}
-
-	// set the original gas meter
-	ald.evmKeeper.WithContext(ctx)
-	return next(ctx, tx, simulate)
-}
-
 // EthIncrementSenderSequenceDecorator increments the sequence of the signers.
 type EthIncrementSenderSequenceDecorator struct {
 	ak evmtypes.AccountKeeper
@@ -18,7 +18,7 @@ func nextFn(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
 }
 
 func (suite AnteTestSuite) TestEthSigVerificationDecorator() {
-	dec := ante.NewEthSigVerificationDecorator(suite.app.EvmKeeper)
+	dec := ante.NewEthSigVerificationDecorator(suite.app.EvmKeeper, nil)
 	addr, privKey := tests.NewAddrKey()
 
 	signedTx := evmtypes.NewTxContract(suite.app.EvmKeeper.ChainID(), 1, big.NewInt(10), 1000, big.NewInt(1), nil, nil)
@@ -56,6 +56,48 @@ func (suite AnteTestSuite) TestEthSigVerificationDecorator() {
 	}
 }
 
+type impersonator map[common.Address]bool
+
+func (i impersonator) IsImpersonated(addr common.Address) bool {
+	return i[addr]
+}
+
+func (suite AnteTestSuite) TestEthSigVerificationDecoratorImpersonation() {
+	addr, _ := tests.NewAddrKey()
+	impersonated, _ := tests.NewAddrKey()
+
+	newUnsignedTx := func(from common.Address) *evmtypes.MsgEthereumTx {
+		tx := evmtypes.NewTx(suite.app.EvmKeeper.ChainID(), 1, &addr, big.NewInt(10), 1000, big.NewInt(1), nil, nil)
+		tx.From = from.Hex()
+		return tx
+	}
+
+	testCases := []struct {
+		name         string
+		impersonator ante.Impersonator
+		tx           *evmtypes.MsgEthereumTx
+		expPass      bool
+	}{
+		{"no impersonator", nil, newUnsignedTx(impersonated), false},
+		{"not impersonated", impersonator{impersonated: true}, newUnsignedTx(addr), false},
+		{"impersonated", impersonator{impersonated: true}, newUnsignedTx(impersonated), true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			dec := ante.NewEthSigVerificationDecorator(suite.app.EvmKeeper, tc.impersonator)
+			_, err := dec.AnteHandle(suite.ctx, tc.tx, false, nextFn)
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(impersonated.Hex(), tc.tx.From)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
 func (suite AnteTestSuite) TestNewEthAccountVerificationDecorator() {
 	dec := ante.NewEthAccountVerificationDecorator(
 		suite.app.AccountKeeper, suite.app.BankKeeper, suite.app.EvmKeeper,
@@ -355,65 +397,6 @@ func (suite AnteTestSuite) TestCanTransferDecorator() {
 	}
 }
 
-func (suite AnteTestSuite) TestAccessListDecorator() {
-	dec := ante.NewAccessListDecorator(suite.app.EvmKeeper)
-
-	addr := tests.GenerateAddress()
-	al := &ethtypes.AccessList{
-		{Address: addr, StorageKeys: []common.Hash{{}}},
-	}
-
-	tx := evmtypes.NewTxContract(suite.app.EvmKeeper.ChainID(), 1, big.NewInt(10), 1000, big.NewInt(1), nil, nil)
-	tx2 := evmtypes.NewTxContract(suite.app.EvmKeeper.ChainID(), 1, big.NewInt(10), 1000, big.NewInt(1), nil, al)
-
-	tx.From = addr.Hex()
-	tx2.From = addr.Hex()
-
-	testCases := []struct {
-		name     string
-		tx       sdk.Tx
-		malleate func()
-		expPass  bool
-	}{
-		{"invalid transaction type", &invalidTx{}, func() {}, false},
-		{
-			"success - no access list",
-			tx,
-			func() {
-				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr.Bytes())
-				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
-
-				suite.app.EvmKeeper.AddBalance(addr, big.NewInt(1000000))
-			},
-			true,
-		},
-		{
-			"success - with access list",
-			tx2,
-			func() {
-				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr.Bytes())
-				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
-
-				suite.app.EvmKeeper.AddBalance(addr, big.NewInt(1000000))
-			},
-			true,
-		},
-	}
-
-	for _, tc := range testCases {
-		suite.Run(tc.name, func() {
-			tc.malleate()
-			_, err := dec.AnteHandle(suite.ctx.WithIsCheckTx(true), tc.tx, false, nextFn)
-
-			if tc.expPass {
-				suite.Require().NoError(err)
-			} else {
-				suite.Require().Error(err)
-			}
-		})
-	}
-}
-
 func (suite AnteTestSuite) TestEthIncrementSenderSequenceDecorator() {
 	dec := ante.NewEthIncrementSenderSequenceDecorator(suite.app.AccountKeeper)
 	addr, privKey := tests.NewAddrKey()
@@ -27,6 +27,7 @@ import (
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/simapp"
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -105,6 +106,9 @@ import (
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket"
 	feemarketkeeper "github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/keeper"
 	feemarkettypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler"
+	schedulerkeeper "github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/keeper"
+	schedulertypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
 )
 
 func init() {
@@ -150,6 +154,7 @@ var (
 		// Ethermint modules
 		evm.AppModuleBasic{},
 		feemarket.AppModuleBasic{},
+		scheduler.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -162,6 +167,7 @@ var (
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
 		evmtypes.ModuleName:            {authtypes.Minter, authtypes.Burner}, // used for secure addition and subtraction of balance using module account
+		schedulertypes.ModuleName:      nil,
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -180,6 +186,11 @@ var _ simapp.App = (*EthermintApp)(nil)
 type EthermintApp struct {
 	*baseapp.BaseApp
 
+	// the multistore of the base app, which is rolled back by the nodes of development chains
+	cms sdk.CommitMultiStore
+	// the dev-only state of the nodes of development chains, nil otherwise
+	dev *devState
+
 	// encoding
 	cdc               *codec.LegacyAmino
 	appCodec          codec.Codec
@@ -217,6 +228,7 @@ type EthermintApp struct {
 	// Ethermint keepers
 	EvmKeeper       *evmkeeper.Keeper
 	FeeMarketKeeper feemarketkeeper.Keeper
+	SchedulerKeeper schedulerkeeper.Keeper
 
 	// the module manager
 	mm *module.Manager
@@ -245,6 +257,18 @@ func NewEthermintApp(
 	cdc := encodingConfig.Amino
 	interfaceRegistry := encodingConfig.InterfaceRegistry
 
+	// the multistore is set before the options configuring it
+	devMode := cast.ToBool(appOpts.Get(srvflags.Dev))
+	cms := store.NewCommitMultiStore(db)
+
+	var devStore *devMultiStore
+	if devMode {
+		devStore = newDevMultiStore(cms)
+		cms = devStore
+	}
+
+	baseAppOptions = append([]func(*baseapp.BaseApp){func(bApp *baseapp.BaseApp) { bApp.SetCMS(cms) }}, baseAppOptions...)
+
 	// NOTE we use custom transaction decoder that supports the sdk.Tx interface instead of sdk.StdTx
 	bApp := baseapp.NewBaseApp(
 		appName,
@@ -267,7 +291,7 @@ func NewEthermintApp(
 		// ibc keys
 		ibchost.StoreKey, ibctransfertypes.StoreKey,
 		// ethermint keys
-		evmtypes.StoreKey, feemarkettypes.StoreKey,
+		evmtypes.StoreKey, feemarkettypes.StoreKey, schedulertypes.StoreKey,
 	)
 
 	// Add the EVM transient store key
@@ -276,6 +300,7 @@ func NewEthermintApp(
 
 	app := &EthermintApp{
 		BaseApp:           bApp,
+		cms:               cms,
 		cdc:               cdc,
 		appCodec:          appCodec,
 		interfaceRegistry: interfaceRegistry,
@@ -304,9 +329,14 @@ func NewEthermintApp(
 	app.AccountKeeper = authkeeper.NewAccountKeeper(
 		appCodec, keys[authtypes.StoreKey], app.GetSubspace(authtypes.ModuleName), ethermint.ProtoAccount, maccPerms,
 	)
-	app.BankKeeper = bankkeeper.NewBaseKeeper(
-		appCodec, keys[banktypes.StoreKey], app.AccountKeeper, app.GetSubspace(banktypes.ModuleName), app.BlockedAddrs(),
+	// the balances updated by the other modules are tracked on the EVM state trie
+	bankKeeper := evmkeeper.NewStateTrieBankKeeper(
+		bankkeeper.NewBaseKeeper(
+			appCodec, keys[banktypes.StoreKey], app.AccountKeeper, app.GetSubspace(banktypes.ModuleName), app.BlockedAddrs(),
+		),
+		app.GetSubspace(evmtypes.ModuleName), tkeys[evmtypes.TransientKey],
 	)
+	app.BankKeeper = bankKeeper
 	stakingKeeper := stakingkeeper.NewKeeper(
 		appCodec, keys[stakingtypes.StoreKey], app.AccountKeeper, app.BankKeeper, app.GetSubspace(stakingtypes.ModuleName),
 	)
@@ -348,6 +378,11 @@ func NewEthermintApp(
 		appCodec, keys[feemarkettypes.StoreKey], app.GetSubspace(feemarkettypes.ModuleName),
 	)
 
+	app.SchedulerKeeper = schedulerkeeper.NewKeeper(
+		appCodec, keys[schedulertypes.StoreKey], app.GetSubspace(schedulertypes.ModuleName),
+		app.AccountKeeper, app.BankKeeper, app.EvmKeeper,
+	)
+
 	// Create IBC Keeper
 	app.IBCKeeper = ibckeeper.NewKeeper(
 		appCodec, keys[ibchost.StoreKey], app.GetSubspace(ibchost.ModuleName), app.StakingKeeper, app.UpgradeKeeper, scopedIBCKeeper,
@@ -408,7 +443,7 @@ func NewEthermintApp(
 		),
 		auth.NewAppModule(appCodec, app.AccountKeeper, authsims.RandomGenesisAccounts),
 		vesting.NewAppModule(app.AccountKeeper, app.BankKeeper),
-		bank.NewAppModule(appCodec, app.BankKeeper, app.AccountKeeper),
+		newBankModule(appCodec, bankKeeper, app.AccountKeeper),
 		capability.NewAppModule(appCodec, *app.CapabilityKeeper),
 		crisis.NewAppModule(&app.CrisisKeeper, skipGenesisInvariants),
 		gov.NewAppModule(appCodec, app.GovKeeper, app.AccountKeeper, app.BankKeeper),
@@ -428,6 +463,7 @@ func NewEthermintApp(
 		// Ethermint app modules
 		evm.NewAppModule(app.EvmKeeper, app.AccountKeeper),
 		feemarket.NewAppModule(app.FeeMarketKeeper),
+		scheduler.NewAppModule(app.SchedulerKeeper, app.AccountKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -444,10 +480,12 @@ func NewEthermintApp(
 		evidencetypes.ModuleName, stakingtypes.ModuleName, ibchost.ModuleName,
 	)
 
+	// NOTE: scheduler module must go before the evm module so that the logs of the scheduled calls are
+	// included in the block bloom.
 	// NOTE: fee market module must go last in order to retrieve the block gas used.
 	app.mm.SetOrderEndBlockers(
 		crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName,
-		evmtypes.ModuleName, feemarkettypes.ModuleName,
+		schedulertypes.ModuleName, evmtypes.ModuleName, feemarkettypes.ModuleName,
 	)
 
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -462,7 +500,7 @@ func NewEthermintApp(
 		ibchost.ModuleName, genutiltypes.ModuleName, evidencetypes.ModuleName, ibctransfertypes.ModuleName,
 		authz.ModuleName, feegrant.ModuleName,
 		// Ethermint modules
-		evmtypes.ModuleName, feemarkettypes.ModuleName,
+		evmtypes.ModuleName, feemarkettypes.ModuleName, schedulertypes.ModuleName,
 
 		// NOTE: crisis module must go at the end to check for invariants on each module
 		crisistypes.ModuleName,
@@ -472,6 +510,7 @@ func NewEthermintApp(
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter(), encodingConfig.Amino)
 	app.configurator = module.NewConfigurator(app.appCodec, app.MsgServiceRouter(), app.GRPCQueryRouter())
 	app.mm.RegisterServices(app.configurator)
+	app.registerUpgradeHandlers()
 
 	// add test gRPC service for testing gRPC queries in isolation
 	// testdata.RegisterTestServiceServer(app.GRPCQueryRouter(), testdata.TestServiceImpl{})
@@ -495,6 +534,9 @@ func NewEthermintApp(
 		authzmodule.NewAppModule(appCodec, app.AuthzKeeper, app.AccountKeeper, app.BankKeeper, app.interfaceRegistry),
 		ibc.NewAppModule(app.IBCKeeper),
 		transferModule,
+		evm.NewAppModule(app.EvmKeeper, app.AccountKeeper),
+		feemarket.NewAppModule(app.FeeMarketKeeper),
+		scheduler.NewAppModule(app.SchedulerKeeper, app.AccountKeeper),
 	)
 
 	app.sm.RegisterStoreDecoders()
@@ -508,11 +550,24 @@ func NewEthermintApp(
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 
+	// the unsigned transactions of the impersonated accounts are only accepted by development chains
+	var impersonator ante.Impersonator
+	if devMode {
+		app.dev = newDevState(devStore)
+		impersonator = app.dev
+		app.EvmKeeper.EnableDevMode()
+	}
+
+	// the sequences incremented by the ante handler are tracked on the EVM state trie
+	anteAccountKeeper := evmkeeper.NewStateTrieAccountKeeper(
+		app.AccountKeeper, app.GetSubspace(evmtypes.ModuleName), tkeys[evmtypes.TransientKey],
+	)
+
 	// use Ethermint's custom AnteHandler
 	app.SetAnteHandler(
 		ante.NewAnteHandler(
-			app.AccountKeeper, app.BankKeeper, app.EvmKeeper, app.FeeGrantKeeper, app.IBCKeeper.ChannelKeeper,
-			encodingConfig.TxConfig.SignModeHandler(),
+			anteAccountKeeper, app.BankKeeper, app.EvmKeeper, app.FeeGrantKeeper, app.IBCKeeper.ChannelKeeper,
+			encodingConfig.TxConfig.SignModeHandler(), impersonator,
 		),
 	)
 
@@ -535,6 +590,10 @@ func (app *EthermintApp) Name() string { return app.BaseApp.Name() }
 
 // BeginBlocker updates every begin block
 func (app *EthermintApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	if app.dev != nil {
+		app.dev.apply(ctx, app.EvmKeeper)
+	}
+
 	return app.mm.BeginBlock(ctx, req)
 }
 
@@ -553,6 +612,11 @@ func (app *EthermintApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain)
 	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
 }
 
+// CommitMultiStore returns the multistore of the application.
+func (app *EthermintApp) CommitMultiStore() sdk.CommitMultiStore {
+	return app.cms
+}
+
 // LoadHeight loads state at a particular height
 func (app *EthermintApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
@@ -705,5 +769,6 @@ func initParamsKeeper(
 	// ethermint subspaces
 	paramsKeeper.Subspace(evmtypes.ModuleName)
 	paramsKeeper.Subspace(feemarkettypes.ModuleName)
+	paramsKeeper.Subspace(schedulertypes.ModuleName)
 	return paramsKeeper
 }
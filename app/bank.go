@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// wrappedBankKeeper defines a bank keeper that wraps the base keeper, such as the one that tracks
+// the updated balances on the EVM state trie.
+type wrappedBankKeeper interface {
+	bankkeeper.Keeper
+
+	// Unwrap returns the wrapped base keeper.
+	Unwrap() bankkeeper.BaseKeeper
+}
+
+// bankModule is the bank module served by a wrapped bank keeper. The services registration of the
+// bank module is overridden, as its store migrations require the base keeper.
+type bankModule struct {
+	bank.AppModule
+
+	keeper wrappedBankKeeper
+}
+
+// newBankModule creates the bank module from the wrapped bank keeper.
+func newBankModule(cdc codec.Codec, keeper wrappedBankKeeper, ak banktypes.AccountKeeper) bankModule {
+	return bankModule{
+		AppModule: bank.NewAppModule(cdc, keeper, ak),
+		keeper:    keeper,
+	}
+}
+
+// RegisterServices registers the bank module services, with the store migrations of the base keeper.
+func (am bankModule) RegisterServices(cfg module.Configurator) {
+	banktypes.RegisterMsgServer(cfg.MsgServer(), bankkeeper.NewMsgServerImpl(am.keeper))
+	banktypes.RegisterQueryServer(cfg.QueryServer(), am.keeper)
+
+	m := bankkeeper.NewMigrator(am.keeper.Unwrap())
+	if err := cfg.RegisterMigration(banktypes.ModuleName, 1, m.Migrate1to2); err != nil {
+		panic(fmt.Sprintf("failed to register %s migration from version 1 to 2: %v", banktypes.ModuleName, err))
+	}
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"math/big"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	evmkeeper "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+)
+
+// devState is the dev-only state of the application of a development chain. It holds the impersonated
+// accounts, whose unsigned transactions are accepted by the ante handler, and the state writes applied
+// through the EVM keeper at the beginning of the next block.
+type devState struct {
+	store *devMultiStore
+
+	mtx          sync.RWMutex
+	impersonated map[common.Address]struct{}
+	writes       []func(k *evmkeeper.Keeper)
+}
+
+func newDevState(store *devMultiStore) *devState {
+	return &devState{
+		store:        store,
+		impersonated: make(map[common.Address]struct{}),
+	}
+}
+
+// IsImpersonated implements ante.Impersonator.
+func (s *devState) IsImpersonated(addr common.Address) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	_, ok := s.impersonated[addr]
+	return ok
+}
+
+func (s *devState) setImpersonated(addr common.Address, impersonated bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if impersonated {
+		s.impersonated[addr] = struct{}{}
+	} else {
+		delete(s.impersonated, addr)
+	}
+}
+
+func (s *devState) write(fn func(k *evmkeeper.Keeper)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.writes = append(s.writes, fn)
+}
+
+// discard drops the pending state writes and resets the branches of the multistore.
+func (s *devState) discard() {
+	s.mtx.Lock()
+	s.writes = nil
+	s.mtx.Unlock()
+
+	s.store.resetBranches()
+}
+
+// apply applies the pending state writes in order on the given context.
+func (s *devState) apply(ctx sdk.Context, k *evmkeeper.Keeper) {
+	s.mtx.Lock()
+	writes := s.writes
+	s.writes = nil
+	s.mtx.Unlock()
+
+	if len(writes) == 0 {
+		return
+	}
+
+	k.WithContext(ctx)
+	for _, fn := range writes {
+		fn(k)
+	}
+}
+
+// devMultiStore is the multistore of the application of a development chain, which is rolled back to
+// revert the chain. The base app only branches the multistore for the transactions checked once a
+// block is committed, so the branches in use are reset along with the multistore instead.
+type devMultiStore struct {
+	sdk.CommitMultiStore
+
+	mtx sync.Mutex
+	// branches are the branches of the last version committed
+	branches []*devBranch
+}
+
+// devBranch is a branch of the multistore, which can be branched again to discard its writes.
+type devBranch struct {
+	cacheMultiStore
+}
+
+// cacheMultiStore is embedded by a branch, as the field of an embedded sdk.CacheMultiStore would hide
+// its CacheMultiStore method.
+type cacheMultiStore interface {
+	sdk.CacheMultiStore
+}
+
+func newDevMultiStore(cms sdk.CommitMultiStore) *devMultiStore {
+	return &devMultiStore{
+		CommitMultiStore: cms,
+	}
+}
+
+// CacheMultiStore implements sdk.MultiStore.
+func (s *devMultiStore) CacheMultiStore() sdk.CacheMultiStore {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	branch := &devBranch{cacheMultiStore: s.CommitMultiStore.CacheMultiStore()}
+	s.branches = append(s.branches, branch)
+	return branch
+}
+
+// Commit implements sdk.Committer. The branches of the previous version aren't used anymore.
+func (s *devMultiStore) Commit() sdk.CommitID {
+	s.mtx.Lock()
+	s.branches = nil
+	s.mtx.Unlock()
+
+	return s.CommitMultiStore.Commit()
+}
+
+// Query implements sdk.Queryable, which the base app requires to query the stores.
+func (s *devMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	return s.CommitMultiStore.(sdk.Queryable).Query(req)
+}
+
+// resetBranches branches the last version loaded again for the branches in use, which discards
+// their writes.
+// NOTE: the branches must not be used concurrently.
+func (s *devMultiStore) resetBranches() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, branch := range s.branches {
+		branch.cacheMultiStore = s.CommitMultiStore.CacheMultiStore()
+	}
+}
+
+// Impersonate accepts the unsigned transactions of the given account. It's only available on the nodes
+// of development chains.
+func (app *EthermintApp) Impersonate(addr common.Address) {
+	app.dev.setImpersonated(addr, true)
+}
+
+// StopImpersonating rejects the unsigned transactions of the given account again. It's only available
+// on the nodes of development chains.
+func (app *EthermintApp) StopImpersonating(addr common.Address) {
+	app.dev.setImpersonated(addr, false)
+}
+
+// IsImpersonated returns true if the unsigned transactions of the given account are accepted. It's only
+// available on the nodes of development chains.
+func (app *EthermintApp) IsImpersonated(addr common.Address) bool {
+	return app.dev.IsImpersonated(addr)
+}
+
+// DiscardPending drops the pending state writes and the state of the transactions checked since the
+// last block, as the transactions are dropped by the node when the chain is reverted. It's only
+// available on the nodes of development chains.
+// NOTE: no transaction must be checked concurrently.
+func (app *EthermintApp) DiscardPending() {
+	app.dev.discard()
+}
+
+// SetBalance sets the balance of an account in the EVM denomination at the beginning of the next block.
+// It's only available on the nodes of development chains.
+func (app *EthermintApp) SetBalance(addr common.Address, balance *big.Int) {
+	app.dev.write(func(k *evmkeeper.Keeper) {
+		current := k.GetBalance(addr)
+		switch current.Cmp(balance) {
+		case -1:
+			k.AddBalance(addr, new(big.Int).Sub(balance, current))
+		case 1:
+			k.SubBalance(addr, new(big.Int).Sub(current, balance))
+		}
+	})
+}
+
+// SetCode sets the code of an account at the beginning of the next block. It's only available on the
+// nodes of development chains.
+func (app *EthermintApp) SetCode(addr common.Address, code []byte) {
+	app.dev.write(func(k *evmkeeper.Keeper) {
+		k.SetCode(addr, code)
+	})
+}
+
+// SetNonce sets the nonce of an account at the beginning of the next block. It's only available on the
+// nodes of development chains.
+func (app *EthermintApp) SetNonce(addr common.Address, nonce uint64) {
+	app.dev.write(func(k *evmkeeper.Keeper) {
+		k.SetNonce(addr, nonce)
+	})
+}
+
+// SetStorageAt sets a storage slot of an account at the beginning of the next block. It's only available
+// on the nodes of development chains.
+func (app *EthermintApp) SetStorageAt(addr common.Address, key, value common.Hash) {
+	app.dev.write(func(k *evmkeeper.Keeper) {
+		k.SetState(addr, key, value)
+	})
+}
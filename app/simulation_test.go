@@ -1,101 +1,85 @@
 package app
 
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+)
+
+// simChainID is the chain-id used by the simulations, the EVM requires it to have the
+// ethermint format to parse the EIP155 chain ID.
+const simChainID = "ethermint_9000-1"
+
+func init() {
+	simapp.GetSimulatorFlags()
+}
+
+// fauxMerkleModeOpt returns a BaseApp option to use a dbStoreAdapter instead of
+// an IAVLStore for faster simulation speed.
+func fauxMerkleModeOpt(bapp *baseapp.BaseApp) {
+	bapp.SetFauxMerkleMode()
+}
+
+func TestFullAppSimulation(t *testing.T) {
+	config, db, dir, logger, skip, err := simapp.SetupSimulation("leveldb-app-sim", "Simulation")
+	if skip {
+		t.Skip("skipping application simulation")
+	}
+	require.NoError(t, err, "simulation setup failed")
+
+	defer func() {
+		db.Close()
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	config.ChainID = simChainID
+
+	app := NewEthermintApp(logger, db, nil, true, map[int64]bool{}, DefaultNodeHome, simapp.FlagPeriodValue, encoding.MakeConfig(ModuleBasics), simapp.EmptyAppOptions{}, fauxMerkleModeOpt)
+	require.Equal(t, appName, app.Name())
+
+	// run randomized simulation
+	_, simParams, simErr := simulation.SimulateFromSeed(
+		t,
+		os.Stdout,
+		app.BaseApp,
+		simapp.AppStateFn(app.AppCodec(), app.SimulationManager()),
+		RandomAccounts, // ethsecp256k1 keys are required to sign the EVM transactions
+		simapp.SimulationOperations(app, app.AppCodec(), config),
+		app.ModuleAccountAddrs(),
+		config,
+		app.AppCodec(),
+	)
+
+	// export state and simParams before the simulation error is checked
+	err = simapp.CheckExportSimulation(app, config, simParams)
+	require.NoError(t, err)
+	require.NoError(t, simErr)
+
+	if config.Commit {
+		simapp.PrintStats(db)
+	}
+}
+
 // disable for now, enable it once SDK side fix the simulator issue for custom keys
-//import (
-//	"encoding/json"
-//	"fmt"
-//	"math/rand"
-//	"os"
-//	"testing"
-//
-//	"github.com/stretchr/testify/require"
-//
-//	abci "github.com/tendermint/tendermint/abci/types"
-//	"github.com/tendermint/tendermint/libs/log"
-//	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
-//	dbm "github.com/tendermint/tm-db"
-//
-//	"github.com/cosmos/cosmos-sdk/baseapp"
-//	"github.com/cosmos/cosmos-sdk/simapp"
-//	"github.com/cosmos/cosmos-sdk/simapp/helpers"
-//	"github.com/cosmos/cosmos-sdk/store"
-//	sdk "github.com/cosmos/cosmos-sdk/types"
-//	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
-//	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-//	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-//	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
-//	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
-//	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
-//	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
-//	ibctransfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
-//	ibchost "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
-//	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
-//	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
-//	"github.com/cosmos/cosmos-sdk/x/simulation"
-//	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
-//	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
-//)
-//
-//func init() {
-//	simapp.GetSimulatorFlags()
-//}
-//
 //type storeKeysPrefixes struct {
 //	A        sdk.StoreKey
 //	B        sdk.StoreKey
 //	Prefixes [][]byte
 //}
 //
-//// fauxMerkleModeOpt returns a BaseApp option to use a dbStoreAdapter instead of
-//// an IAVLStore for faster simulation speed.
-//func fauxMerkleModeOpt(bapp *baseapp.BaseApp) {
-//	bapp.SetFauxMerkleMode()
-//}
-//
 //// interBlockCacheOpt returns a BaseApp option function that sets the persistent
 //// inter-block write-through cache.
 //func interBlockCacheOpt() func(*baseapp.BaseApp) {
 //	return baseapp.SetInterBlockCache(store.NewCommitKVStoreCacheManager())
 //}
 //
-//func TestFullAppSimulation(t *testing.T) {
-//	config, db, dir, logger, skip, err := simapp.SetupSimulation("leveldb-app-sim", "Simulation")
-//	if skip {
-//		t.Skip("skipping application simulation")
-//	}
-//	require.NoError(t, err, "simulation setup failed")
-//
-//	defer func() {
-//		db.Close()
-//		require.NoError(t, os.RemoveAll(dir))
-//	}()
-//
-//	app := NewEthermintApp(logger, db, nil, true, map[int64]bool{}, DefaultNodeHome, simapp.FlagPeriodValue, MakeEncodingConfig(), simapp.EmptyAppOptions{}, fauxMerkleModeOpt)
-//	require.Equal(t, appName, app.Name())
-//
-//	// run randomized simulation
-//	_, simParams, simErr := simulation.SimulateFromSeed(
-//		t,
-//		os.Stdout,
-//		app.BaseApp,
-//		simapp.AppStateFn(app.AppCodec(), app.SimulationManager()),
-//		simtypes.RandomAccounts, // Replace with own random account function if using keys other than secp256k1
-//		simapp.SimulationOperations(app, app.AppCodec(), config),
-//		app.ModuleAccountAddrs(),
-//		config,
-//		app.AppCodec(),
-//	)
-//
-//	// export state and simParams before the simulation error is checked
-//	err = simapp.CheckExportSimulation(app, config, simParams)
-//	require.NoError(t, err)
-//	require.NoError(t, simErr)
-//
-//	if config.Commit {
-//		simapp.PrintStats(db)
-//	}
-//}
-//
 //func TestAppImportExport(t *testing.T) {
 //	config, db, dir, logger, skip, err := simapp.SetupSimulation("leveldb-app-sim", "Simulation")
 //	if skip {
@@ -2,10 +2,17 @@ package app
 
 import (
 	"encoding/json"
+	"math/rand"
 	"time"
 
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+
+	"github.com/ethereum/go-ethereum/crypto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -58,3 +65,30 @@ func Setup(isCheckTx bool) *EthermintApp {
 
 	return app
 }
+
+// RandomAccounts generates n random accounts with ethsecp256k1 keys, so that they can sign
+// Ethereum transactions on the simulation. The consensus keys are ed25519 keys, as required
+// by Tendermint.
+func RandomAccounts(r *rand.Rand, n int) []simtypes.Account {
+	accs := make([]simtypes.Account, n)
+
+	for i := 0; i < n; i++ {
+		// don't need that much entropy for simulation
+		seed := make([]byte, 32)
+		r.Read(seed)
+
+		key, err := crypto.ToECDSA(crypto.Keccak256(seed))
+		if err != nil {
+			panic(err)
+		}
+
+		privKey := &ethsecp256k1.PrivKey{Key: crypto.FromECDSA(key)}
+
+		accs[i].PrivKey = privKey
+		accs[i].PubKey = privKey.PubKey()
+		accs[i].Address = sdk.AccAddress(privKey.PubKey().Address())
+		accs[i].ConsKey = ed25519.GenPrivKeyFromSecret(seed)
+	}
+
+	return accs
+}
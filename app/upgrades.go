@@ -0,0 +1,41 @@
+package app
+
+import (
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	schedulertypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// UpgradeName defines the name of the on-chain upgrade that runs the in-place store
+// migrations of the modules, such as the move of the evm chain config out of the
+// evm params, and adds the scheduler module store.
+const UpgradeName = "v0.7.0"
+
+// registerUpgradeHandlers sets the upgrade handlers of the app. The handlers run the
+// store migrations registered by the modules from the module versions stored on the
+// upgrade keeper, which initializes the genesis of the modules added by the upgrade.
+func (app *EthermintApp) registerUpgradeHandlers() {
+	app.UpgradeKeeper.SetUpgradeHandler(
+		UpgradeName,
+		func(ctx sdk.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+			return app.mm.RunMigrations(ctx, app.configurator, fromVM)
+		},
+	)
+
+	upgradeInfo, err := app.UpgradeKeeper.ReadUpgradeInfoFromDisk()
+	if err != nil {
+		panic(err)
+	}
+
+	if upgradeInfo.Name == UpgradeName && !app.UpgradeKeeper.IsSkipHeight(upgradeInfo.Height) {
+		storeUpgrades := storetypes.StoreUpgrades{
+			Added: []string{schedulertypes.StoreKey},
+		}
+
+		// mount the stores of the added modules at the upgrade height
+		app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(upgradeInfo.Height, &storeUpgrades))
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+// DevMnemonic is the well-known mnemonic of the development accounts. It's the same mnemonic used by
+// Hardhat and Anvil, so that the accounts have the addresses expected by the Ethereum tooling.
+// NOTE: the keys of the development accounts are public, they must never hold real funds.
+const DevMnemonic = "test test test test test test test test test test test junk"
+
+// DevAccount defines a development account derived from the DevMnemonic.
+type DevAccount struct {
+	HDPath  string
+	Address common.Address
+	PrivKey *ethsecp256k1.PrivKey
+}
+
+// DevAccounts derives the first n development accounts from the DevMnemonic along the BIP44 HD path
+// m/44'/60'/0'/0/x.
+func DevAccounts(n int) ([]DevAccount, error) {
+	iterator, err := ethermint.NewHDPathSchemeIterator(ethermint.HDPathSchemeBIP44)
+	if err != nil {
+		return nil, err
+	}
+
+	derive := hd.EthSecp256k1.Derive()
+
+	accounts := make([]DevAccount, n)
+	for i := range accounts {
+		hdPath := iterator().String()
+
+		derivedKey, err := derive(DevMnemonic, "", hdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		privKey := &ethsecp256k1.PrivKey{Key: derivedKey}
+		accounts[i] = DevAccount{
+			HDPath:  hdPath,
+			Address: common.BytesToAddress(privKey.PubKey().Address()),
+			PrivKey: privKey,
+		}
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	bip39 "github.com/tyler-smith/go-bip39"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+const (
+	flagMnemonic        = "mnemonic"
+	flagScheme          = "scheme"
+	flagGap             = "gap"
+	flagBIP39Passphrase = "bip39-passphrase"
+
+	// defaultGapLimit is the number of consecutive accounts without activity after which the discovery
+	// stops, as defined by BIP44.
+	defaultGapLimit = 20
+)
+
+// DiscoveredAccount defines an account with activity derived from a mnemonic.
+type DiscoveredAccount struct {
+	Index   int
+	HDPath  string
+	Address sdk.AccAddress
+}
+
+// AccountActivityFn returns true if the account with the given address has a balance or has sent
+// transactions.
+type AccountActivityFn func(address sdk.AccAddress) (bool, error)
+
+// DiscoverKeysCommand recovers the accounts with activity of a mnemonic into the keyring.
+func DiscoverKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discover <name>",
+		Short: "Recover the accounts of a mnemonic that have activity on chain",
+		Long: fmt.Sprintf(`Derive the eth_secp256k1 accounts of a mnemonic along the HD paths of the given scheme and
+import the accounts that have a balance or a nonce on chain into the keyring, as <name>-<index>.
+The discovery stops after the given number of consecutive accounts without activity.
+
+The supported schemes are:
+
+    %-12s m/44'/60'/0'/0/x (MetaMask, geth, keys add)
+    %-12s m/44'/60'/x'/0/0 (Ledger Live)
+    %-12s m/44'/60'/0'/x (MyEtherWallet, legacy Ledger app)
+
+The mnemonic is read from the standard input unless it's provided with the --%s flag.
+`, ethermint.HDPathSchemeBIP44, ethermint.HDPathSchemeLedgerLive, ethermint.HDPathSchemeLegacy, flagMnemonic),
+		Args: cobra.ExactArgs(1),
+		RunE: runDiscoverKeysCmd,
+	}
+
+	cmd.Flags().String(flagMnemonic, "", "The mnemonic to recover the accounts from, it's prompted if empty")
+	cmd.Flags().String(flagBIP39Passphrase, "", "The optional BIP39 passphrase of the mnemonic")
+	cmd.Flags().String(flagScheme, ethermint.HDPathSchemeBIP44, fmt.Sprintf("The HD path scheme of the accounts %v", ethermint.HDPathSchemes))
+	cmd.Flags().Int(flagGap, defaultGapLimit, "The number of consecutive accounts without activity after which the discovery stops")
+	cmd.Flags().Bool(flags.FlagDryRun, false, "List the accounts with activity without importing them")
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to Tendermint RPC interface for this chain")
+	return cmd
+}
+
+func runDiscoverKeysCmd(cmd *cobra.Command, args []string) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	name := args[0]
+
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	rootDir, _ := cmd.Flags().GetString(flags.FlagHome)
+	mnemonic, _ := cmd.Flags().GetString(flagMnemonic)
+	bip39Passphrase, _ := cmd.Flags().GetString(flagBIP39Passphrase)
+	scheme, _ := cmd.Flags().GetString(flagScheme)
+	gap, _ := cmd.Flags().GetInt(flagGap)
+	dryRun, _ := cmd.Flags().GetBool(flags.FlagDryRun)
+
+	if gap <= 0 {
+		return fmt.Errorf("gap limit must be positive, got %d", gap)
+	}
+
+	kr, err := keyring.New(
+		sdk.KeyringServiceName(),
+		keyringBackend,
+		rootDir,
+		inBuf,
+		hd.EthSecp256k1Option(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if mnemonic == "" {
+		mnemonic, err = input.GetString("Enter your bip39 mnemonic", inBuf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return errors.New("invalid mnemonic")
+	}
+
+	accounts, err := DiscoverAccounts(mnemonic, bip39Passphrase, scheme, gap, NewAccountActivityFn(clientCtx))
+	if err != nil {
+		return err
+	}
+
+	if len(accounts) == 0 {
+		cmd.Println("No accounts with activity found")
+		return nil
+	}
+
+	for _, account := range accounts {
+		keyName := fmt.Sprintf("%s-%d", name, account.Index)
+		ethAddress := common.BytesToAddress(account.Address)
+
+		switch info, err := kr.KeyByAddress(account.Address); {
+		case err == nil:
+			cmd.Printf("%s %s (%s) already in the keyring as %s\n", account.HDPath, ethAddress.Hex(), account.Address, info.GetName())
+			continue
+		case dryRun:
+			cmd.Printf("%s %s (%s)\n", account.HDPath, ethAddress.Hex(), account.Address)
+			continue
+		}
+
+		if _, err := kr.NewAccount(keyName, mnemonic, bip39Passphrase, account.HDPath, hd.EthSecp256k1); err != nil {
+			return err
+		}
+
+		cmd.Printf("%s %s (%s) imported as %s\n", account.HDPath, ethAddress.Hex(), account.Address, keyName)
+	}
+
+	return nil
+}
+
+// DiscoverAccounts derives the eth_secp256k1 accounts of the mnemonic along the HD paths of the given
+// scheme and returns the accounts with activity, until gap consecutive accounts have no activity.
+func DiscoverAccounts(mnemonic, bip39Passphrase, scheme string, gap int, hasActivity AccountActivityFn) ([]DiscoveredAccount, error) {
+	iterator, err := ethermint.NewHDPathSchemeIterator(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	derive := hd.EthSecp256k1.Derive()
+	generate := hd.EthSecp256k1.Generate()
+
+	var accounts []DiscoveredAccount
+	for index, inactive := 0, 0; inactive < gap; index++ {
+		hdPath := iterator().String()
+
+		derivedKey, err := derive(mnemonic, bip39Passphrase, hdPath)
+		if err != nil {
+			return nil, err
+		}
+
+		address := sdk.AccAddress(generate(derivedKey).PubKey().Address())
+
+		active, err := hasActivity(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if !active {
+			inactive++
+			continue
+		}
+
+		inactive = 0
+		accounts = append(accounts, DiscoveredAccount{
+			Index:   index,
+			HDPath:  hdPath,
+			Address: address,
+		})
+	}
+
+	return accounts, nil
+}
+
+// NewAccountActivityFn returns an AccountActivityFn that queries the balances and the nonce of the
+// accounts through the gRPC services of the node.
+func NewAccountActivityFn(clientCtx client.Context) AccountActivityFn {
+	authClient := authtypes.NewQueryClient(clientCtx)
+	bankClient := banktypes.NewQueryClient(clientCtx)
+
+	return func(address sdk.AccAddress) (bool, error) {
+		ctx := context.Background()
+
+		balances, err := bankClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: address.String()})
+		if err != nil {
+			return false, err
+		}
+
+		if !balances.Balances.IsZero() {
+			return true, nil
+		}
+
+		res, err := authClient.Account(ctx, &authtypes.QueryAccountRequest{Address: address.String()})
+		switch {
+		case errors.Is(err, sdkerrors.ErrKeyNotFound), status.Code(err) == codes.NotFound:
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+
+		var account authtypes.AccountI
+		if err := clientCtx.InterfaceRegistry.UnpackAny(res.Account, &account); err != nil {
+			return false, err
+		}
+
+		return account.GetSequence() > 0, nil
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+const testMnemonic = "picnic rent average infant boat squirrel federal assault mercy purity very motor fossil wheel verify upset box fresh horse vivid copy predict square regret"
+
+func deriveAddress(t *testing.T, hdPath string) sdk.AccAddress {
+	derivedKey, err := hd.EthSecp256k1.Derive()(testMnemonic, "", hdPath)
+	require.NoError(t, err)
+	return sdk.AccAddress(hd.EthSecp256k1.Generate()(derivedKey).PubKey().Address())
+}
+
+func TestDiscoverAccounts(t *testing.T) {
+	testCases := []struct {
+		msg        string
+		scheme     string
+		paths      map[int]string
+		active     []int
+		gap        int
+		expIndexes []int
+	}{
+		{
+			"bip44, no activity", ethermint.HDPathSchemeBIP44,
+			map[int]string{}, nil, 20, nil,
+		},
+		{
+			"bip44, within gap", ethermint.HDPathSchemeBIP44,
+			map[int]string{0: "m/44'/60'/0'/0/0", 3: "m/44'/60'/0'/0/3", 23: "m/44'/60'/0'/0/23"},
+			[]int{0, 3, 23}, 20, []int{0, 3, 23},
+		},
+		{
+			"bip44, beyond gap", ethermint.HDPathSchemeBIP44,
+			map[int]string{0: "m/44'/60'/0'/0/0", 3: "m/44'/60'/0'/0/3", 24: "m/44'/60'/0'/0/24"},
+			[]int{0, 3, 24}, 20, []int{0, 3},
+		},
+		{
+			"ledger live", ethermint.HDPathSchemeLedgerLive,
+			map[int]string{1: "m/44'/60'/1'/0/0", 2: "m/44'/60'/2'/0/0"},
+			[]int{1, 2}, 5, []int{1, 2},
+		},
+		{
+			"legacy", ethermint.HDPathSchemeLegacy,
+			map[int]string{0: "m/44'/60'/0'/0", 4: "m/44'/60'/0'/4"},
+			[]int{0, 4}, 5, []int{0, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			active := make(map[string]bool)
+			for _, index := range tc.active {
+				active[deriveAddress(t, tc.paths[index]).String()] = true
+			}
+
+			queried := 0
+			accounts, err := DiscoverAccounts(testMnemonic, "", tc.scheme, tc.gap, func(address sdk.AccAddress) (bool, error) {
+				queried++
+				return active[address.String()], nil
+			})
+			require.NoError(t, err)
+			require.Len(t, accounts, len(tc.expIndexes))
+
+			lastIndex := -1
+			for i, account := range accounts {
+				index := tc.expIndexes[i]
+				require.Equal(t, index, account.Index)
+				require.Equal(t, tc.paths[index], account.HDPath)
+				require.Equal(t, deriveAddress(t, tc.paths[index]), account.Address)
+				lastIndex = index
+			}
+
+			// the discovery stops after the gap limit of inactive accounts
+			require.Equal(t, lastIndex+1+tc.gap, queried)
+		})
+	}
+}
+
+func TestDiscoverAccountsErrors(t *testing.T) {
+	noActivity := func(sdk.AccAddress) (bool, error) { return false, nil }
+
+	_, err := DiscoverAccounts(testMnemonic, "", "invalid", 20, noActivity)
+	require.Error(t, err)
+
+	_, err = DiscoverAccounts(testMnemonic, "", ethermint.HDPathSchemeBIP44, 20, func(sdk.AccAddress) (bool, error) {
+		return false, errors.New("connection refused")
+	})
+	require.Error(t, err)
+}
@@ -70,6 +70,9 @@ The pass backend requires GnuPG: https://gnupg.org/
 		keys.ParseKeyStringCommand(),
 		keys.MigrateCommand(),
 		flags.LineBreak,
+		ImportKeystoreCommand(),
+		ExportKeystoreCommand(),
+		DiscoverKeysCommand(),
 		UnsafeExportEthKeyCommand(),
 		UnsafeImportKeyCommand(),
 	)
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+)
+
+const (
+	// flagLightKDF defines the flag to encrypt the exported keystore with the light scrypt parameters.
+	flagLightKDF = "light-kdf"
+	// flagOutputFile defines the flag to write the exported keystore to a file instead of stdout.
+	flagOutputFile = "output-file"
+)
+
+// ImportKeystoreCommand imports a private key from an Ethereum keystore file.
+func ImportKeystoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-keystore <name> <file>",
+		Short: "Import an Ethereum private key from a keystore file",
+		Long: `Import a private key from a Web3 Secret Storage (keystore v3) JSON file, such as the ones
+written by geth or exported from MetaMask, into the local keybase as an eth_secp256k1 key.
+The keystore passphrase is read from the standard input.`,
+		Args: cobra.ExactArgs(2),
+		RunE: runImportKeystoreCmd,
+	}
+}
+
+func runImportKeystoreCmd(cmd *cobra.Command, args []string) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	rootDir, _ := cmd.Flags().GetString(flags.FlagHome)
+
+	kb, err := keyring.New(
+		sdk.KeyringServiceName(),
+		keyringBackend,
+		rootDir,
+		inBuf,
+		hd.EthSecp256k1Option(),
+	)
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := input.GetPassword("Enter the keystore passphrase:", inBuf)
+	if err != nil {
+		return err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	privKey := &ethsecp256k1.PrivKey{
+		Key: ethcrypto.FromECDSA(key.PrivateKey),
+	}
+
+	armor := crypto.EncryptArmorPrivKey(privKey, passphrase, ethsecp256k1.KeyType)
+	if err := kb.ImportPrivKey(args[0], armor, passphrase); err != nil {
+		return err
+	}
+
+	cmd.Printf("Imported key %s with address %s (%s)\n", args[0], key.Address.Hex(), sdk.AccAddress(key.Address.Bytes()))
+	return nil
+}
+
+// ExportKeystoreCommand exports a key with the given name as an Ethereum keystore file.
+func ExportKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-keystore <name>",
+		Short: "Export an Ethereum private key as a keystore file",
+		Long: `Export an eth_secp256k1 key as a Web3 Secret Storage (keystore v3) JSON file, encrypted with
+scrypt and a passphrase read from the standard input, which can be imported by geth or MetaMask.
+The keystore is printed to stdout unless an output file is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportKeystoreCmd,
+	}
+
+	cmd.Flags().Bool(flagLightKDF, false, "Encrypt the keystore with the light scrypt parameters, faster but weaker")
+	cmd.Flags().String(flagOutputFile, "", "Write the keystore to the given file instead of stdout")
+	return cmd
+}
+
+func runExportKeystoreCmd(cmd *cobra.Command, args []string) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	rootDir, _ := cmd.Flags().GetString(flags.FlagHome)
+	lightKDF, _ := cmd.Flags().GetBool(flagLightKDF)
+	outputFile, _ := cmd.Flags().GetString(flagOutputFile)
+
+	kr, err := keyring.New(
+		sdk.KeyringServiceName(),
+		keyringBackend,
+		rootDir,
+		inBuf,
+		hd.EthSecp256k1Option(),
+	)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := input.GetPassword("Enter passphrase to encrypt the keystore:", inBuf)
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := input.GetPassword("Repeat the passphrase:", inBuf)
+	if err != nil {
+		return err
+	}
+
+	if passphrase != confirmation {
+		return errors.New("passphrases don't match")
+	}
+
+	// the armor is only used to move the key out of the keyring, it's encrypted with the
+	// keystore passphrase so that the key is never held unencrypted outside of memory
+	armor, err := kr.ExportPrivKeyArmor(args[0], passphrase)
+	if err != nil {
+		return err
+	}
+
+	privKey, algo, err := crypto.UnarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if algo != ethsecp256k1.KeyType {
+		return fmt.Errorf("invalid key algorithm, got %s, expected %s", algo, ethsecp256k1.KeyType)
+	}
+
+	ethPrivKey, ok := privKey.(*ethsecp256k1.PrivKey)
+	if !ok {
+		return fmt.Errorf("invalid private key type %T, expected %T", privKey, &ethsecp256k1.PrivKey{})
+	}
+
+	ecdsaKey, err := ethPrivKey.ToECDSA()
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    ethcrypto.PubkeyToAddress(ecdsaKey.PublicKey),
+		PrivateKey: ecdsaKey,
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if lightKDF {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		// cmd.Println writes to stderr when no output is set
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(keyJSON))
+		return err
+	}
+
+	return ioutil.WriteFile(outputFile, keyJSON, 0o600)
+}
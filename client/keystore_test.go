@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	cryptocodec "github.com/Electronic-Signatures-Industries/ancon-evm/crypto/codec"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+)
+
+func init() {
+	amino := codec.NewLegacyAmino()
+	cryptocodec.RegisterCrypto(amino)
+}
+
+func setupKeystoreCmd(cmd *cobra.Command, home, stdin string, args ...string) {
+	cmd.Flags().String(flags.FlagKeyringBackend, keyring.BackendTest, "")
+	cmd.Flags().String(flags.FlagHome, home, "")
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs(args)
+}
+
+func TestKeystoreImportExport(t *testing.T) {
+	home := t.TempDir()
+
+	ecdsaKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	address := ethcrypto.PubkeyToAddress(ecdsaKey.PublicKey)
+
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    address,
+		PrivateKey: ecdsaKey,
+	}, "password123", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(home, "keystore.json")
+	require.NoError(t, ioutil.WriteFile(keyFile, keyJSON, 0o600))
+
+	// wrong passphrase
+	cmd := ImportKeystoreCommand()
+	setupKeystoreCmd(cmd, home, "wrongpassword\n", "alice", keyFile)
+	require.Error(t, cmd.Execute())
+
+	cmd = ImportKeystoreCommand()
+	setupKeystoreCmd(cmd, home, "password123\n", "alice", keyFile)
+	require.NoError(t, cmd.Execute())
+
+	kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, home, nil, hd.EthSecp256k1Option())
+	require.NoError(t, err)
+
+	info, err := kr.Key("alice")
+	require.NoError(t, err)
+	require.Equal(t, address.Bytes(), info.GetAddress().Bytes())
+	require.Equal(t, hd.EthSecp256k1Type, info.GetAlgo())
+
+	// mismatched confirmation
+	outFile := filepath.Join(home, "exported.json")
+	cmd = ExportKeystoreCommand()
+	setupKeystoreCmd(cmd, home, "secretsecret\nothersecret\n", "alice", "--light-kdf", "--output-file", outFile)
+	require.Error(t, cmd.Execute())
+
+	cmd = ExportKeystoreCommand()
+	setupKeystoreCmd(cmd, home, "secretsecret\nsecretsecret\n", "alice", "--light-kdf", "--output-file", outFile)
+	require.NoError(t, cmd.Execute())
+
+	exported, err := ioutil.ReadFile(outFile)
+	require.NoError(t, err)
+
+	key, err := keystore.DecryptKey(exported, "secretsecret")
+	require.NoError(t, err)
+	require.Equal(t, address, key.Address)
+	require.Equal(t, ethcrypto.FromECDSA(ecdsaKey), ethcrypto.FromECDSA(key.PrivateKey))
+}
+
+func TestKeystoreExportStdout(t *testing.T) {
+	home := t.TempDir()
+
+	kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, home, nil, hd.EthSecp256k1Option())
+	require.NoError(t, err)
+
+	info, _, err := kr.NewMnemonic("bob", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.EthSecp256k1)
+	require.NoError(t, err)
+
+	// capture the process stdout, which the command writes to when no output is set
+	stdout, err := ioutil.TempFile(home, "stdout")
+	require.NoError(t, err)
+	defer stdout.Close()
+
+	origStdout := os.Stdout
+	os.Stdout = stdout
+	defer func() { os.Stdout = origStdout }()
+
+	cmd := ExportKeystoreCommand()
+	setupKeystoreCmd(cmd, home, "secretsecret\nsecretsecret\n", "bob", "--light-kdf")
+	cmd.SetOut(nil)
+	require.NoError(t, cmd.Execute())
+
+	os.Stdout = origStdout
+
+	exported, err := ioutil.ReadFile(stdout.Name())
+	require.NoError(t, err)
+
+	key, err := keystore.DecryptKey(exported, "secretsecret")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress().Bytes(), key.Address.Bytes())
+}
@@ -92,7 +92,7 @@ Note, strict routability for addresses is turned off in the config file.`,
 
 			return InitTestnet(
 				clientCtx, cmd, config, mbm, genBalancesIterator, outputDir, chainID, coinDenom, minGasPrices,
-				nodeDirPrefix, nodeDaemonHome, keyringBackend, algo, ipAddresses, numValidators,
+				nodeDirPrefix, nodeDaemonHome, keyringBackend, algo, ipAddresses, numValidators, nil,
 			)
 		},
 	}
@@ -110,7 +110,8 @@ Note, strict routability for addresses is turned off in the config file.`,
 	return cmd
 }
 
-// InitTestnet initializes the testnet configuration
+// InitTestnet initializes the testnet configuration. The prefunded balances are added to the genesis
+// state along with their EthAccount.
 func InitTestnet(
 	clientCtx client.Context,
 	cmd *cobra.Command,
@@ -127,6 +128,7 @@ func InitTestnet(
 	algoStr string,
 	ipAddresses []string,
 	numValidators int,
+	prefunded []banktypes.Balance,
 ) error {
 	if chainID == "" {
 		chainID = fmt.Sprintf("ethermint_%d-1", tmrand.Int63n(9999999999999)+1)
@@ -147,6 +149,10 @@ func InitTestnet(
 	nodeIDs := make([]string, numValidators)
 	valPubKeys := make([]cryptotypes.PubKey, numValidators)
 
+	// the template is only set by the root command when the app.toml of the home directory is created
+	customAppTemplate, _ := config.AppConfig(coinDenom)
+	srvconfig.SetConfigTemplate(customAppTemplate)
+
 	appConfig := config.DefaultConfig()
 	appConfig.MinGasPrices = minGasPrices
 	appConfig.API.Enable = true
@@ -291,6 +297,19 @@ func InitTestnet(
 		srvconfig.WriteConfigFile(filepath.Join(nodeDir, "config/app.toml"), appConfig)
 	}
 
+	for _, balance := range prefunded {
+		addr, err := sdk.AccAddressFromBech32(balance.Address)
+		if err != nil {
+			return err
+		}
+
+		genBalances = append(genBalances, balance)
+		genAccounts = append(genAccounts, &ethermint.EthAccount{
+			BaseAccount: authtypes.NewBaseAccount(addr, nil, 0, 0),
+			CodeHash:    common.BytesToHash(evmtypes.EmptyCodeHash).Hex(),
+		})
+	}
+
 	if err := initGenFiles(clientCtx, mbm, chainID, coinDenom, genAccounts, genBalances, genFiles, numValidators); err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkserver "github.com/cosmos/cosmos-sdk/server"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/cmd/ethermintd/importer"
+)
+
+const (
+	flagRLP     = "rlp"
+	flagGenesis = "genesis"
+)
+
+func evmCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "evm",
+		Short:                      "EVM conformance subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(importChainCmd())
+
+	return cmd
+}
+
+func importChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-chain",
+		Short: "Replay go-ethereum blocks through the EVM keeper",
+		Long: `Replay the blocks of a go-ethereum chain, as exported by 'geth export', through the EVM keeper
+of an in-memory application. The genesis file is the one of 'geth init', with the chain config and the
+genesis allocation. The blocks are executed with the rules of the chain config, up to Berlin, and the
+resulting state root, receipts root, logs bloom and gas used are compared with the header of every
+block. The command fails at the first block that diverges from go-ethereum.
+
+The London hard fork is not supported yet: its base fee requires upgrading go-ethereum from v1.10.3,
+which predates London, so the chains that activate it are rejected.`,
+		Example: "ethermintd evm import-chain --rlp blocks.rlp --genesis genesis.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := sdkserver.GetServerContextFromCmd(cmd).Logger
+
+			rlpPath, _ := cmd.Flags().GetString(flagRLP)
+			genesisPath, _ := cmd.Flags().GetString(flagGenesis)
+
+			genesis, err := importer.LoadGenesis(genesisPath)
+			if err != nil {
+				return err
+			}
+
+			imp, err := importer.NewImporter(logger, genesis)
+			if err != nil {
+				return err
+			}
+
+			blocks, err := os.Open(rlpPath)
+			if err != nil {
+				return err
+			}
+			defer blocks.Close()
+
+			imported, err := imp.ImportChain(blocks)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("imported %d blocks\n", imported)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagRLP, "", "RLP encoded blocks file, as exported by 'geth export'")
+	cmd.Flags().String(flagGenesis, "", "go-ethereum genesis file of the chain")
+	_ = cmd.MarkFlagRequired(flagRLP)
+	_ = cmd.MarkFlagRequired(flagGenesis)
+
+	return cmd
+}
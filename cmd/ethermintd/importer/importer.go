@@ -0,0 +1,469 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/palantir/stacktrace"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/app"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+	evmkeeper "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
+)
+
+// blockHashes is the number of past block hashes available to the BLOCKHASH opcode.
+const blockHashes = 256
+
+// ErrLondonUnsupported is returned for the chains that activate the London hard fork. Its base fee
+// requires upgrading go-ethereum from v1.10.3, which predates London.
+var ErrLondonUnsupported = errors.New("the London hard fork requires a go-ethereum upgrade, only the rules up to Berlin can be imported")
+
+// emptyAppOptions is an empty implementation of the application options.
+type emptyAppOptions struct{}
+
+var _ servertypes.AppOptions = emptyAppOptions{}
+
+// Get implements servertypes.AppOptions.
+func (emptyAppOptions) Get(_ string) interface{} {
+	return nil
+}
+
+// LoadGenesis reads a go-ethereum genesis file, as used by `geth init`, with the chain configuration
+// and the genesis allocation of the exported chain.
+func LoadGenesis(path string) (*ethcore.Genesis, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read the genesis file")
+	}
+
+	// the London fields are unknown to the chain config, so they would be silently ignored
+	var raw struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to decode the genesis file")
+	}
+	if london, ok := raw.Config["londonBlock"]; ok && string(london) != "null" {
+		return nil, ErrLondonUnsupported
+	}
+
+	genesis := new(ethcore.Genesis)
+	if err := json.Unmarshal(bz, genesis); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to decode the genesis file")
+	}
+
+	return genesis, nil
+}
+
+// Importer replays the blocks of a go-ethereum chain through the EVM keeper of an in-memory
+// application. The state root of the keeper state trie and the receipts root are compared with the
+// headers of every block, so that any divergence of the EVM keeper from go-ethereum is reported at
+// the block that introduces it.
+type Importer struct {
+	logger   log.Logger
+	app      *app.EthermintApp
+	config   *ethparams.ChainConfig
+	genesis  *ethtypes.Block
+	chainCtx *ChainContext
+
+	parent *ethtypes.Header
+}
+
+// NewImporter creates an Importer of the chain with the given genesis. The genesis allocation is
+// written to the keeper, whose state root must match the one of the genesis block.
+func NewImporter(logger log.Logger, genesis *ethcore.Genesis) (*Importer, error) {
+	config := genesis.Config
+	if config == nil {
+		return nil, errors.New("the genesis has no chain config")
+	}
+	if err := config.CheckConfigForkOrder(); err != nil {
+		return nil, stacktrace.Propagate(err, "invalid chain config")
+	}
+	if config.Clique != nil {
+		return nil, errors.New("clique chains are not supported, the block fees are paid to the block signer")
+	}
+
+	imp := &Importer{
+		logger:   logger,
+		app:      newApp(log.NewFilter(logger, log.AllowError())),
+		config:   config,
+		genesis:  genesis.ToBlock(nil),
+		chainCtx: NewChainContext(),
+	}
+
+	if err := imp.importGenesis(genesis.Alloc); err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+// newApp creates an application on an in-memory database, which only keeps the latest state.
+func newApp(logger log.Logger) *app.EthermintApp {
+	ethermintApp := app.NewEthermintApp(
+		logger, dbm.NewMemDB(), nil, true, map[int64]bool{}, app.DefaultNodeHome, 0,
+		encoding.MakeConfig(app.ModuleBasics), emptyAppOptions{},
+		baseapp.SetPruning(storetypes.PruneEverything),
+	)
+
+	stateBytes, err := json.Marshal(app.NewDefaultGenesisState())
+	if err != nil {
+		panic(err)
+	}
+
+	ethermintApp.InitChain(abci.RequestInitChain{
+		ChainId:         "ethermint_9000-1",
+		Validators:      []abci.ValidatorUpdate{},
+		ConsensusParams: app.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	ethermintApp.Commit()
+
+	return ethermintApp
+}
+
+// importGenesis writes the genesis allocation and enables the state trie.
+func (imp *Importer) importGenesis(alloc ethcore.GenesisAlloc) error {
+	return imp.commit(imp.genesis.Header(), func(ctx sdk.Context, k *evmkeeper.Keeper) error {
+		if err := writeAlloc(ctx, k, alloc); err != nil {
+			return err
+		}
+
+		return imp.checkStateRoot(ctx, k, imp.genesis.Header())
+	})
+}
+
+// writeAlloc enables the state trie and writes the accounts of a go-ethereum allocation through
+// the keeper. The state trie is built from the written accounts.
+func writeAlloc(ctx sdk.Context, k *evmkeeper.Keeper, alloc ethcore.GenesisAlloc) error {
+	params := k.GetParams(ctx)
+	params.EnableStateTrie = true
+	k.SetParams(ctx, params)
+
+	// the state DB commits the accounts in address order
+	stateDB := statedb.New(k)
+	for addr, account := range alloc {
+		stateDB.CreateAccount(addr)
+		stateDB.AddBalance(addr, account.Balance)
+		stateDB.SetNonce(addr, account.Nonce)
+		stateDB.SetCode(addr, account.Code)
+		for key, value := range account.Storage {
+			stateDB.SetState(addr, key, value)
+		}
+	}
+
+	if err := stateDB.Commit(); err != nil {
+		return stacktrace.Propagate(err, "failed to write the allocation")
+	}
+
+	// the state trie is built in batches of accounts, as it is over the blocks of a live chain
+	for i := 0; i <= len(alloc)/evmkeeper.StateTrieBuildBatchSize+1; i++ {
+		k.UpdateStateTrie(ctx)
+		if _, found := k.GetStateRoot(ctx); found {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("failed to build the state trie of the allocation of %d accounts", len(alloc))
+}
+
+// ImportChain imports the RLP encoded blocks, as exported by `geth export`, and returns the number
+// of imported blocks. The genesis block is skipped if it is included on the export.
+func (imp *Importer) ImportChain(r io.Reader) (uint64, error) {
+	imported := uint64(0)
+	stream := rlp.NewStream(r, 0)
+
+	for {
+		block := new(ethtypes.Block)
+		if err := stream.Decode(block); err == io.EOF {
+			return imported, nil
+		} else if err != nil {
+			// the blocks of the London hard fork have an additional base fee field on the header
+			return imported, stacktrace.Propagate(err, "failed to decode the block after %d imported blocks", imported)
+		}
+
+		if block.NumberU64() == 0 {
+			if block.Hash() != imp.genesis.Hash() {
+				return imported, fmt.Errorf("genesis block mismatch: have %s, want %s", block.Hash().Hex(), imp.genesis.Hash().Hex())
+			}
+			continue
+		}
+
+		if err := imp.ImportBlock(block); err != nil {
+			return imported, stacktrace.Propagate(err, "failed to import block %d (%s)", block.NumberU64(), block.Hash().Hex())
+		}
+
+		imported++
+		if imported%1000 == 0 {
+			imp.logger.Info("imported blocks", "count", imported, "number", block.NumberU64())
+		}
+	}
+}
+
+// ImportBlock applies the transactions, the mining rewards and the DAO hard fork of a block on top
+// of the last imported one, and checks the resulting state and receipts against the block header.
+func (imp *Importer) ImportBlock(block *ethtypes.Block) error {
+	header := block.Header()
+
+	parent := imp.parent
+	if parent == nil {
+		parent = imp.genesis.Header()
+	}
+	if header.ParentHash != parent.Hash() || block.NumberU64() != parent.Number.Uint64()+1 {
+		return fmt.Errorf("block is not a child of block %d (%s)", parent.Number.Uint64(), parent.Hash().Hex())
+	}
+
+	// the parent hashes are available to the BLOCKHASH opcode
+	imp.chainCtx.SetHeader(parent.Number.Uint64(), parent)
+	if number := parent.Number.Uint64(); number >= blockHashes {
+		delete(imp.chainCtx.headersByNumber, number-blockHashes)
+	}
+
+	if err := imp.commit(header, func(ctx sdk.Context, k *evmkeeper.Keeper) error {
+		return imp.applyBlock(ctx, k, block)
+	}); err != nil {
+		return err
+	}
+
+	imp.parent = header
+	return nil
+}
+
+// commit runs the given function on a branch of the latest state at the height of the block and
+// commits its state changes. Only the errors of the modules are logged, as the balance updates
+// are logged by the bank module.
+func (imp *Importer) commit(header *ethtypes.Header, fn func(ctx sdk.Context, k *evmkeeper.Keeper) error) error {
+	cms := imp.app.CommitMultiStore()
+	ms := cms.CacheMultiStore()
+
+	ctx := sdk.NewContext(ms, tmproto.Header{
+		Height: header.Number.Int64(),
+		Time:   time.Unix(int64(header.Time), 0).UTC(),
+	}, false, log.NewFilter(imp.logger, log.AllowError()))
+
+	k := imp.app.EvmKeeper
+	k.WithContext(ctx)
+
+	if err := fn(ctx, k); err != nil {
+		return err
+	}
+
+	ms.Write()
+	cms.Commit()
+	return nil
+}
+
+// applyBlock applies the block on the state of the given context.
+func (imp *Importer) applyBlock(ctx sdk.Context, k *evmkeeper.Keeper, block *ethtypes.Block) error {
+	header := block.Header()
+
+	if imp.config.DAOForkSupport && imp.config.DAOForkBlock != nil && imp.config.DAOForkBlock.Cmp(header.Number) == 0 {
+		if err := applyDAOHardFork(k); err != nil {
+			return err
+		}
+	}
+
+	var (
+		receipts = make(ethtypes.Receipts, 0, len(block.Transactions()))
+		usedGas  = uint64(0)
+		gp       = new(ethcore.GasPool).AddGas(header.GasLimit)
+		signer   = ethtypes.MakeSigner(imp.config, header.Number)
+	)
+
+	imp.chainCtx.Coinbase = header.Coinbase
+	blockCtx := ethcore.NewEVMBlockContext(header, imp.chainCtx, nil)
+
+	for i, tx := range block.Transactions() {
+		msg, err := tx.AsMessage(signer)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to recover the sender of transaction %d (%s)", i, tx.Hash().Hex())
+		}
+
+		stateDB := statedb.New(k)
+		evm := vm.NewEVM(blockCtx, ethcore.NewEVMTxContext(msg), stateDB, imp.config, vm.Config{})
+
+		gasUsed, failed, err := applyMessage(k, evm, stateDB, gp, msg)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to apply transaction %d (%s)", i, tx.Hash().Hex())
+		}
+
+		if err := stateDB.Commit(); err != nil {
+			return stacktrace.Propagate(err, "failed to commit the state of transaction %d (%s)", i, tx.Hash().Hex())
+		}
+		usedGas += gasUsed
+
+		// the receipts of the blocks before Byzantium commit to the state root after every transaction
+		var root []byte
+		if !imp.config.IsByzantium(header.Number) {
+			k.UpdateStateTrie(ctx)
+			stateRoot, _ := k.GetStateRoot(ctx)
+			root = stateRoot.Bytes()
+		}
+
+		receipt := ethtypes.NewReceipt(root, failed, usedGas)
+		receipt.Type = tx.Type()
+		receipt.TxHash = tx.Hash()
+		receipt.GasUsed = gasUsed
+		if msg.To() == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From(), tx.Nonce())
+		}
+		receipt.Logs = stateDB.Logs()
+		receipt.Bloom = ethtypes.CreateBloom(ethtypes.Receipts{receipt})
+		receipts = append(receipts, receipt)
+	}
+
+	if err := accumulateRewards(k, imp.config, header, block.Uncles()); err != nil {
+		return err
+	}
+
+	if usedGas != header.GasUsed {
+		return fmt.Errorf("gas used mismatch: have %d, want %d", usedGas, header.GasUsed)
+	}
+
+	if bloom := ethtypes.CreateBloom(receipts); bloom != header.Bloom {
+		return fmt.Errorf("logs bloom mismatch: have %x, want %x", bloom, header.Bloom)
+	}
+
+	if receiptHash := ethtypes.DeriveSha(receipts, trie.NewStackTrie(nil)); receiptHash != header.ReceiptHash {
+		return fmt.Errorf("receipts root mismatch: have %s, want %s", receiptHash.Hex(), header.ReceiptHash.Hex())
+	}
+
+	k.UpdateStateTrie(ctx)
+	return imp.checkStateRoot(ctx, k, header)
+}
+
+// applyMessage applies a transaction message as go-ethereum does: the gas is bought by the sender
+// and its unused part is refunded, and the coinbase is paid the fee of the used gas. The
+// execution itself is done by the keeper. It returns the gas used by the transaction and whether
+// its execution failed, or an error if the transaction is invalid.
+func applyMessage(k *evmkeeper.Keeper, evm *vm.EVM, stateDB *statedb.StateDB, gp *ethcore.GasPool, msg ethcore.Message) (uint64, bool, error) {
+	from := msg.From()
+
+	if nonce := stateDB.GetNonce(from); nonce != msg.Nonce() {
+		return 0, false, fmt.Errorf("invalid nonce for address %s: have %d, want %d", from.Hex(), msg.Nonce(), nonce)
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), msg.GasPrice())
+	if balance := stateDB.GetBalance(from); balance.Cmp(fee) < 0 {
+		return 0, false, fmt.Errorf("%w: address %s have %s want %s", ethcore.ErrInsufficientFunds, from.Hex(), balance, fee)
+	}
+	if err := gp.SubGas(msg.Gas()); err != nil {
+		return 0, false, err
+	}
+	stateDB.SubBalance(from, fee)
+
+	if msg.Value().Sign() > 0 && !evm.Context.CanTransfer(stateDB, from, msg.Value()) {
+		return 0, false, fmt.Errorf("%w: address %s", ethcore.ErrInsufficientFundsForTransfer, from.Hex())
+	}
+
+	// the nonce of a contract creation is incremented by the EVM
+	if msg.To() != nil {
+		stateDB.SetNonce(from, stateDB.GetNonce(from)+1)
+	}
+
+	res, err := k.ApplyMessage(evm, msg, evm.ChainConfig(), true)
+	if err != nil {
+		return 0, false, err
+	}
+
+	leftoverGas := msg.Gas() - res.GasUsed
+	stateDB.AddBalance(from, new(big.Int).Mul(new(big.Int).SetUint64(leftoverGas), msg.GasPrice()))
+	gp.AddGas(leftoverGas)
+
+	stateDB.AddBalance(evm.Context.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(res.GasUsed), msg.GasPrice()))
+
+	return res.GasUsed, res.Failed(), nil
+}
+
+// checkStateRoot compares the root of the keeper state trie with the state root of the header.
+func (imp *Importer) checkStateRoot(ctx sdk.Context, k *evmkeeper.Keeper, header *ethtypes.Header) error {
+	root, found := k.GetStateRoot(ctx)
+	if !found {
+		return errors.New("the state trie could not be updated, see the logs for details")
+	}
+
+	if root != header.Root {
+		return fmt.Errorf("state root mismatch: have %s, want %s", root.Hex(), header.Root.Hex())
+	}
+
+	return nil
+}
+
+// accumulateRewards credits the coinbase of the given block with the mining reward. The total
+// reward consists of the static block reward and rewards for included uncles. The coinbase of each
+// uncle block is also rewarded.
+// Ref: https://github.com/ethereum/go-ethereum/blob/v1.10.3/consensus/ethash/consensus.go#L627
+func accumulateRewards(k *evmkeeper.Keeper, config *ethparams.ChainConfig, header *ethtypes.Header, uncles []*ethtypes.Header) error {
+	if config.IsCatalyst(header.Number) {
+		return nil
+	}
+
+	blockReward := ethash.FrontierBlockReward
+	if config.IsByzantium(header.Number) {
+		blockReward = ethash.ByzantiumBlockReward
+	}
+	if config.IsConstantinople(header.Number) {
+		blockReward = ethash.ConstantinopleBlockReward
+	}
+
+	stateDB := statedb.New(k)
+
+	reward := new(big.Int).Set(blockReward)
+	r := new(big.Int)
+	for _, uncle := range uncles {
+		r.Add(uncle.Number, big.NewInt(8))
+		r.Sub(r, header.Number)
+		r.Mul(r, blockReward)
+		r.Div(r, big.NewInt(8))
+		stateDB.AddBalance(uncle.Coinbase, r)
+
+		r.Div(blockReward, big.NewInt(32))
+		reward.Add(reward, r)
+	}
+	stateDB.AddBalance(header.Coinbase, reward)
+
+	return stacktrace.Propagate(stateDB.Commit(), "failed to commit the mining rewards")
+}
+
+// applyDAOHardFork transfers all the balances of a set of DAO accounts to a single refund contract.
+// Ref: https://github.com/ethereum/go-ethereum/blob/v1.10.3/consensus/misc/dao.go#L74
+func applyDAOHardFork(k *evmkeeper.Keeper) error {
+	stateDB := statedb.New(k)
+
+	if !stateDB.Exist(ethparams.DAORefundContract) {
+		stateDB.CreateAccount(ethparams.DAORefundContract)
+	}
+
+	for _, addr := range ethparams.DAODrainList() {
+		balance := stateDB.GetBalance(addr)
+		stateDB.AddBalance(ethparams.DAORefundContract, balance)
+		stateDB.SubBalance(addr, balance)
+	}
+
+	return stacktrace.Propagate(stateDB.Commit(), "failed to commit the DAO hard fork")
+}
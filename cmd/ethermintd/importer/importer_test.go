@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+
+	// testCode stores 1 on the slot 1 and deploys a contract that stores the call data on the slot 0,
+	// clears the slot 1 and logs the call data.
+	testCode = hexutil.MustDecode("0x600160015560196011600039" + "60196000f3" +
+		"600035600055600060015560003560005260" + "2a60206000a100")
+)
+
+// generateChain generates a chain with transfers, a contract creation and calls, a failed call, an
+// uncle and an access list transaction, with the forks up to Berlin activated along the chain.
+func generateChain(t *testing.T) (*ethcore.Genesis, []*ethtypes.Block) {
+	config := &ethparams.ChainConfig{
+		ChainID:             big.NewInt(1),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(2),
+		ConstantinopleBlock: big.NewInt(3),
+		PetersburgBlock:     big.NewInt(3),
+		IstanbulBlock:       big.NewInt(3),
+		MuirGlacierBlock:    big.NewInt(3),
+		BerlinBlock:         big.NewInt(4),
+		Ethash:              new(ethparams.EthashConfig),
+	}
+
+	genesis := &ethcore.Genesis{
+		Config:     config,
+		GasLimit:   10_000_000,
+		Difficulty: big.NewInt(131072),
+		Alloc: ethcore.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(1e18)},
+			common.HexToAddress("0x1000"): {
+				Balance: new(big.Int),
+				Code:    []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00},
+				Storage: map[common.Hash]common.Hash{{0x01}: {0x02}},
+			},
+		},
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	genesisBlock := genesis.MustCommit(db)
+
+	var (
+		signer   = ethtypes.LatestSigner(config)
+		contract = crypto.CreateAddress(testAddr, 1)
+		gasPrice = big.NewInt(1e9)
+	)
+
+	sign := func(txData ethtypes.TxData) *ethtypes.Transaction {
+		tx, err := ethtypes.SignNewTx(testKey, signer, txData)
+		require.NoError(t, err)
+		return tx
+	}
+
+	blocks, _ := ethcore.GenerateChain(config, genesisBlock, ethash.NewFaker(), db, 4, func(i int, b *ethcore.BlockGen) {
+		b.SetCoinbase(common.Address{0xc0})
+
+		nonce := b.TxNonce(testAddr)
+		switch i {
+		case 0:
+			b.AddTx(sign(&ethtypes.LegacyTx{Nonce: nonce, To: &common.Address{0x01}, Value: big.NewInt(1000), Gas: 21000, GasPrice: gasPrice}))
+			b.AddTx(sign(&ethtypes.LegacyTx{Nonce: nonce + 1, Value: big.NewInt(0), Gas: 100000, GasPrice: gasPrice, Data: testCode}))
+		case 1:
+			b.AddTx(sign(&ethtypes.LegacyTx{Nonce: nonce, To: &contract, Value: big.NewInt(0), Gas: 100000, GasPrice: gasPrice, Data: common.Hash{0x0a}.Bytes()}))
+			b.AddTx(sign(&ethtypes.LegacyTx{Nonce: nonce + 1, To: &common.Address{0x10, 0x00}, Value: big.NewInt(0), Gas: 100000, GasPrice: gasPrice}))
+		case 2:
+			// runs out of gas on the first store
+			b.AddTx(sign(&ethtypes.LegacyTx{Nonce: nonce, To: &contract, Value: big.NewInt(0), Gas: 25000, GasPrice: gasPrice, Data: common.Hash{0x0b}.Bytes()}))
+			b.AddUncle(&ethtypes.Header{
+				ParentHash: b.PrevBlock(i - 1).Hash(),
+				Number:     big.NewInt(int64(i)),
+				Coinbase:   common.Address{0x0c},
+				Difficulty: big.NewInt(131072),
+				Extra:      []byte("uncle"),
+			})
+		case 3:
+			b.AddTx(sign(&ethtypes.AccessListTx{
+				ChainID:    config.ChainID,
+				Nonce:      nonce,
+				To:         &contract,
+				Value:      big.NewInt(0),
+				Gas:        100000,
+				GasPrice:   gasPrice,
+				Data:       common.Hash{0x0c}.Bytes(),
+				AccessList: ethtypes.AccessList{{Address: contract, StorageKeys: []common.Hash{{}}}},
+			}))
+		}
+	})
+
+	return genesis, append([]*ethtypes.Block{genesisBlock}, blocks...)
+}
+
+func encodeBlocks(t *testing.T, blocks []*ethtypes.Block) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	for _, block := range blocks {
+		require.NoError(t, rlp.Encode(buf, block))
+	}
+	return buf
+}
+
+func TestImportChain(t *testing.T) {
+	genesis, blocks := generateChain(t)
+
+	imp, err := NewImporter(log.NewNopLogger(), genesis)
+	require.NoError(t, err)
+
+	imported, err := imp.ImportChain(encodeBlocks(t, blocks))
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(blocks)-1), imported)
+	require.Equal(t, blocks[len(blocks)-1].Hash(), imp.parent.Hash())
+}
+
+func TestImportChainMismatch(t *testing.T) {
+	genesis, blocks := generateChain(t)
+
+	imp, err := NewImporter(log.NewNopLogger(), genesis)
+	require.NoError(t, err)
+
+	// the block is sealed with a different state root
+	header := blocks[2].Header()
+	header.Root = common.Hash{0x01}
+	blocks[2] = blocks[2].WithSeal(header)
+
+	imported, err := imp.ImportChain(encodeBlocks(t, blocks))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "state root mismatch")
+	require.Equal(t, uint64(1), imported)
+
+	// the blocks must be imported in order
+	err = imp.ImportBlock(blocks[3])
+	require.Error(t, err)
+}
+
+func TestImportChainGenesisMismatch(t *testing.T) {
+	genesis, blocks := generateChain(t)
+
+	genesis.Alloc[testAddr] = ethcore.GenesisAccount{Balance: big.NewInt(2e18)}
+	imp, err := NewImporter(log.NewNopLogger(), genesis)
+	require.NoError(t, err)
+
+	_, err = imp.ImportChain(encodeBlocks(t, blocks))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "genesis block mismatch")
+}
+
+func TestLoadGenesis(t *testing.T) {
+	genesis, _ := generateChain(t)
+	dir := t.TempDir()
+
+	bz, err := json.Marshal(genesis)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "genesis.json")
+	require.NoError(t, ioutil.WriteFile(path, bz, 0o600))
+
+	loaded, err := LoadGenesis(path)
+	require.NoError(t, err)
+	require.Equal(t, genesis.ToBlock(nil).Hash(), loaded.ToBlock(nil).Hash())
+
+	// the London hard fork is rejected instead of ignored
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(bz, &raw))
+	raw["config"].(map[string]interface{})["londonBlock"] = 5
+
+	bz, err = json.Marshal(raw)
+	require.NoError(t, err)
+
+	londonPath := filepath.Join(dir, "london.json")
+	require.NoError(t, ioutil.WriteFile(londonPath, bz, 0o600))
+
+	_, err = LoadGenesis(londonPath)
+	require.ErrorIs(t, err, ErrLondonUnsupported)
+}
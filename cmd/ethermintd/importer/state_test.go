@@ -0,0 +1,289 @@
+package importer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/tests"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
+)
+
+// stateTestsDir is the directory of the local state test fixtures. They are not taken from the
+// ethereum/tests repository: they are written in the format of its GeneralStateTests, and their
+// expected post states are computed with the state test runner of go-ethereum v1.10.3.
+var stateTestsDir = filepath.Join("testdata", "statetests")
+
+// ethereumTestsDirEnv is the environment variable with the path of a checkout of the ethereum/tests
+// repository (https://github.com/ethereum/tests, MIT licensed). When it is set, the official
+// fixtures of its GeneralStateTests directory are run instead of the local ones.
+const ethereumTestsDirEnv = "ETHEREUM_TESTS_DIR"
+
+// generalStateTestsDir returns the directory of the fixtures to run.
+func generalStateTestsDir() string {
+	if dir := os.Getenv(ethereumTestsDirEnv); dir != "" {
+		return filepath.Join(dir, "GeneralStateTests")
+	}
+	return stateTestsDir
+}
+
+// stateTestSkips are the known divergences of the keeper from go-ethereum. The patterns are matched
+// against the subtest names, which are formed by the fixture file, the test name, the fork and the
+// post state index.
+var stateTestSkips = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{
+		pattern: regexp.MustCompile(`^stSelfDestruct/`),
+		reason:  "the keeper only clears the balance of self-destructed accounts, their nonce, code and storage are kept",
+	},
+	{
+		pattern: regexp.MustCompile(`^stCreate2/create2SuicideRecreate\.json/`),
+		reason:  "the keeper only clears the balance of self-destructed accounts, their nonce, code and storage are kept",
+	},
+	{
+		pattern: regexp.MustCompile(`^stEIP158Specific/touchEmptyAccount\.json/.*/(EIP158|Byzantium|Constantinople|ConstantinopleFix|Istanbul|Berlin)/`),
+		reason:  "the keeper doesn't delete the empty accounts touched by a transaction (EIP-158)",
+	},
+	{
+		pattern: regexp.MustCompile(`^stTransactionTest/nonceTooHigh\.json/`),
+		reason:  "the keeper doesn't delete the empty coinbase touched after an invalid transaction (EIP-158)",
+	},
+}
+
+// stateTest is a GeneralStateTests test, which executes a transaction on a pre-state with the rules
+// of several forks, and with several combinations of its data, gas limit and value. The root of the
+// post-state and the hash of the logs are given for every fork and combination.
+type stateTest struct {
+	Env  stateTestEnv               `json:"env"`
+	Pre  ethcore.GenesisAlloc       `json:"pre"`
+	Tx   stateTestTx                `json:"transaction"`
+	Post map[string][]stateTestPost `json:"post"`
+}
+
+type stateTestEnv struct {
+	Coinbase   common.UnprefixedAddress `json:"currentCoinbase"`
+	Difficulty *math.HexOrDecimal256    `json:"currentDifficulty"`
+	GasLimit   math.HexOrDecimal64      `json:"currentGasLimit"`
+	Number     math.HexOrDecimal64      `json:"currentNumber"`
+	Timestamp  math.HexOrDecimal64      `json:"currentTimestamp"`
+}
+
+type stateTestTx struct {
+	GasPrice    *math.HexOrDecimal256  `json:"gasPrice"`
+	Nonce       math.HexOrDecimal64    `json:"nonce"`
+	To          string                 `json:"to"`
+	Data        []string               `json:"data"`
+	AccessLists []*ethtypes.AccessList `json:"accessLists"`
+	GasLimit    []math.HexOrDecimal64  `json:"gasLimit"`
+	Value       []string               `json:"value"`
+	PrivateKey  hexutil.Bytes          `json:"secretKey"`
+}
+
+type stateTestPost struct {
+	Root    common.UnprefixedHash `json:"hash"`
+	Logs    common.UnprefixedHash `json:"logs"`
+	Indexes struct {
+		Data  int `json:"data"`
+		Gas   int `json:"gas"`
+		Value int `json:"value"`
+	} `json:"indexes"`
+}
+
+// toMessage returns the message of the transaction with the data, gas limit and value of the post
+// state.
+func (tx stateTestTx) toMessage(post stateTestPost) (ethcore.Message, error) {
+	key, err := crypto.ToECDSA(tx.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	var to *common.Address
+	if tx.To != "" {
+		to = new(common.Address)
+		if err := to.UnmarshalText([]byte(tx.To)); err != nil {
+			return nil, fmt.Errorf("invalid to address: %w", err)
+		}
+	}
+
+	idx := post.Indexes
+	if idx.Data >= len(tx.Data) || idx.Gas >= len(tx.GasLimit) || idx.Value >= len(tx.Value) {
+		return nil, fmt.Errorf("post state indexes out of bounds: %+v", idx)
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(tx.Data[idx.Data], "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data %q: %w", tx.Data[idx.Data], err)
+	}
+
+	value := new(big.Int)
+	if valueHex := tx.Value[idx.Value]; valueHex != "0x" {
+		var ok bool
+		if value, ok = math.ParseBig256(valueHex); !ok {
+			return nil, fmt.Errorf("invalid value %q", valueHex)
+		}
+	}
+
+	var accessList ethtypes.AccessList
+	if idx.Data < len(tx.AccessLists) && tx.AccessLists[idx.Data] != nil {
+		accessList = *tx.AccessLists[idx.Data]
+	}
+
+	return ethtypes.NewMessage(
+		crypto.PubkeyToAddress(key.PublicKey), to, uint64(tx.Nonce), value, uint64(tx.GasLimit[idx.Gas]),
+		(*big.Int)(tx.GasPrice), data, accessList, true,
+	), nil
+}
+
+func TestGeneralStateTests(t *testing.T) {
+	dir := generalStateTestsDir()
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".json" {
+			files = append(files, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		name, err := filepath.Rel(dir, file)
+		require.NoError(t, err)
+
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			bz, err := ioutil.ReadFile(file)
+			require.NoError(t, err)
+
+			var stateTests map[string]stateTest
+			require.NoError(t, json.Unmarshal(bz, &stateTests))
+
+			for testName, test := range stateTests {
+				test := test
+				for _, fork := range sortedForks(test.Post) {
+					for i, post := range test.Post[fork] {
+						post := post
+						t.Run(fmt.Sprintf("%s/%s/%d", testName, fork, i), func(t *testing.T) {
+							for _, skip := range stateTestSkips {
+								if skip.pattern.MatchString(t.Name()[len("TestGeneralStateTests/"):]) {
+									t.Skip(skip.reason)
+								}
+							}
+
+							runStateTest(t, test, fork, post)
+						})
+					}
+				}
+			}
+		})
+	}
+}
+
+// runStateTest executes the transaction of a state test through the keeper, on an in-memory
+// application with the pre-state, and compares the root of the keeper state trie and the hash of
+// the logs with the post state, as the go-ethereum state test runner does.
+func runStateTest(t *testing.T, test stateTest, fork string, post stateTestPost) {
+	config, eips, err := tests.GetChainConfig(fork)
+	if _, ok := err.(tests.UnsupportedForkError); ok {
+		t.Skipf("the %s fork is not supported by go-ethereum v1.10.3", fork)
+	}
+	require.NoError(t, err)
+
+	msg, err := test.Tx.toMessage(post)
+	require.NoError(t, err)
+
+	logger := log.NewNopLogger()
+	ethermintApp := newApp(logger)
+	ctx := sdk.NewContext(ethermintApp.CommitMultiStore().CacheMultiStore(), tmproto.Header{
+		Height: int64(test.Env.Number),
+		Time:   time.Unix(int64(test.Env.Timestamp), 0).UTC(),
+	}, false, logger)
+
+	k := ethermintApp.EvmKeeper
+	k.WithContext(ctx)
+	require.NoError(t, writeAlloc(ctx, k, test.Pre))
+
+	coinbase := common.Address(test.Env.Coinbase)
+	blockCtx := vm.BlockContext{
+		CanTransfer: ethcore.CanTransfer,
+		Transfer:    ethcore.Transfer,
+		GetHash:     stateTestBlockHash,
+		Coinbase:    coinbase,
+		BlockNumber: new(big.Int).SetUint64(uint64(test.Env.Number)),
+		Time:        new(big.Int).SetUint64(uint64(test.Env.Timestamp)),
+		Difficulty:  (*big.Int)(test.Env.Difficulty),
+		GasLimit:    uint64(test.Env.GasLimit),
+	}
+
+	stateDB := statedb.New(k)
+	evm := vm.NewEVM(blockCtx, ethcore.NewEVMTxContext(msg), stateDB, config, vm.Config{ExtraEips: eips})
+	gp := new(ethcore.GasPool).AddGas(uint64(test.Env.GasLimit))
+
+	// the state changes of an invalid transaction are discarded
+	var logs []*ethtypes.Log
+	if _, _, err := applyMessage(k, evm, stateDB, gp, msg); err == nil {
+		require.NoError(t, stateDB.Commit())
+		logs = stateDB.Logs()
+	}
+
+	// the coinbase is touched with a zero reward, as go-ethereum does
+	rewardDB := statedb.New(k)
+	rewardDB.AddBalance(coinbase, new(big.Int))
+	require.NoError(t, rewardDB.Commit())
+
+	k.UpdateStateTrie(ctx)
+	root, found := k.GetStateRoot(ctx)
+	require.True(t, found)
+
+	require.Equal(t, common.Hash(post.Root).Hex(), root.Hex(), "post state root mismatch")
+	require.Equal(t, common.Hash(post.Logs).Hex(), rlpHash(logs).Hex(), "post state logs hash mismatch")
+}
+
+// stateTestBlockHash returns the hash of the block number as in the go-ethereum state tests.
+func stateTestBlockHash(n uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte(new(big.Int).SetUint64(n).String()))
+}
+
+func rlpHash(x interface{}) common.Hash {
+	bz, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		panic(err)
+	}
+	return crypto.Keccak256Hash(bz)
+}
+
+func sortedForks(post map[string][]stateTestPost) []string {
+	forks := make([]string, 0, len(post))
+	for fork := range post {
+		forks = append(forks, fork)
+	}
+	sort.Strings(forks)
+	return forks
+}
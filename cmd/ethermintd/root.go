@@ -107,12 +107,14 @@ func NewRootCmd() (*cobra.Command, params.EncodingConfig) {
 
 	a := appCreator{encodingConfig}
 	server.AddCommands(rootCmd, app.DefaultNodeHome, a.newApp, a.appExport, addModuleInitFlags)
+	rootCmd.AddCommand(server.DevCmd(a.newApp, app.ModuleBasics, banktypes.GenesisBalancesIterator{}))
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
 		rpc.StatusCommand(),
 		queryCommand(),
 		txCommand(),
+		evmCommand(),
 		ethermintclient.KeyCommands(app.DefaultNodeHome),
 	)
 	rootCmd = srvflags.AddTxFlags(rootCmd)
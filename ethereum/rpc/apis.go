@@ -12,14 +12,14 @@ import (
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/debug"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/eth"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/eth/filters"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/evm"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/hardhat"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/miner"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/net"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/personal"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/txpool"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/web3"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
-
-	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 )
 
 // RPC namespaces and API version
@@ -31,14 +31,25 @@ const (
 	TxPoolNamespace   = "txpool"
 	DebugNamespace    = "debug"
 	MinerNamespace    = "miner"
+	EVMNamespace      = "evm"
+	HardhatNamespace  = "hardhat"
+	AnvilNamespace    = "anvil"
 
 	apiVersion = "1.0"
 )
 
-// GetRPCAPIs returns the list of all APIs
-func GetRPCAPIs(ctx *server.Context, clientCtx client.Context, tmWSClient *rpcclient.WSClient, selectedAPIs []string) []rpc.API {
+// GetRPCAPIs returns the list of all APIs. The filter APIs receive the Tendermint events from the
+// given event system. The dev-only APIs are only returned for the node of a development chain, in
+// which case devNode is not nil.
+func GetRPCAPIs(
+	ctx *server.Context, clientCtx client.Context, events *filters.EventSystem, devNode hardhat.DevNode, selectedAPIs []string,
+) []rpc.API {
 	nonceLock := new(types.AddrLocker)
 	evmBackend := backend.NewEVMBackend(ctx, ctx.Logger, clientCtx)
+	if devNode != nil {
+		// the transactions of the impersonated accounts are sent unsigned
+		evmBackend.WithImpersonator(devNode)
+	}
 
 	var apis []rpc.API
 	// remove duplicates
@@ -57,7 +68,7 @@ func GetRPCAPIs(ctx *server.Context, clientCtx client.Context, tmWSClient *rpccl
 				rpc.API{
 					Namespace: EthNamespace,
 					Version:   apiVersion,
-					Service:   filters.NewPublicAPI(ctx.Logger, tmWSClient, evmBackend),
+					Service:   filters.NewPublicAPI(ctx.Logger, events, evmBackend),
 					Public:    true,
 				},
 			)
@@ -115,6 +126,34 @@ func GetRPCAPIs(ctx *server.Context, clientCtx client.Context, tmWSClient *rpccl
 					Public:    false,
 				},
 			)
+		case EVMNamespace:
+			if devNode == nil {
+				ctx.Logger.Error("the namespace is only available in dev mode", "namespace", EVMNamespace)
+				continue
+			}
+
+			apis = append(apis,
+				rpc.API{
+					Namespace: EVMNamespace,
+					Version:   apiVersion,
+					Service:   evm.NewAPI(ctx.Logger, devNode),
+					Public:    false,
+				},
+			)
+		case HardhatNamespace, AnvilNamespace:
+			if devNode == nil {
+				ctx.Logger.Error("the namespace is only available in dev mode", "namespace", selectedAPIs[index])
+				continue
+			}
+
+			apis = append(apis,
+				rpc.API{
+					Namespace: selectedAPIs[index],
+					Version:   apiVersion,
+					Service:   hardhat.NewAPI(ctx.Logger, devNode),
+					Public:    false,
+				},
+			)
 		default:
 			ctx.Logger.Error("invalid namespace value", "namespace", selectedAPIs[index])
 		}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+)
+
+// StateQuery performs gRPC state queries with the given context, which sets the queried height, and
+// query client.
+type StateQuery func(ctx context.Context, queryClient *types.QueryClient) error
+
+// newArchiveQueryClient returns a query client that sends the queries to the gRPC endpoint of an
+// archive node. The connection is established lazily on the first query.
+func newArchiveQueryClient(address string) (*types.QueryClient, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewQueryClientFromConn(conn), nil
+}
+
+// EarliestStateHeight returns the earliest height whose state is retained by the node, according to
+// its pruning options and the earliest block of its block store. The state of the heights kept by the
+// pruning keep-every option before it is also available.
+func (e *EVMBackend) EarliestStateHeight() (int64, error) {
+	status, err := e.clientCtx.Client.Status(e.ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return types.EarliestStateHeight(e.pruning, status.SyncInfo.LatestBlockHeight, status.SyncInfo.EarliestBlockHeight), nil
+}
+
+// isStateAvailable returns true if the state at the given height is retained by the node.
+func (e *EVMBackend) isStateAvailable(height int64) (bool, error) {
+	status, err := e.clientCtx.Client.Status(e.ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return types.IsStateAvailable(e.pruning, height, status.SyncInfo.LatestBlockHeight, status.SyncInfo.EarliestBlockHeight), nil
+}
+
+// QueryState performs the state query at the given block number. If the state of the block was pruned
+// from the node, the query is sent to the archive node when the archive proxy is enabled, otherwise a
+// MissingStateError is returned.
+func (e *EVMBackend) QueryState(blockNum types.BlockNumber, query StateQuery) error {
+	height := blockNum.Int64()
+	ctx := types.ContextWithHeight(height)
+
+	// latest and pending state queries are always served locally
+	if height <= 0 {
+		return query(ctx, e.queryClient)
+	}
+
+	if e.archiveQueryClient != nil {
+		available, err := e.isStateAvailable(height)
+		if err != nil {
+			return err
+		}
+
+		if !available {
+			e.logger.Debug("forwarding state query to archive node", "height", height)
+			return query(ctx, e.archiveQueryClient)
+		}
+	}
+
+	err := query(ctx, e.queryClient)
+	if !types.IsMissingStateError(err) {
+		return err
+	}
+
+	if e.archiveQueryClient != nil {
+		return query(ctx, e.archiveQueryClient)
+	}
+
+	earliest, statusErr := e.EarliestStateHeight()
+	if statusErr != nil {
+		e.logger.Debug("failed to get earliest state height", "error", statusErr.Error())
+	}
+
+	return types.NewMissingStateError(height, earliest)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/server"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/params"
@@ -20,6 +21,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
@@ -63,11 +65,17 @@ type Backend interface {
 	ChainConfig() *params.ChainConfig
 	SuggestGasTipCap() (*big.Int, error)
 	GetFilteredBlocks(from int64, to int64, filter [][]filters.BloomIV, filterAddresses bool) ([]int64, error)
+	EarliestStateHeight() (int64, error)
+	QueryState(blockNum types.BlockNumber, query StateQuery) error
+	ExternalSigner() *types.ExternalSigner
 }
 
 var _ Backend = (*EVMBackend)(nil)
 
-var bAttributeKeyEthereumBloom = []byte(evmtypes.AttributeKeyEthereumBloom)
+var (
+	bAttributeKeyEthereumBloom = []byte(evmtypes.AttributeKeyEthereumBloom)
+	bAttributeKeyStateRoot     = []byte(evmtypes.AttributeKeyStateRoot)
+)
 
 // EVMBackend implements the Backend interface
 type EVMBackend struct {
@@ -77,6 +85,20 @@ type EVMBackend struct {
 	logger      log.Logger
 	chainID     *big.Int
 	cfg         config.Config
+	pruning     storetypes.PruningOptions
+	// archiveQueryClient is the gRPC query client of the archive node, nil if the proxy is disabled
+	archiveQueryClient *types.QueryClient
+	// externalSigner is the client of the external signer, nil if the keyring is used to sign
+	externalSigner *types.ExternalSigner
+	// impersonator reports the accounts whose transactions are sent unsigned, it's only set on the nodes
+	// of development chains
+	impersonator Impersonator
+}
+
+// Impersonator reports the impersonated accounts of a development chain, whose transactions are sent
+// unsigned.
+type Impersonator interface {
+	IsImpersonated(addr common.Address) bool
 }
 
 // NewEVMBackend creates a new EVMBackend instance
@@ -88,16 +110,43 @@ func NewEVMBackend(ctx *server.Context, logger log.Logger, clientCtx client.Cont
 
 	appConf := config.GetConfig(ctx.Viper)
 
+	pruningOpts, err := server.GetPruningOptionsFromFlags(ctx.Viper)
+	if err != nil {
+		panic(err)
+	}
+
+	var archiveQueryClient *types.QueryClient
+	if appConf.JSONRPC.ArchiveGRPCAddress != "" {
+		archiveQueryClient, err = newArchiveQueryClient(appConf.JSONRPC.ArchiveGRPCAddress)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var externalSigner *types.ExternalSigner
+	if appConf.JSONRPC.ExternalSigner != "" {
+		externalSigner = types.NewExternalSigner(appConf.JSONRPC.ExternalSigner)
+	}
+
 	return &EVMBackend{
-		ctx:         context.Background(),
-		clientCtx:   clientCtx,
-		queryClient: types.NewQueryClient(clientCtx),
-		logger:      logger.With("module", "evm-backend"),
-		chainID:     chainID,
-		cfg:         appConf,
+		ctx:                context.Background(),
+		clientCtx:          clientCtx,
+		queryClient:        types.NewQueryClient(clientCtx),
+		logger:             logger.With("module", "evm-backend"),
+		chainID:            chainID,
+		cfg:                appConf,
+		pruning:            pruningOpts,
+		archiveQueryClient: archiveQueryClient,
+		externalSigner:     externalSigner,
 	}
 }
 
+// WithImpersonator sets the impersonated accounts of a development chain, whose transactions are sent
+// unsigned by SendTransaction.
+func (e *EVMBackend) WithImpersonator(impersonator Impersonator) {
+	e.impersonator = impersonator
+}
+
 // BlockNumber returns the current block number in abci app state.
 // Because abci app state could lag behind from tendermint latest block, it's more stable
 // for the client to use the latest block number in abci app state than tendermint rpc.
@@ -220,6 +269,37 @@ func (e *EVMBackend) BlockBloom(height *int64) (ethtypes.Bloom, error) {
 	return ethtypes.Bloom{}, errors.New("block bloom event is not found")
 }
 
+// StateRoot query the EVM state trie root from block results, which is only found if the state
+// trie is enabled on the EVM module params.
+func (e *EVMBackend) StateRoot(height *int64) (common.Hash, error) {
+	result, err := e.clientCtx.Client.BlockResults(e.ctx, height)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	root, found := stateRootFromEvents(result.EndBlockEvents)
+	if !found {
+		return common.Hash{}, errors.New("state root event is not found")
+	}
+	return root, nil
+}
+
+// stateRootFromEvents returns the EVM state trie root from the end block events.
+func stateRootFromEvents(events []abci.Event) (common.Hash, bool) {
+	for _, event := range events {
+		if event.Type != evmtypes.EventTypeStateRoot {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if bytes.Equal(attr.Key, bAttributeKeyStateRoot) {
+				return common.HexToHash(string(attr.Value)), true
+			}
+		}
+	}
+	return common.Hash{}, false
+}
+
 // EthBlockFromTendermint returns a JSON-RPC compatible Ethereum block from a given Tendermint block and its block result.
 func (e *EVMBackend) EthBlockFromTendermint(
 	block *tmtypes.Block,
@@ -248,7 +328,7 @@ func (e *EVMBackend) EthBlockFromTendermint(
 			}
 
 			// get full transaction from message data
-			from, err := ethMsg.GetSender(e.chainID)
+			from, err := types.GetTxSender(ethMsg, e.chainID)
 			if err != nil {
 				e.logger.Debug("failed to get sender from already included transaction", "hash", hash.Hex(), "error", err.Error())
 				from = common.HexToAddress(ethMsg.From)
@@ -323,6 +403,12 @@ func (e *EVMBackend) EthBlockFromTendermint(
 	}
 
 	formattedBlock := types.FormatBlock(block.Header, block.Size(), gasLimit, new(big.Int).SetUint64(gasUsed), ethRPCTxs, bloom, validatorAddr)
+
+	// the state root is the app hash, unless the EVM state trie is enabled
+	if root, found := stateRootFromEvents(resBlockResult.EndBlockEvents); found {
+		formattedBlock["stateRoot"] = root
+	}
+
 	return formattedBlock, nil
 }
 
@@ -367,6 +453,11 @@ func (e *EVMBackend) HeaderByNumber(blockNum types.BlockNumber) (*ethtypes.Heade
 
 	ethHeader := types.EthHeaderFromTendermint(resBlock.Block.Header)
 	ethHeader.Bloom = bloom
+
+	if root, err := e.StateRoot(&resBlock.Block.Height); err == nil {
+		ethHeader.Root = root
+	}
+
 	return ethHeader, nil
 }
 
@@ -389,6 +480,11 @@ func (e *EVMBackend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header, erro
 
 	ethHeader := types.EthHeaderFromTendermint(resBlock.Block.Header)
 	ethHeader.Bloom = bloom
+
+	if root, err := e.StateRoot(&resBlock.Block.Height); err == nil {
+		ethHeader.Root = root
+	}
+
 	return ethHeader, nil
 }
 
@@ -584,14 +680,18 @@ func (e *EVMBackend) GetTxByEthHash(hash common.Hash) (*tmrpctypes.ResultTx, err
 }
 
 func (e *EVMBackend) SendTransaction(args types.SendTxArgs) (common.Hash, error) {
-	// Look up the wallet containing the requested signer
-	_, err := e.clientCtx.Keyring.KeyByAddress(sdk.AccAddress(args.From.Bytes()))
-	if err != nil {
-		e.logger.Error("failed to find key in keyring", "address", args.From, "error", err.Error())
-		return common.Hash{}, fmt.Errorf("%s; %s", keystore.ErrNoMatch, err.Error())
+	impersonated := e.impersonator != nil && e.impersonator.IsImpersonated(args.From)
+
+	// Look up the wallet containing the requested signer, the external signer checks the account itself
+	if e.externalSigner == nil && !impersonated {
+		_, err := e.clientCtx.Keyring.KeyByAddress(sdk.AccAddress(args.From.Bytes()))
+		if err != nil {
+			e.logger.Error("failed to find key in keyring", "address", args.From, "error", err.Error())
+			return common.Hash{}, fmt.Errorf("%s; %s", keystore.ErrNoMatch, err.Error())
+		}
 	}
 
-	args, err = e.setTxDefaults(args)
+	args, err := e.setTxDefaults(args)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -606,8 +706,18 @@ func (e *EVMBackend) SendTransaction(args types.SendTxArgs) (common.Hash, error)
 	// TODO: get from chain config
 	signer := ethtypes.LatestSignerForChainID(args.ChainID.ToInt())
 
-	// Sign transaction
-	if err := msg.Sign(signer, e.clientCtx.Keyring); err != nil {
+	// Sign transaction, the sender of an unsigned transaction is the From field
+	if impersonated {
+		e.logger.Debug("sending unsigned tx of impersonated account", "address", args.From)
+	} else if e.externalSigner != nil {
+		tx, err := e.externalSigner.SignTransaction(e.ctx, args, signer)
+		if err != nil {
+			e.logger.Debug("external signer failed to sign tx", "endpoint", e.externalSigner.Endpoint(), "error", err.Error())
+			return common.Hash{}, err
+		}
+
+		msg.FromEthereumTx(tx)
+	} else if err := msg.Sign(signer, e.clientCtx.Keyring); err != nil {
 		e.logger.Debug("failed to sign tx", "error", err.Error())
 		return common.Hash{}, err
 	}
@@ -687,10 +797,11 @@ func (e *EVMBackend) EstimateGas(args evmtypes.CallArgs, blockNrOptional *types.
 
 	req := evmtypes.EthCallRequest{Args: bz, GasCap: e.RPCGasCap()}
 
-	// From ContextWithHeight: if the provided height is 0,
-	// it will return an empty context and the gRPC query will use
-	// the latest block height for querying.
-	res, err := e.queryClient.EstimateGas(types.ContextWithHeight(blockNr.Int64()), &req)
+	var res *evmtypes.EstimateGasResponse
+	err = e.QueryState(blockNr, func(ctx context.Context, queryClient *types.QueryClient) (err error) {
+		res, err = queryClient.EstimateGas(ctx, &req)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -711,7 +822,7 @@ func (e *EVMBackend) GetTransactionCount(address common.Address, blockNum types.
 	}
 
 	includePending := blockNum == types.EthPendingBlockNumber
-	nonce, err := e.getAccountNonce(address, includePending, blockNum.Int64(), e.logger)
+	nonce, err := e.getAccountNonce(address, includePending, blockNum, e.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -820,3 +931,9 @@ func checkMatches(bloom ethtypes.Bloom, filter []filters.BloomIV) bool {
 	}
 	return false
 }
+
+// ExternalSigner returns the client of the external signer that signs the transactions and data
+// instead of the keyring, or nil if the external signer is disabled.
+func (e *EVMBackend) ExternalSigner() *types.ExternalSigner {
+	return e.externalSigner
+}
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"math/big"
 
@@ -29,7 +30,7 @@ func (e *EVMBackend) setTxDefaults(args types.SendTxArgs) (types.SendTxArgs, err
 	if args.Nonce == nil {
 		// get the nonce from the account retriever
 		// ignore error in case tge account doesn't exist yet
-		nonce, _ := e.getAccountNonce(args.From, true, 0, e.logger)
+		nonce, _ := e.getAccountNonce(args.From, true, types.EthLatestBlockNumber, e.logger)
 		args.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 
@@ -68,7 +69,8 @@ func (e *EVMBackend) setTxDefaults(args types.SendTxArgs) (types.SendTxArgs, err
 			Data:       input,
 			AccessList: args.AccessList,
 		}
-		blockNr := types.NewBlockNumber(big.NewInt(0))
+		// the gas is estimated on the latest state, the block number 0 being the earliest block
+		blockNr := types.EthPendingBlockNumber
 		estimated, err := e.EstimateGas(callArgs, &blockNr)
 		if err != nil {
 			return args, err
@@ -87,10 +89,14 @@ func (e *EVMBackend) setTxDefaults(args types.SendTxArgs) (types.SendTxArgs, err
 // getAccountNonce returns the account nonce for the given account address.
 // If the pending value is true, it will iterate over the mempool (pending)
 // txs in order to compute and return the pending tx sequence.
-// Todo: include the ability to specify a blockNumber
-func (e *EVMBackend) getAccountNonce(accAddr common.Address, pending bool, height int64, logger log.Logger) (uint64, error) {
-	queryClient := authtypes.NewQueryClient(e.clientCtx)
-	res, err := queryClient.Account(types.ContextWithHeight(height), &authtypes.QueryAccountRequest{Address: sdk.AccAddress(accAddr.Bytes()).String()})
+func (e *EVMBackend) getAccountNonce(accAddr common.Address, pending bool, blockNum types.BlockNumber, logger log.Logger) (uint64, error) {
+	req := &authtypes.QueryAccountRequest{Address: sdk.AccAddress(accAddr.Bytes()).String()}
+
+	var res *authtypes.QueryAccountResponse
+	err := e.QueryState(blockNum, func(ctx context.Context, queryClient *types.QueryClient) (err error) {
+		res, err = queryClient.Auth.Account(ctx, req)
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -122,7 +128,7 @@ func (e *EVMBackend) getAccountNonce(accAddr common.Address, pending bool, heigh
 			continue
 		}
 
-		sender, err := msg.GetSender(e.chainID)
+		sender, err := types.GetTxSender(msg, e.chainID)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,491 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	metrics "github.com/armon/go-metrics"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/eth/filters"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+)
+
+// blockParamIndex defines the position of the block number (or hash) parameter of the eth methods
+// that query the state at a given block. Cached methods that are not listed here depend on the
+// latest block only.
+var blockParamIndex = map[string]int{
+	"eth_getBalance":                          1,
+	"eth_getStorageAt":                        2,
+	"eth_getTransactionCount":                 1,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getUncleCountByBlockNumber":          0,
+	"eth_getCode":                             1,
+	"eth_call":                                1,
+	"eth_estimateGas":                         1,
+	"eth_getBlockByNumber":                    0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getProof":                            2,
+	"eth_getBlockReceipts":                    0,
+}
+
+// maxRequestSize defines the maximum size of a request body read by the cache, which matches the
+// limit of the go-ethereum HTTP server.
+const maxRequestSize = 5 * 1024 * 1024
+
+// jsonrpcMessage defines the subset of the JSON-RPC 2.0 message fields used by the cache.
+type jsonrpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+// cacheKey identifies a cached response.
+type cacheKey struct {
+	// key defines the method and the parameters, with the block parameter resolved to a height
+	key string
+	// historical is true if the response is for a given block height or hash, in which case it
+	// never changes and can be cached permanently
+	historical bool
+}
+
+// ResponseCache is an HTTP middleware that caches the responses of the JSON-RPC eth methods that
+// query the chain state. Responses are keyed by method, parameters and resolved block height:
+//
+//   - queries for an explicit height (or block hash) up to the latest block are cached permanently
+//   - queries for the latest block are cached until a new block is committed
+//   - queries for the pending block, future heights and error responses are never cached
+//
+// Requests within a batch are answered from the cache individually, and only the ones that miss
+// the cache are forwarded to the JSON-RPC server.
+//
+// The cache only wraps the HTTP transport: the calls made over the WebSocket and IPC connections are
+// always served by the JSON-RPC server.
+type ResponseCache struct {
+	logger  log.Logger
+	methods map[string]bool
+
+	historical *lru.Cache
+	latest     *lru.Cache
+
+	mu     sync.RWMutex // guards height and the latest cache invalidation
+	height int64        // latest block height, 0 until the first block is received
+
+	// stop cancels the subscription to the new blocks
+	stop func()
+}
+
+// NewResponseCache creates a new response cache of the given size for the given eth methods. The
+// responses of the latest block are invalidated on every new block received from the event system.
+func NewResponseCache(logger log.Logger, events *filters.EventSystem, size int, methods []string) (*ResponseCache, error) {
+	c, err := newResponseCache(logger, size, methods)
+	if err != nil {
+		return nil, err
+	}
+
+	headersSub, cancelSub, err := events.SubscribeNewHeads()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to subscribe to new blocks")
+	}
+
+	c.stop = func() {
+		headersSub.Unsubscribe(events)
+		cancelSub()
+	}
+
+	go c.invalidateLoop(headersSub)
+
+	return c, nil
+}
+
+// Stop cancels the subscription to the new blocks. The cached responses are no longer invalidated
+// once the cache is stopped.
+func (c *ResponseCache) Stop() {
+	if c.stop != nil {
+		c.stop()
+	}
+}
+
+func newResponseCache(logger log.Logger, size int, methods []string) (*ResponseCache, error) {
+	historical, err := lru.New(size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create historical responses cache")
+	}
+
+	latest, err := lru.New(size)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create latest responses cache")
+	}
+
+	c := &ResponseCache{
+		logger:     logger.With("module", "rpc-cache"),
+		methods:    make(map[string]bool, len(methods)),
+		historical: historical,
+		latest:     latest,
+	}
+
+	for _, method := range methods {
+		c.methods[method] = true
+	}
+
+	return c, nil
+}
+
+// invalidateLoop updates the latest block height and purges the responses of the previous block
+// on every new block.
+func (c *ResponseCache) invalidateLoop(headersSub *filters.Subscription) {
+	for ev := range headersSub.Event() {
+		data, ok := ev.Data.(tmtypes.EventDataNewBlockHeader)
+		if !ok {
+			continue
+		}
+
+		c.newBlock(data.Header.Height)
+	}
+}
+
+// newBlock sets the latest block height and purges the responses of the previous block. All the
+// responses are purged if the height is lower than the latest one, as the chain was rolled back.
+func (c *ResponseCache) newBlock(height int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case height > c.height:
+		c.height = height
+		c.latest.Purge()
+	case height < c.height:
+		c.height = height
+		c.latest.Purge()
+		c.historical.Purge()
+	}
+}
+
+// Reset purges all the cached responses and the latest block height, so that nothing is cached
+// until the next block. It must be called when the chain is rolled back, since the cached responses
+// may describe the blocks that were reverted.
+func (c *ResponseCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.height = 0
+	c.latest.Purge()
+	c.historical.Purge()
+}
+
+// Height returns the latest block height known by the cache.
+func (c *ResponseCache) Height() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.height
+}
+
+// Handler returns an http.Handler that serves the cached responses and forwards the remaining
+// requests to the given handler.
+func (c *ResponseCache) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		msgs, batch := parseMessages(body)
+		if len(msgs) == 0 {
+			// not a valid JSON-RPC request, let the server handle the error
+			forwardRequest(next, w, r, body)
+			return
+		}
+
+		height := c.Height()
+		keys := make([]*cacheKey, len(msgs))
+		responses := make([]json.RawMessage, len(msgs))
+		misses := []*jsonrpcMessage{}
+
+		for i, msg := range msgs {
+			keys[i] = c.cacheKey(msg, height)
+			if keys[i] != nil {
+				if result, ok := c.get(keys[i]); ok {
+					responses[i] = newResponse(msg.ID, result)
+					recordCacheMetric(msg.Method, "hits")
+					continue
+				}
+
+				recordCacheMetric(msg.Method, "misses")
+			}
+
+			misses = append(misses, msg)
+		}
+
+		if len(misses) == len(msgs) && !c.cacheable(keys) {
+			forwardRequest(next, w, r, body)
+			return
+		}
+
+		if len(misses) > 0 {
+			// forward the requests that missed the cache
+			reqBody := body
+			if len(misses) < len(msgs) {
+				reqBody, err = marshalMessages(misses, batch)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			forwardRequest(next, rec, r, reqBody)
+
+			results, _ := parseMessages(rec.Body.Bytes())
+			if rec.Code != http.StatusOK || len(results) == 0 {
+				// the whole request failed, return the server response as is
+				copyResponse(w, rec)
+				return
+			}
+
+			c.store(msgs, keys, responses, results, height)
+		}
+
+		// responses of notifications are omitted
+		out := make([]json.RawMessage, 0, len(responses))
+		for _, res := range responses {
+			if res != nil {
+				out = append(out, res)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if batch {
+			err = json.NewEncoder(w).Encode(out)
+		} else if len(out) == 1 {
+			_, err = w.Write(out[0])
+		}
+
+		if err != nil {
+			c.logger.Debug("failed to write response", "error", err.Error())
+		}
+	})
+}
+
+// cacheKey returns the cache key of the given request, or nil if its response can't be cached.
+func (c *ResponseCache) cacheKey(msg *jsonrpcMessage, height int64) *cacheKey {
+	// notifications don't have a response
+	if !c.methods[msg.Method] || len(msg.ID) == 0 || height == 0 {
+		return nil
+	}
+
+	var params []json.RawMessage
+	if len(msg.Params) > 0 && string(msg.Params) != "null" {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+	}
+
+	historical := false
+
+	index, ok := blockParamIndex[msg.Method]
+	if ok {
+		blockParam := json.RawMessage(strconv.Quote(hexutil.EncodeUint64(uint64(height))))
+
+		if index < len(params) && string(params[index]) != "null" {
+			var blockNrOrHash types.BlockNumberOrHash
+			if err := json.Unmarshal(params[index], &blockNrOrHash); err != nil {
+				return nil
+			}
+
+			switch {
+			case blockNrOrHash.BlockHash != nil:
+				historical = true
+				blockParam = json.RawMessage(strconv.Quote(blockNrOrHash.BlockHash.Hex()))
+			case blockNrOrHash.BlockNumber == nil, *blockNrOrHash.BlockNumber == types.EthPendingBlockNumber:
+				return nil
+			case *blockNrOrHash.BlockNumber != types.EthLatestBlockNumber:
+				number := blockNrOrHash.BlockNumber.Int64()
+				if number > height {
+					return nil
+				}
+
+				historical = true
+				blockParam = json.RawMessage(strconv.Quote(hexutil.EncodeUint64(uint64(number))))
+			}
+		}
+
+		// the block parameter is optional for some methods
+		for len(params) <= index {
+			params = append(params, nil)
+		}
+		params[index] = blockParam
+	}
+
+	for i := range params {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, params[i]); err == nil {
+			params[i] = buf.Bytes()
+		}
+	}
+
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+
+	return &cacheKey{
+		key:        msg.Method + string(bz),
+		historical: historical,
+	}
+}
+
+// cacheable returns true if at least one of the keys is set.
+func (c *ResponseCache) cacheable(keys []*cacheKey) bool {
+	for _, key := range keys {
+		if key != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the cached result of the given key. Responses of the latest block are also served
+// from the historical responses of the same height.
+func (c *ResponseCache) get(key *cacheKey) (json.RawMessage, bool) {
+	if !key.historical {
+		if result, ok := c.latest.Get(key.key); ok {
+			return result.(json.RawMessage), true
+		}
+	}
+
+	if result, ok := c.historical.Get(key.key); ok {
+		return result.(json.RawMessage), true
+	}
+
+	return nil, false
+}
+
+// store caches the successful results of the forwarded requests and sets them as the responses
+// of the original requests.
+func (c *ResponseCache) store(msgs []*jsonrpcMessage, keys []*cacheKey, responses []json.RawMessage, results []*jsonrpcMessage, height int64) {
+	byID := make(map[string]*jsonrpcMessage, len(results))
+	for _, res := range results {
+		byID[string(res.ID)] = res
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i, msg := range msgs {
+		if responses[i] != nil || len(msg.ID) == 0 {
+			continue
+		}
+
+		res, ok := byID[string(msg.ID)]
+		if !ok {
+			continue
+		}
+
+		bz, err := json.Marshal(res)
+		if err != nil {
+			continue
+		}
+		responses[i] = bz
+
+		key := keys[i]
+		if key == nil || len(res.Error) > 0 || len(res.Result) == 0 || string(res.Result) == "null" {
+			continue
+		}
+
+		switch {
+		case key.historical:
+			c.historical.Add(key.key, res.Result)
+		case c.height == height:
+			// skip the response if a new block was committed while processing the request
+			c.latest.Add(key.key, res.Result)
+		}
+	}
+}
+
+// forwardRequest serves the given request body with the next handler.
+func forwardRequest(next http.Handler, w http.ResponseWriter, r *http.Request, body []byte) {
+	req := r.Clone(r.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	next.ServeHTTP(w, req)
+}
+
+// parseMessages decodes a single JSON-RPC message or a batch of messages.
+func parseMessages(bz []byte) ([]*jsonrpcMessage, bool) {
+	bz = bytes.TrimSpace(bz)
+	if len(bz) == 0 {
+		return nil, false
+	}
+
+	if bz[0] != '[' {
+		msg := new(jsonrpcMessage)
+		if err := json.Unmarshal(bz, msg); err != nil {
+			return nil, false
+		}
+		return []*jsonrpcMessage{msg}, false
+	}
+
+	var msgs []*jsonrpcMessage
+	if err := json.Unmarshal(bz, &msgs); err != nil {
+		return nil, true
+	}
+
+	for _, msg := range msgs {
+		if msg == nil {
+			return nil, true
+		}
+	}
+
+	return msgs, true
+}
+
+// marshalMessages encodes the given JSON-RPC messages as a batch or as a single message.
+func marshalMessages(msgs []*jsonrpcMessage, batch bool) ([]byte, error) {
+	if !batch && len(msgs) == 1 {
+		return json.Marshal(msgs[0])
+	}
+	return json.Marshal(msgs)
+}
+
+// newResponse returns the JSON-RPC response with the given id and result.
+func newResponse(id, result json.RawMessage) json.RawMessage {
+	bz, _ := json.Marshal(&jsonrpcMessage{Version: "2.0", ID: id, Result: result})
+	return bz
+}
+
+// copyResponse writes the recorded response.
+func copyResponse(w http.ResponseWriter, rec *httptest.ResponseRecorder) {
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(rec.Body.Bytes())
+}
+
+// recordCacheMetric increments the cache hits or misses counter of the given method.
+func recordCacheMetric(method, result string) {
+	telemetry.IncrCounterWithLabels(
+		[]string{types.MetricKeyJSONRPC, "cache", result},
+		1,
+		[]metrics.Label{telemetry.NewLabel(types.MetricLabelMethod, method)},
+	)
+}
@@ -0,0 +1,227 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// countingHandler answers every request with the number of requests it has served for the method.
+type countingHandler struct {
+	calls map[string]int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	msgs, batch := parseMessages(body)
+
+	responses := make([]*jsonrpcMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		h.calls[msg.Method]++
+		result := json.RawMessage(fmt.Sprintf(`"%s-%d"`, msg.Method, h.calls[msg.Method]))
+		if msg.Method == "eth_fail" {
+			responses = append(responses, &jsonrpcMessage{Version: "2.0", ID: msg.ID, Error: json.RawMessage(`{"code":-32000,"message":"failed"}`)})
+			continue
+		}
+		responses = append(responses, &jsonrpcMessage{Version: "2.0", ID: msg.ID, Result: result})
+	}
+
+	bz, _ := marshalMessages(responses, batch)
+	_, _ = w.Write(bz)
+}
+
+func TestResponseCache(t *testing.T) {
+	cache, err := newResponseCache(log.NewNopLogger(), 10, []string{"eth_getBalance", "eth_blockNumber", "eth_fail"})
+	require.NoError(t, err)
+
+	next := &countingHandler{calls: make(map[string]int)}
+	handler := cache.Handler(next)
+
+	do := func(body string) []*jsonrpcMessage {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		msgs, _ := parseMessages(rec.Body.Bytes())
+		return msgs
+	}
+
+	balance := func(id int, block string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"eth_getBalance","params":["0x0000000000000000000000000000000000000001",%s]}`, id, block)
+	}
+
+	// nothing is cached until the latest height is known
+	do(balance(1, `"latest"`))
+	do(balance(1, `"latest"`))
+	require.Equal(t, 2, next.calls["eth_getBalance"])
+
+	cache.newBlock(10)
+
+	res := do(balance(1, `"latest"`))
+	require.Equal(t, `"eth_getBalance-3"`, string(res[0].Result))
+	res = do(balance(2, `"latest"`))
+	require.Equal(t, `"eth_getBalance-3"`, string(res[0].Result))
+	require.Equal(t, "2", string(res[0].ID))
+	require.Equal(t, 3, next.calls["eth_getBalance"])
+
+	// pending and future heights are never cached
+	do(balance(1, `"pending"`))
+	do(balance(1, `"pending"`))
+	do(balance(1, `"0xb"`))
+	do(balance(1, `"0xb"`))
+	require.Equal(t, 7, next.calls["eth_getBalance"])
+
+	// historical height
+	res = do(balance(1, `"0xa"`))
+	require.Equal(t, `"eth_getBalance-8"`, string(res[0].Result))
+
+	// a new block invalidates the latest responses only
+	cache.newBlock(11)
+	res = do(balance(1, `"latest"`))
+	require.Equal(t, `"eth_getBalance-9"`, string(res[0].Result))
+	res = do(balance(1, `"0xa"`))
+	require.Equal(t, `"eth_getBalance-8"`, string(res[0].Result))
+
+	// the latest responses are served from the explicit queries for the same height
+	cache.newBlock(12)
+	res = do(balance(1, `{"blockNumber":"0xc"}`))
+	require.Equal(t, `"eth_getBalance-10"`, string(res[0].Result))
+	res = do(balance(1, `"latest"`))
+	require.Equal(t, `"eth_getBalance-10"`, string(res[0].Result))
+	require.Equal(t, 10, next.calls["eth_getBalance"])
+
+	// batch with cached, uncached, failed and non-cacheable requests
+	res = do(fmt.Sprintf(`[%s,{"jsonrpc":"2.0","id":3,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":4,"method":"eth_fail"},{"jsonrpc":"2.0","id":5,"method":"net_version"}]`, balance(2, `"0xa"`)))
+	require.Len(t, res, 4)
+	require.Equal(t, `"eth_getBalance-8"`, string(res[0].Result))
+	require.Equal(t, `"eth_blockNumber-1"`, string(res[1].Result))
+	require.NotEmpty(t, res[2].Error)
+	require.Equal(t, `"net_version-1"`, string(res[3].Result))
+	require.Equal(t, 10, next.calls["eth_getBalance"])
+
+	res = do(`[{"jsonrpc":"2.0","id":3,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":4,"method":"eth_fail"}]`)
+	require.Equal(t, `"eth_blockNumber-1"`, string(res[0].Result))
+	require.Equal(t, 1, next.calls["eth_blockNumber"])
+	require.Equal(t, 2, next.calls["eth_fail"])
+}
+
+func TestResponseCacheRollback(t *testing.T) {
+	cache, err := newResponseCache(log.NewNopLogger(), 10, []string{"eth_getBalance", "eth_blockNumber"})
+	require.NoError(t, err)
+
+	next := &countingHandler{calls: make(map[string]int)}
+	handler := cache.Handler(next)
+
+	do := func(body string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		msgs, _ := parseMessages(rec.Body.Bytes())
+		require.Len(t, msgs, 1)
+		return string(msgs[0].Result)
+	}
+
+	blockNumber := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`
+	balance := `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x0000000000000000000000000000000000000001","0x9"]}`
+
+	cache.newBlock(10)
+	require.Equal(t, `"eth_blockNumber-1"`, do(blockNumber))
+	require.Equal(t, `"eth_getBalance-1"`, do(balance))
+
+	// the chain is reverted to the height 8 and the block 9 is committed again
+	cache.Reset()
+	require.Equal(t, int64(0), cache.Height())
+	require.Equal(t, `"eth_blockNumber-2"`, do(blockNumber))
+
+	cache.newBlock(9)
+	require.Equal(t, `"eth_blockNumber-3"`, do(blockNumber))
+	require.Equal(t, `"eth_getBalance-2"`, do(balance))
+	require.Equal(t, `"eth_getBalance-2"`, do(balance))
+
+	// a lower height is a rollback as well
+	cache.newBlock(12)
+	require.Equal(t, `"eth_getBalance-2"`, do(balance))
+	cache.newBlock(10)
+	require.Equal(t, int64(10), cache.Height())
+	require.Equal(t, `"eth_blockNumber-4"`, do(blockNumber))
+	require.Equal(t, `"eth_getBalance-3"`, do(balance))
+}
+
+// blockNumberService counts the eth_blockNumber calls served by the JSON-RPC server.
+type blockNumberService struct {
+	calls uint64
+}
+
+func (s *blockNumberService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(atomic.AddUint64(&s.calls, 1))
+}
+
+func TestResponseCacheTransports(t *testing.T) {
+	cache, err := newResponseCache(log.NewNopLogger(), 10, []string{"eth_blockNumber"})
+	require.NoError(t, err)
+	cache.newBlock(10)
+
+	service := &blockNumberService{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	defer server.Stop()
+
+	httpSrv := httptest.NewServer(cache.Handler(server))
+	defer httpSrv.Close()
+
+	wsSrv := httptest.NewServer(WebsocketHandler(server))
+	defer wsSrv.Close()
+
+	endpoint := filepath.Join(t.TempDir(), "rpc.ipc")
+	listener, err := net.Listen("unix", endpoint)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go ServeListener(server, listener) // nolint: errcheck
+
+	blockNumber := func(client *rpc.Client) uint64 {
+		var res hexutil.Uint64
+		require.NoError(t, client.Call(&res, "eth_blockNumber"))
+		return uint64(res)
+	}
+
+	// the HTTP calls are answered from the cache
+	httpClient, err := rpc.DialHTTP(httpSrv.URL)
+	require.NoError(t, err)
+	defer httpClient.Close()
+
+	require.Equal(t, uint64(1), blockNumber(httpClient))
+	require.Equal(t, uint64(1), blockNumber(httpClient))
+
+	// the WebSocket and IPC calls are always served by the server
+	wsClient, err := rpc.DialWebsocket(context.Background(), "ws://"+strings.TrimPrefix(wsSrv.URL, "http://"), "")
+	require.NoError(t, err)
+	defer wsClient.Close()
+
+	require.Equal(t, uint64(2), blockNumber(wsClient))
+	require.Equal(t, uint64(3), blockNumber(wsClient))
+
+	ipcClient, err := rpc.DialIPC(context.Background(), endpoint)
+	require.NoError(t, err)
+	defer ipcClient.Close()
+
+	require.Equal(t, uint64(4), blockNumber(ipcClient))
+	require.Equal(t, uint64(5), blockNumber(ipcClient))
+}
@@ -0,0 +1,254 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ethereum/go-ethereum/p2p/netutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+)
+
+// The WebSocket connection settings of the go-ethereum RPC server.
+const (
+	wsReadBuffer       = 1024
+	wsWriteBuffer      = 1024
+	wsPingInterval     = 60 * time.Second
+	wsPingWriteTimeout = 5 * time.Second
+	wsMessageSizeLimit = 15 * 1024 * 1024
+)
+
+// errCodeMethodNotFound is the JSON-RPC error code of the calls to the methods that are not registered.
+const errCodeMethodNotFound = -32601
+
+// recordRequest records the metrics of a JSON-RPC call. It is replaced by the tests.
+var recordRequest = types.RecordRequest
+
+// pendingCall defines a JSON-RPC call waiting for its response.
+type pendingCall struct {
+	method string
+	start  time.Time
+}
+
+// callRecorder records the request metrics of the JSON-RPC calls of a connection. The requests are
+// matched with their responses by id, so the metrics are the same whatever the transport. The calls
+// to methods that are not registered on the server are not recorded, which bounds the number of
+// method labels.
+type callRecorder struct {
+	mu      sync.Mutex
+	pending map[string]pendingCall
+}
+
+func newCallRecorder() *callRecorder {
+	return &callRecorder{
+		pending: make(map[string]pendingCall),
+	}
+}
+
+// requests registers the calls of the given request message or batch. Notifications are skipped
+// since they don't have a response.
+func (r *callRecorder) requests(bz []byte) {
+	msgs, _ := parseMessages(bz)
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range msgs {
+		if len(msg.ID) == 0 || msg.Method == "" {
+			continue
+		}
+
+		r.pending[string(bytes.TrimSpace(msg.ID))] = pendingCall{method: msg.Method, start: now}
+	}
+}
+
+// responses records the calls answered by the given response message or batch. The subscription
+// notifications don't have an id and are skipped.
+func (r *callRecorder) responses(bz []byte) {
+	msgs, _ := parseMessages(bz)
+	now := time.Now()
+
+	for _, msg := range msgs {
+		if len(msg.ID) == 0 {
+			continue
+		}
+
+		id := string(bytes.TrimSpace(msg.ID))
+
+		r.mu.Lock()
+		call, ok := r.pending[id]
+		delete(r.pending, id)
+		r.mu.Unlock()
+
+		if !ok || isMethodNotFound(msg.Error) {
+			continue
+		}
+
+		recordRequest(call.method, now.Sub(call.start))
+	}
+}
+
+// isMethodNotFound returns true if the given response error is returned for a method that isn't registered.
+func isMethodNotFound(rawErr json.RawMessage) bool {
+	if len(rawErr) == 0 {
+		return false
+	}
+
+	var rpcErr struct {
+		Code int `json:"code"`
+	}
+	if err := json.Unmarshal(rawErr, &rpcErr); err != nil {
+		return false
+	}
+
+	return rpcErr.Code == errCodeMethodNotFound
+}
+
+// MetricsHandler returns an http.Handler that records the request metrics of the JSON-RPC calls
+// served by the given handler.
+func MetricsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		calls := newCallRecorder()
+		calls.requests(body)
+
+		tee := &teeResponseWriter{ResponseWriter: w}
+		forwardRequest(next, tee, r, body)
+
+		calls.responses(tee.body.Bytes())
+	})
+}
+
+// teeResponseWriter is an http.ResponseWriter that keeps a copy of the response body.
+type teeResponseWriter struct {
+	http.ResponseWriter
+
+	body bytes.Buffer
+}
+
+// Write implements http.ResponseWriter.
+func (w *teeResponseWriter) Write(bz []byte) (int, error) {
+	w.body.Write(bz)
+	return w.ResponseWriter.Write(bz)
+}
+
+// codecConn defines the connection of a server codec, which is closed by the server and whose write
+// deadline is set for every response.
+type codecConn interface {
+	io.Closer
+	SetWriteDeadline(time.Time) error
+}
+
+// newMetricsCodec returns a server codec that encodes and decodes the JSON-RPC messages of the
+// connection with the given functions, and records the request metrics of the calls.
+func newMetricsCodec(conn codecConn, encode, decode func(v interface{}) error) rpc.ServerCodec {
+	calls := newCallRecorder()
+
+	return rpc.NewFuncCodec(
+		conn,
+		func(v interface{}) error {
+			bz, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+
+			if err := encode(json.RawMessage(bz)); err != nil {
+				return err
+			}
+
+			calls.responses(bz)
+			return nil
+		},
+		func(v interface{}) error {
+			if err := decode(v); err != nil {
+				return err
+			}
+
+			// the server decodes the messages as raw JSON before parsing them
+			if raw, ok := v.(*json.RawMessage); ok {
+				calls.requests(*raw)
+			}
+			return nil
+		},
+	)
+}
+
+// ServeListener accepts the connections of the given listener, such as the IPC socket, and serves the
+// JSON-RPC APIs of the server on them. It returns when the listener is closed.
+func ServeListener(server *rpc.Server, l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if netutil.IsTemporaryError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(conn)
+		dec := json.NewDecoder(conn)
+		dec.UseNumber()
+
+		go server.ServeCodec(newMetricsCodec(conn, enc.Encode, dec.Decode), 0)
+	}
+}
+
+// WebsocketHandler returns an http.Handler that serves the JSON-RPC APIs of the server over WebSocket
+// connections from any origin, as rpc.Server.WebsocketHandler does, and records the request metrics
+// of the calls.
+func WebsocketHandler(server *rpc.Server) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  wsReadBuffer,
+		WriteBufferSize: wsWriteBuffer,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader replied with the error
+			return
+		}
+
+		conn.SetReadLimit(wsMessageSizeLimit)
+
+		done := make(chan struct{})
+		defer close(done)
+
+		go wsPingLoop(conn, done)
+
+		server.ServeCodec(newMetricsCodec(conn, conn.WriteJSON, conn.ReadJSON), 0)
+	})
+}
+
+// wsPingLoop pings the WebSocket connection until done is closed, so that the idle connections are
+// kept open by the proxies.
+func wsPingLoop(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsPingWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
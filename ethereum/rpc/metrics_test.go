@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// recordedRequests replaces the request metrics recorder until the end of the test and returns the
+// methods of the recorded calls.
+func recordedRequests(t *testing.T) func() []string {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+
+	prevRecordRequest := recordRequest
+	recordRequest = func(method string, _ time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, method)
+	}
+	t.Cleanup(func() {
+		recordRequest = prevRecordRequest
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, methods...)
+	}
+}
+
+type testService struct{}
+
+func (testService) Echo(s string) string {
+	return s
+}
+
+func newTestServer(t *testing.T) *rpc.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("test", testService{}))
+	t.Cleanup(server.Stop)
+	return server
+}
+
+// callMethods calls a registered method, a method that doesn't exist and sends a notification.
+func callMethods(t *testing.T, client *rpc.Client) {
+	var res string
+	require.NoError(t, client.Call(&res, "test_echo", "hello"))
+	require.Equal(t, "hello", res)
+
+	require.Error(t, client.Call(&res, "test_unknown"))
+	require.NoError(t, client.Notify(context.Background(), "test_echo", "notification"))
+}
+
+func TestMetricsHandler(t *testing.T) {
+	methods := recordedRequests(t)
+
+	handler := MetricsHandler(&countingHandler{calls: make(map[string]int)})
+	notFound := MetricsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		msgs, batch := parseMessages(body)
+
+		responses := make([]*jsonrpcMessage, 0, len(msgs))
+		for _, msg := range msgs {
+			responses = append(responses, &jsonrpcMessage{Version: "2.0", ID: msg.ID, Error: json.RawMessage(`{"code":-32601,"message":"not found"}`)})
+		}
+
+		bz, _ := marshalMessages(responses, batch)
+		_, _ = w.Write(bz)
+	}))
+
+	do := func(handler http.Handler, body string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	do(handler, `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	require.Equal(t, []string{"eth_blockNumber"}, methods())
+
+	do(handler, `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":"a","method":"eth_getBalance","params":[]}]`)
+	require.ElementsMatch(t, []string{"eth_blockNumber", "eth_chainId", "eth_getBalance"}, methods())
+
+	// the calls to methods that are not registered are skipped
+	do(notFound, `{"jsonrpc":"2.0","id":1,"method":"eth_unknown"}`)
+	require.Len(t, methods(), 3)
+
+	// invalid requests are forwarded as is
+	do(handler, `invalid`)
+	require.Len(t, methods(), 3)
+}
+
+func TestWebsocketHandler(t *testing.T) {
+	methods := recordedRequests(t)
+
+	srv := httptest.NewServer(WebsocketHandler(newTestServer(t)))
+	defer srv.Close()
+
+	client, err := rpc.DialWebsocket(context.Background(), "ws://"+strings.TrimPrefix(srv.URL, "http://"), "")
+	require.NoError(t, err)
+	defer client.Close()
+
+	callMethods(t, client)
+
+	require.Eventually(t, func() bool {
+		return len(methods()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"test_echo"}, methods())
+}
+
+func TestServeListener(t *testing.T) {
+	methods := recordedRequests(t)
+
+	endpoint := filepath.Join(t.TempDir(), "rpc.ipc")
+	listener, err := net.Listen("unix", endpoint)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go ServeListener(newTestServer(t), listener) // nolint: errcheck
+
+	client, err := rpc.DialIPC(context.Background(), endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+
+	callMethods(t, client)
+
+	require.Eventually(t, func() bool {
+		return len(methods()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"test_echo"}, methods())
+}
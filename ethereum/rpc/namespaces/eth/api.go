@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -174,6 +175,15 @@ func (e *PublicAPI) Accounts() ([]common.Address, error) {
 
 	addresses := make([]common.Address, 0) // return [] instead of nil if empty
 
+	if signer := e.backend.ExternalSigner(); signer != nil {
+		signerAddresses, err := signer.Accounts(e.ctx)
+		if err != nil {
+			return addresses, err
+		}
+
+		return append(addresses, signerAddresses...), nil
+	}
+
 	infos, err := e.clientCtx.Keyring.List()
 	if err != nil {
 		return addresses, err
@@ -206,7 +216,11 @@ func (e *PublicAPI) GetBalance(address common.Address, blockNrOrHash rpctypes.Bl
 		Address: address.String(),
 	}
 
-	res, err := e.queryClient.Balance(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+	var res *evmtypes.QueryBalanceResponse
+	err = e.backend.QueryState(blockNum, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.Balance(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +247,11 @@ func (e *PublicAPI) GetStorageAt(address common.Address, key string, blockNrOrHa
 		Key:     key,
 	}
 
-	res, err := e.queryClient.Storage(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+	var res *evmtypes.QueryStorageResponse
+	err = e.backend.QueryState(blockNum, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.Storage(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +330,11 @@ func (e *PublicAPI) GetCode(address common.Address, blockNrOrHash rpctypes.Block
 		Address: address.String(),
 	}
 
-	res, err := e.queryClient.Code(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+	var res *evmtypes.QueryCodeResponse
+	err = e.backend.QueryState(blockNum, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.Code(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -327,9 +349,21 @@ func (e *PublicAPI) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, err
 }
 
 // Sign signs the provided data using the private key of address via Geth's signature standard.
+// When an external signer is configured, the data is signed by the signer with the Ethereum signed
+// message prefix.
 func (e *PublicAPI) Sign(address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
 	e.logger.Debug("eth_sign", "address", address.Hex(), "data", common.Bytes2Hex(data))
 
+	if signer := e.backend.ExternalSigner(); signer != nil {
+		signature, err := signer.SignText(e.ctx, address, data)
+		if err != nil {
+			e.logger.Error("external signer failed to sign data", "address", address.Hex(), "error", err.Error())
+			return nil, err
+		}
+
+		return signature, nil
+	}
+
 	from := sdk.AccAddress(address.Bytes())
 
 	_, err := e.clientCtx.Keyring.KeyByAddress(from)
@@ -460,10 +494,11 @@ func (e *PublicAPI) doCall(
 	}
 	req := evmtypes.EthCallRequest{Args: bz, GasCap: e.backend.RPCGasCap()}
 
-	// From ContextWithHeight: if the provided height is 0,
-	// it will return an empty context and the gRPC query will use
-	// the latest block height for querying.
-	res, err := e.queryClient.EthCall(rpctypes.ContextWithHeight(blockNr.Int64()), &req)
+	var res *evmtypes.MsgEthereumTxResponse
+	err = e.backend.QueryState(blockNr, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.EthCall(ctx, &req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -634,14 +669,9 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 	}
 
 	// Get the transaction result from the log
-	var status hexutil.Uint
-	if strings.Contains(res.TxResult.GetLog(), evmtypes.AttributeKeyEthereumTxFailed) {
-		status = hexutil.Uint(ethtypes.ReceiptStatusFailed)
-	} else {
-		status = hexutil.Uint(ethtypes.ReceiptStatusSuccessful)
-	}
+	failed := strings.Contains(res.TxResult.GetLog(), evmtypes.AttributeKeyEthereumTxFailed)
 
-	from, err := msg.GetSender(e.chainIDEpoch)
+	from, err := rpctypes.GetTxSender(msg, e.chainIDEpoch)
 	if err != nil {
 		return nil, err
 	}
@@ -651,6 +681,125 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 		e.logger.Debug("logs not found", "hash", hash.Hex(), "error", err.Error())
 	}
 
+	return newReceipt(
+		hash, txData, from, failed,
+		cumulativeGasUsed, uint64(res.TxResult.GasUsed), logs,
+		common.BytesToHash(resBlock.Block.Header.Hash()), uint64(res.Height), uint64(res.Index),
+	), nil
+}
+
+// GetBlockReceipts returns the receipts of all the ethereum transactions in the block identified by
+// number or hash. The block results are loaded once for the whole block. As for
+// GetTransactionReceipt, the transactions rejected by the ante handler have no receipt and the logs
+// are returned as indexed.
+func (e *PublicAPI) GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	e.logger.Debug("eth_getBlockReceipts", "block number or hash", blockNrOrHash)
+
+	var (
+		resBlock *tmrpctypes.ResultBlock
+		err      error
+	)
+	if blockNrOrHash.BlockHash != nil {
+		resBlock, err = e.clientCtx.Client.BlockByHash(e.ctx, blockNrOrHash.BlockHash.Bytes())
+	} else {
+		blockNum, blockErr := e.getBlockNumber(blockNrOrHash)
+		if blockErr != nil {
+			return nil, blockErr
+		}
+		resBlock, err = e.backend.GetTendermintBlockByNumber(blockNum)
+	}
+	if err != nil {
+		e.logger.Debug("block not found", "block number or hash", blockNrOrHash, "error", err.Error())
+		return nil, nil
+	}
+
+	if resBlock == nil || resBlock.Block == nil {
+		e.logger.Debug("block not found", "block number or hash", blockNrOrHash)
+		return nil, nil
+	}
+
+	block := resBlock.Block
+	blockHash := common.BytesToHash(block.Header.Hash())
+	height := uint64(block.Height)
+
+	blockRes, err := e.clientCtx.Client.BlockResults(e.ctx, &block.Height)
+	if err != nil {
+		e.logger.Debug("failed to retrieve block results", "height", block.Height, "error", err.Error())
+		return nil, nil
+	}
+
+	if len(blockRes.TxsResults) != len(block.Txs) {
+		return nil, fmt.Errorf("block %d has %d txs but %d tx results", block.Height, len(block.Txs), len(blockRes.TxsResults))
+	}
+
+	receipts := []map[string]interface{}{}
+	cumulativeGasUsed := uint64(0)
+
+	for i, txBz := range block.Txs {
+		txResult := blockRes.TxsResults[i]
+		cumulativeGasUsed += uint64(txResult.GasUsed)
+
+		if !txResult.IsOK() {
+			continue
+		}
+
+		tx, err := e.clientCtx.TxConfig.TxDecoder()(txBz)
+		if err != nil {
+			e.logger.Debug("failed to decode transaction in block", "height", block.Height, "error", err.Error())
+			continue
+		}
+
+		txLogs := backend.TxLogsFromEvents(e.clientCtx.Codec, txResult.Events)
+		failed := strings.Contains(txResult.Log, evmtypes.AttributeKeyEthereumTxFailed)
+
+		for _, msg := range tx.GetMsgs() {
+			ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+			if !ok {
+				continue
+			}
+
+			txData, err := evmtypes.UnpackTxData(ethMsg.Data)
+			if err != nil {
+				e.logger.Error("failed to unpack tx data", "error", err.Error())
+				return nil, err
+			}
+
+			from, err := rpctypes.GetTxSender(ethMsg, e.chainIDEpoch)
+			if err != nil {
+				return nil, err
+			}
+
+			hash := ethMsg.AsTransaction().Hash()
+
+			logs := []*ethtypes.Log{}
+			for _, log := range txLogs {
+				if log.TxHash == hash {
+					logs = append(logs, log)
+				}
+			}
+
+			receipts = append(receipts, newReceipt(
+				hash, txData, from, failed,
+				cumulativeGasUsed, uint64(txResult.GasUsed), logs,
+				blockHash, height, uint64(i),
+			))
+		}
+	}
+
+	return receipts, nil
+}
+
+// newReceipt returns the JSON-RPC receipt of an ethereum transaction.
+func newReceipt(
+	hash common.Hash, txData evmtypes.TxData, from common.Address, failed bool,
+	cumulativeGasUsed, gasUsed uint64, logs []*ethtypes.Log,
+	blockHash common.Hash, blockNumber, txIndex uint64,
+) map[string]interface{} {
+	status := hexutil.Uint(ethtypes.ReceiptStatusSuccessful)
+	if failed {
+		status = hexutil.Uint(ethtypes.ReceiptStatusFailed)
+	}
+
 	receipt := map[string]interface{}{
 		// Consensus fields: These fields are defined by the Yellow Paper
 		"status":            status,
@@ -662,14 +811,14 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 		// They are stored in the chain database.
 		"transactionHash": hash,
 		"contractAddress": nil,
-		"gasUsed":         hexutil.Uint64(res.TxResult.GasUsed),
+		"gasUsed":         hexutil.Uint64(gasUsed),
 		"type":            hexutil.Uint(txData.TxType()),
 
 		// Inclusion information: These fields provide information about the inclusion of the
 		// transaction corresponding to this receipt.
-		"blockHash":        common.BytesToHash(resBlock.Block.Header.Hash()).Hex(),
-		"blockNumber":      hexutil.Uint64(res.Height),
-		"transactionIndex": hexutil.Uint64(res.Index),
+		"blockHash":        blockHash.Hex(),
+		"blockNumber":      hexutil.Uint64(blockNumber),
+		"transactionIndex": hexutil.Uint64(txIndex),
 
 		// sender and receiver (contract or EOA) addreses
 		"from": from,
@@ -685,7 +834,7 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 		receipt["contractAddress"] = crypto.CreateAddress(from, txData.GetNonce())
 	}
 
-	return receipt, nil
+	return receipt
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
@@ -746,6 +895,18 @@ func (e *PublicAPI) GetProof(address common.Address, storageKeys []string, block
 	ctx := rpctypes.ContextWithHeight(height)
 	clientCtx := e.clientCtx.WithHeight(height)
 
+	// generate the proofs against the EVM state trie, if it's enabled
+	proofRes, err := e.queryClient.Proof(ctx, &evmtypes.QueryProofRequest{
+		Address:     address.String(),
+		StorageKeys: storageKeys,
+	})
+	switch {
+	case err == nil:
+		return accountResultFromProof(address, proofRes)
+	case status.Code(err) != codes.NotFound:
+		return nil, err
+	}
+
 	// query storage proofs
 	storageProofs := make([]rpctypes.StorageResult, len(storageKeys))
 
@@ -803,7 +964,34 @@ func (e *PublicAPI) GetProof(address common.Address, storageKeys []string, block
 		Balance:      (*hexutil.Big)(balance.BigInt()),
 		CodeHash:     common.HexToHash(res.CodeHash),
 		Nonce:        hexutil.Uint64(res.Nonce),
-		StorageHash:  common.Hash{}, // NOTE: Ethermint doesn't have a storage hash. TODO: implement?
+		StorageHash:  common.Hash{}, // NOTE: the storage hash is only available on the EVM state trie
+		StorageProof: storageProofs,
+	}, nil
+}
+
+// accountResultFromProof returns the account result from the EVM state trie proofs.
+func accountResultFromProof(address common.Address, res *evmtypes.QueryProofResponse) (*rpctypes.AccountResult, error) {
+	balance, ok := sdk.NewIntFromString(res.Balance)
+	if !ok {
+		return nil, errors.New("invalid balance")
+	}
+
+	storageProofs := make([]rpctypes.StorageResult, len(res.StorageProofs))
+	for i, storageProof := range res.StorageProofs {
+		storageProofs[i] = rpctypes.StorageResult{
+			Key:   storageProof.Key,
+			Value: (*hexutil.Big)(common.HexToHash(storageProof.Value).Big()),
+			Proof: storageProof.Proof,
+		}
+	}
+
+	return &rpctypes.AccountResult{
+		Address:      address,
+		AccountProof: res.AccountProof,
+		Balance:      (*hexutil.Big)(balance.BigInt()),
+		CodeHash:     common.HexToHash(res.CodeHash),
+		Nonce:        hexutil.Uint64(res.Nonce),
+		StorageHash:  common.HexToHash(res.StorageHash),
 		StorageProof: storageProofs,
 	}, nil
 }
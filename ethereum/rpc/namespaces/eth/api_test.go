@@ -0,0 +1,116 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmrpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/app"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+	rpctypes "github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// blockClient is a Tendermint client serving a single block and its results.
+type blockClient struct {
+	rpcclient.Client
+
+	block   *tmtypes.Block
+	results []*abci.ResponseDeliverTx
+}
+
+func (c blockClient) BlockByHash(context.Context, []byte) (*tmrpctypes.ResultBlock, error) {
+	return &tmrpctypes.ResultBlock{Block: c.block}, nil
+}
+
+func (c blockClient) BlockResults(_ context.Context, height *int64) (*tmrpctypes.ResultBlockResults, error) {
+	return &tmrpctypes.ResultBlockResults{Height: *height, TxsResults: c.results}, nil
+}
+
+func TestGetBlockReceipts(t *testing.T) {
+	encodingConfig := encoding.MakeConfig(app.ModuleBasics)
+	chainID := big.NewInt(9000)
+	from, privKey := tests.NewAddrKey()
+	to := tests.GenerateAddress()
+
+	// signedTx returns an encoded transaction with a signed ethereum message and its hash
+	signedTx := func(nonce uint64) ([]byte, common.Hash) {
+		msg := evmtypes.NewTx(chainID, nonce, &to, big.NewInt(1), 100000, big.NewInt(1), nil, nil)
+		msg.From = from.Hex()
+		require.NoError(t, msg.Sign(ethtypes.LatestSignerForChainID(chainID), tests.NewSigner(privKey)))
+
+		builder := encodingConfig.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msg))
+		bz, err := encodingConfig.TxConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz, msg.AsTransaction().Hash()
+	}
+
+	// logEvents returns the events of an ethereum transaction with the given logs
+	logEvents := func(logs ...*ethtypes.Log) []abci.Event {
+		event := abci.Event{Type: evmtypes.EventTypeTxLog}
+		for _, log := range logs {
+			event.Attributes = append(event.Attributes, abci.EventAttribute{
+				Key:   []byte(evmtypes.AttributeKeyTxLog),
+				Value: encodingConfig.Marshaler.MustMarshal(evmtypes.NewLogFromEth(log)),
+			})
+		}
+		return []abci.Event{event}
+	}
+
+	tx1, hash1 := signedTx(0)
+	tx2, _ := signedTx(1)
+	tx3, hash3 := signedTx(1)
+
+	// the logs are indexed by the keeper with their position on the block and the ethereum tx index
+	log1 := &ethtypes.Log{Address: to, TxHash: hash1, TxIndex: 0, Index: 0, BlockNumber: 5}
+	log2 := &ethtypes.Log{Address: to, TxHash: hash1, TxIndex: 0, Index: 1, BlockNumber: 5}
+	log3 := &ethtypes.Log{Address: to, TxHash: hash3, TxIndex: 1, Index: 2, BlockNumber: 5}
+
+	block := tmtypes.MakeBlock(5, []tmtypes.Tx{tx1, tx2, tx3}, nil, nil)
+	results := []*abci.ResponseDeliverTx{
+		{Code: abci.CodeTypeOK, GasUsed: 30000, Events: logEvents(log1, log2)},
+		// rejected by the ante handler
+		{Code: 5, GasUsed: 10000},
+		{Code: abci.CodeTypeOK, GasUsed: 40000, Log: evmtypes.AttributeKeyEthereumTxFailed, Events: logEvents(log3)},
+	}
+
+	api := &PublicAPI{
+		ctx:          context.Background(),
+		clientCtx:    client.Context{}.WithTxConfig(encodingConfig.TxConfig).WithCodec(encodingConfig.Marshaler).WithClient(blockClient{block: block, results: results}),
+		chainIDEpoch: chainID,
+		logger:       log.NewNopLogger(),
+	}
+
+	blockHash := common.BytesToHash(block.Hash())
+	receipts, err := api.GetBlockReceipts(rpctypes.BlockNumberOrHash{BlockHash: &blockHash})
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+
+	require.Equal(t, hash1, receipts[0]["transactionHash"])
+	require.Equal(t, hexutil.Uint(ethtypes.ReceiptStatusSuccessful), receipts[0]["status"])
+	require.Equal(t, hexutil.Uint64(0), receipts[0]["transactionIndex"])
+	require.Equal(t, hexutil.Uint64(30000), receipts[0]["cumulativeGasUsed"])
+	require.Equal(t, []*ethtypes.Log{log1, log2}, receipts[0]["logs"])
+
+	require.Equal(t, hash3, receipts[1]["transactionHash"])
+	require.Equal(t, hexutil.Uint(ethtypes.ReceiptStatusFailed), receipts[1]["status"])
+	require.Equal(t, hexutil.Uint64(2), receipts[1]["transactionIndex"])
+	require.Equal(t, hexutil.Uint64(80000), receipts[1]["cumulativeGasUsed"])
+	require.Equal(t, []*ethtypes.Log{log3}, receipts[1]["logs"])
+}
@@ -11,10 +11,10 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
-	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -26,6 +26,7 @@ import (
 type Backend interface {
 	GetBlockByNumber(blockNum types.BlockNumber, fullTx bool) (map[string]interface{}, error)
 	HeaderByNumber(blockNum types.BlockNumber) (*ethtypes.Header, error)
+	BlockNumber() (hexutil.Uint64, error)
 	HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
 	GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, error)
 	GetLogsByNumber(blockNum types.BlockNumber) ([][]*ethtypes.Log, error)
@@ -39,6 +40,13 @@ type Backend interface {
 // consider a filter inactive if it has not been polled for within deadline
 var deadline = 5 * time.Minute
 
+// subscription types, as defined by the eth_subscribe method
+const (
+	subscriptionNewHeads   = "newHeads"
+	subscriptionLogs       = "logs"
+	subscriptionPendingTxs = "newPendingTransactions"
+)
+
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -58,16 +66,21 @@ type PublicFilterAPI struct {
 	events    *EventSystem
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
+
+	// number of active subscriptions per type, guarded by filtersMu
+	subscriptions map[string]int
 }
 
-// NewPublicAPI returns a new PublicFilterAPI instance.
-func NewPublicAPI(logger log.Logger, tmWSClient *rpcclient.WSClient, backend Backend) *PublicFilterAPI {
+// NewPublicAPI returns a new PublicFilterAPI instance that receives the Tendermint events from the
+// given event system.
+func NewPublicAPI(logger log.Logger, events *EventSystem, backend Backend) *PublicFilterAPI {
 	logger = logger.With("api", "filter")
 	api := &PublicFilterAPI{
-		logger:  logger,
-		backend: backend,
-		filters: make(map[rpc.ID]*filter),
-		events:  NewEventSystem(logger, tmWSClient),
+		logger:        logger,
+		backend:       backend,
+		filters:       make(map[rpc.ID]*filter),
+		subscriptions: make(map[string]int),
+		events:        events,
 	}
 
 	go api.timeoutLoop()
@@ -160,28 +173,23 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
-	rpcSub := notifier.CreateSubscription()
-
-	ctx, cancelFn := context.WithTimeout(context.Background(), deadline)
-	defer cancelFn()
-
-	api.events.WithContext(ctx)
-
 	pendingTxSub, cancelSubs, err := api.events.SubscribePendingTxs()
 	if err != nil {
-		return nil, err
+		return &rpc.Subscription{}, err
 	}
 
+	rpcSub := notifier.CreateSubscription()
+	untrack := api.trackSubscription(subscriptionPendingTxs)
+
 	go func(txsCh <-chan coretypes.ResultEvent) {
 		defer cancelSubs()
+		defer untrack()
+		defer pendingTxSub.Unsubscribe(api.events)
 
 		for {
 			select {
 			case ev, ok := <-txsCh:
 				if !ok {
-					api.filtersMu.Lock()
-					delete(api.filters, pendingTxSub.ID())
-					api.filtersMu.Unlock()
 					return
 				}
 
@@ -195,21 +203,18 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 
 				// To keep the original behavior, send a single tx hash in one notification.
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
-				err = notifier.Notify(rpcSub.ID, txHash)
-				if err != nil {
+				if err := notifier.Notify(rpcSub.ID, txHash); err != nil {
 					return
 				}
-			case <-rpcSub.Err():
-				pendingTxSub.Unsubscribe(api.events)
+			case <-rpcSub.Err(): // client send an unsubscribe request
 				return
-			case <-notifier.Closed():
-				pendingTxSub.Unsubscribe(api.events)
+			case <-notifier.Closed(): // connection dropped
 				return
 			}
 		}
 	}(pendingTxSub.eventCh)
 
-	return rpcSub, err
+	return rpcSub, nil
 }
 
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
@@ -264,29 +269,68 @@ func (api *PublicFilterAPI) NewBlockFilter() rpc.ID {
 	return headerSub.ID()
 }
 
-// NewHeads send a notification each time a new (header) block is appended to the chain.
-func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+// NewHeads send a notification each time a new (header) block is appended to the chain. When the
+// optional fromBlock criteria is provided, the headers of the blocks already committed since that
+// height are sent first so that a client can resume a previous subscription. Headers missed while
+// the connection to Tendermint is down are backfilled once it's re-established.
+func (api *PublicFilterAPI) NewHeads(ctx context.Context, crit *HeadsCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
-	api.events.WithContext(ctx)
-	rpcSub := notifier.CreateSubscription()
+	head, err := api.latestHeight()
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+
+	var fromBlock *types.BlockNumber
+	if crit != nil {
+		fromBlock = crit.FromBlock
+	}
+
+	// height of the last header sent to the subscriber
+	lastHeight, err := resumeHeight(fromBlock, head)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
 
 	headersSub, cancelSubs, err := api.events.SubscribeNewHeads()
 	if err != nil {
 		return &rpc.Subscription{}, err
 	}
 
+	rpcSub := notifier.CreateSubscription()
+	untrack := api.trackSubscription(subscriptionNewHeads)
+
 	go func(headersCh <-chan coretypes.ResultEvent) {
 		defer cancelSubs()
+		defer untrack()
+		defer headersSub.Unsubscribe(api.events)
+
+		reconnected := api.events.Reconnected()
+		backfill := lastHeight < head
 
 		for {
+			if backfill {
+				headers, err := api.headersAfter(lastHeight)
+				for _, header := range headers {
+					if err := notifier.Notify(rpcSub.ID, header); err != nil {
+						return
+					}
+					lastHeight = header.Number.Int64()
+				}
+
+				// retry on the next header if the backfill failed
+				backfill = err != nil
+				if err != nil {
+					api.logger.Error("failed to backfill headers", "error", err.Error())
+				}
+			}
+
 			select {
 			case ev, ok := <-headersCh:
 				if !ok {
-					headersSub.Unsubscribe(api.events)
 					return
 				}
 
@@ -297,56 +341,117 @@ func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, er
 				}
 
 				header := types.EthHeaderFromTendermint(data.Header)
-				err = notifier.Notify(rpcSub.ID, header)
-				if err != nil {
-					headersSub.err <- err
+				height := header.Number.Int64()
+
+				switch {
+				case height <= lastHeight: // already sent
+					continue
+				case height > lastHeight+1: // headers were missed, the current one is backfilled as well
+					backfill = true
+					continue
+				}
+
+				if err := notifier.Notify(rpcSub.ID, header); err != nil {
 					return
 				}
-			case <-rpcSub.Err():
-				headersSub.Unsubscribe(api.events)
+				lastHeight = height
+			case <-reconnected:
+				reconnected = api.events.Reconnected()
+				backfill = true
+			case <-rpcSub.Err(): // client send an unsubscribe request
 				return
-			case <-notifier.Closed():
-				headersSub.Unsubscribe(api.events)
+			case <-notifier.Closed(): // connection dropped
 				return
 			}
 		}
 	}(headersSub.eventCh)
 
-	return rpcSub, err
+	return rpcSub, nil
 }
 
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
+// When the fromBlock criteria refers to an already committed block, the matching logs since that
+// height are sent first so that a client can resume a previous subscription. Logs missed while the
+// connection to Tendermint is down are backfilled once it's re-established. The toBlock criteria is
+// ignored, the logs of all the new blocks are sent.
 func (api *PublicFilterAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
-	api.events.WithContext(ctx)
-	rpcSub := notifier.CreateSubscription()
+	head, err := api.latestHeight()
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+
+	var fromBlock *types.BlockNumber
+	if crit.FromBlock != nil {
+		from := types.BlockNumber(crit.FromBlock.Int64())
+		fromBlock = &from
+	}
+
+	lastHeight, err := resumeHeight(fromBlock, head)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+
+	// the logs are sent from the from block up to the to block, if any, whether they are backfilled
+	// or committed after the subscription
+	rangeCrit := crit
+	toBlock := rangeEnd(crit)
+	crit = filters.FilterCriteria{Addresses: crit.Addresses, Topics: crit.Topics}
 
 	logsSub, cancelSubs, err := api.events.SubscribeLogs(crit)
 	if err != nil {
 		return &rpc.Subscription{}, err
 	}
 
+	rpcSub := notifier.CreateSubscription()
+	untrack := api.trackSubscription(subscriptionLogs)
+
 	go func(logsCh <-chan coretypes.ResultEvent) {
 		defer cancelSubs()
+		defer untrack()
+		defer logsSub.Unsubscribe(api.events)
+
+		cursor := newLogsCursor(lastHeight)
+		reconnected := api.events.Reconnected()
+		backfill := lastHeight < head
+		// set after a reconnection to backfill again on the next event the blocks committed
+		// between the first backfill and the subscription to the new connection
+		recheck := false
 
 		for {
+			if backfill {
+				logs, head, err := api.logsSince(cursor.next(), rangeCrit)
+				if err != nil {
+					api.logger.Error("failed to backfill logs", "error", err.Error())
+				} else {
+					for _, log := range logs {
+						if !cursor.shouldSend(log) {
+							continue
+						}
+						if err := notifier.Notify(rpcSub.ID, log); err != nil {
+							return
+						}
+					}
+					cursor.advance(head)
+				}
+
+				// retry on the next event if the backfill failed
+				backfill = err != nil
+			}
+
 			select {
 			case ev, ok := <-logsCh:
 				if !ok {
-					logsSub.Unsubscribe(api.events)
 					return
 				}
 
-				// filter only events from EVM module txs
-				_, isMsgEthereumTx := ev.Events[evmtypes.TypeMsgEthereumTx]
-
-				if !isMsgEthereumTx {
-					// ignore transaction as it's not from the evm module
-					return
+				if recheck {
+					recheck = false
+					backfill = true
 				}
 
 				// get transaction result data
@@ -356,30 +461,59 @@ func (api *PublicFilterAPI) Logs(ctx context.Context, crit filters.FilterCriteri
 					continue
 				}
 
+				if backfill {
+					// the logs of the event are backfilled as well
+					continue
+				}
+
 				txResponse, err := evmtypes.DecodeTxResponse(dataTx.TxResult.Result.Data)
 				if err != nil {
-					return
+					api.logger.Debug("failed to decode tx response", "error", err.Error())
+					continue
 				}
 
-				logs := FilterLogs(evmtypes.LogsToEthereum(txResponse.Logs), crit.FromBlock, crit.ToBlock, crit.Addresses, crit.Topics)
+				logs := FilterLogs(evmtypes.LogsToEthereum(txResponse.Logs), nil, nil, crit.Addresses, crit.Topics)
 
 				for _, log := range logs {
-					err = notifier.Notify(rpcSub.ID, log)
-					if err != nil {
+					if toBlock >= 0 && int64(log.BlockNumber) > toBlock {
+						continue
+					}
+					if !cursor.shouldSend(log) {
+						continue
+					}
+					if err := notifier.Notify(rpcSub.ID, log); err != nil {
 						return
 					}
 				}
+			case <-reconnected:
+				reconnected = api.events.Reconnected()
+				backfill = true
+				recheck = true
 			case <-rpcSub.Err(): // client send an unsubscribe request
-				logsSub.Unsubscribe(api.events)
 				return
 			case <-notifier.Closed(): // connection dropped
-				logsSub.Unsubscribe(api.events)
 				return
 			}
 		}
 	}(logsSub.eventCh)
 
-	return rpcSub, err
+	return rpcSub, nil
+}
+
+// trackSubscription increments the number of active subscriptions of the given type and returns
+// a function that decrements it once the subscription is terminated.
+func (api *PublicFilterAPI) trackSubscription(typ string) func() {
+	api.filtersMu.Lock()
+	api.subscriptions[typ]++
+	types.SetActiveSubscriptions(typ, api.subscriptions[typ])
+	api.filtersMu.Unlock()
+
+	return func() {
+		api.filtersMu.Lock()
+		api.subscriptions[typ]--
+		types.SetActiveSubscriptions(typ, api.subscriptions[typ])
+		api.filtersMu.Unlock()
+	}
 }
 
 // NewFilter creates a new filter and returns the filter id. It can be
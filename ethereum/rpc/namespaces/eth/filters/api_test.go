@@ -0,0 +1,240 @@
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// logsBackend is a backend serving the logs of the committed blocks.
+type logsBackend struct {
+	mu   sync.Mutex
+	head int64
+	logs map[int64][]*ethtypes.Log
+}
+
+// commit commits a block with the given logs.
+func (b *logsBackend) commit(logs ...*ethtypes.Log) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.head++
+	for _, log := range logs {
+		log.BlockNumber = uint64(b.head)
+	}
+	b.logs[b.head] = logs
+	return b.head
+}
+
+func (b *logsBackend) GetBlockByNumber(types.BlockNumber, bool) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (b *logsBackend) HeaderByNumber(blockNum types.BlockNumber) (*ethtypes.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	height := int64(blockNum)
+	if blockNum < 0 {
+		height = b.head
+	}
+	return &ethtypes.Header{Number: big.NewInt(height)}, nil
+}
+
+func (b *logsBackend) BlockNumber() (hexutil.Uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return hexutil.Uint64(b.head), nil
+}
+
+func (b *logsBackend) HeaderByHash(common.Hash) (*ethtypes.Header, error) { return nil, nil }
+
+func (b *logsBackend) GetLogs(common.Hash) ([][]*ethtypes.Log, error) { return nil, nil }
+
+func (b *logsBackend) GetLogsByNumber(blockNum types.BlockNumber) ([][]*ethtypes.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return [][]*ethtypes.Log{b.logs[int64(blockNum)]}, nil
+}
+
+func (b *logsBackend) GetTransactionLogs(common.Hash) ([]*ethtypes.Log, error) { return nil, nil }
+
+func (b *logsBackend) BloomStatus() (uint64, uint64) { return 0, 0 }
+
+func (b *logsBackend) GetFilteredBlocks(from, to int64, _ [][]BloomIV, _ bool) ([]int64, error) {
+	heights := []int64{}
+	for h := from; h <= to; h++ {
+		heights = append(heights, h)
+	}
+	return heights, nil
+}
+
+// sendTxEvent sends the Tendermint event of an EVM transaction with the given logs.
+func sendTxEvent(t *testing.T, es *EventSystem, height int64, logs []*ethtypes.Log) {
+	data, err := proto.Marshal(&evmtypes.MsgEthereumTxResponse{Logs: evmtypes.NewLogsFromEth(logs)})
+	require.NoError(t, err)
+	txMsgData, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{{Data: data}}})
+	require.NoError(t, err)
+
+	result, err := tmjson.Marshal(coretypes.ResultEvent{
+		Query: evmEvents,
+		Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+			Height: height,
+			Result: abci.ResponseDeliverTx{Data: txMsgData},
+		}},
+	})
+	require.NoError(t, err)
+
+	es.tmWSClient.ResponsesCh <- rpctypes.RPCResponse{JSONRPC: "2.0", Result: json.RawMessage(result)}
+}
+
+// subscribeLogs subscribes to the logs with the given criteria and returns a function receiving the
+// next log, which fails the test if no log is received before the timeout.
+func subscribeLogs(t *testing.T, es *EventSystem, backend Backend, crit map[string]interface{}) func(timeout time.Duration) *ethtypes.Log {
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", NewPublicAPI(log.NewNopLogger(), es, backend)))
+
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+
+	logsCh := make(chan ethtypes.Log)
+	sub, err := client.EthSubscribe(context.Background(), logsCh, subscriptionLogs, crit)
+	require.NoError(t, err)
+	t.Cleanup(sub.Unsubscribe)
+
+	return func(timeout time.Duration) *ethtypes.Log {
+		select {
+		case log := <-logsCh:
+			return &log
+		case err := <-sub.Err():
+			require.NoError(t, err)
+		case <-time.After(timeout):
+		}
+		return nil
+	}
+}
+
+func TestLogsSubscriptionFromBlock(t *testing.T) {
+	es, _ := newTestEventSystem(t)
+
+	contract := common.Address{0x01}
+	newLog := func(tx byte) *ethtypes.Log {
+		return &ethtypes.Log{Address: contract, TxHash: common.Hash{tx}, Topics: []common.Hash{{0x0a}}, Data: []byte{0x0b}}
+	}
+
+	backend := &logsBackend{logs: make(map[int64][]*ethtypes.Log)}
+	backend.commit(newLog(1))
+	backend.commit(newLog(2))
+	backend.commit()
+	backend.commit(newLog(4), &ethtypes.Log{Address: common.Address{0x02}, TxHash: common.Hash{4}, Index: 1})
+
+	next := subscribeLogs(t, es, backend, map[string]interface{}{
+		"fromBlock": "0x2",
+		"address":   contract,
+	})
+	receive := func() *ethtypes.Log {
+		log := next(5 * time.Second)
+		require.NotNil(t, log, "timeout waiting for log")
+		return log
+	}
+
+	// the logs since the from block are backfilled
+	require.Equal(t, common.Hash{2}, receive().TxHash)
+	require.Equal(t, common.Hash{4}, receive().TxHash)
+
+	// the new logs are sent as they are committed
+	live := []*ethtypes.Log{newLog(5), {Address: common.Address{0x02}, TxHash: common.Hash{5}, Index: 1}}
+	sendTxEvent(t, es, backend.commit(live...), live)
+
+	log := receive()
+	require.Equal(t, common.Hash{5}, log.TxHash)
+	require.Equal(t, uint64(5), log.BlockNumber)
+}
+
+func TestLogsSubscriptionToBlock(t *testing.T) {
+	es, _ := newTestEventSystem(t)
+
+	newLog := func(tx byte) *ethtypes.Log {
+		return &ethtypes.Log{TxHash: common.Hash{tx}, Topics: []common.Hash{{0x0a}}, Data: []byte{0x0b}}
+	}
+
+	backend := &logsBackend{logs: make(map[int64][]*ethtypes.Log)}
+	for i := byte(1); i <= 4; i++ {
+		backend.commit(newLog(i))
+	}
+
+	next := subscribeLogs(t, es, backend, map[string]interface{}{
+		"fromBlock": "0x2",
+		"toBlock":   "0x3",
+	})
+
+	// only the logs up to the to block are backfilled
+	for _, tx := range []byte{2, 3} {
+		log := next(5 * time.Second)
+		require.NotNil(t, log, "timeout waiting for log")
+		require.Equal(t, common.Hash{tx}, log.TxHash)
+	}
+	require.Nil(t, next(200*time.Millisecond))
+
+	// the new logs are past the to block
+	live := []*ethtypes.Log{newLog(5)}
+	sendTxEvent(t, es, backend.commit(live...), live)
+	require.Nil(t, next(200*time.Millisecond))
+}
+
+func TestLogsSubscriptionFutureFromBlock(t *testing.T) {
+	es, _ := newTestEventSystem(t)
+
+	newLog := func(tx byte) *ethtypes.Log {
+		return &ethtypes.Log{TxHash: common.Hash{tx}, Topics: []common.Hash{{0x0a}}, Data: []byte{0x0b}}
+	}
+
+	backend := &logsBackend{logs: make(map[int64][]*ethtypes.Log)}
+	backend.commit(newLog(1))
+
+	next := subscribeLogs(t, es, backend, map[string]interface{}{
+		"fromBlock": "0x3",
+	})
+
+	// the event bus drops the events of the subscribers that are not waiting for them
+	time.Sleep(100 * time.Millisecond)
+
+	// the logs of the blocks before the from block are not sent
+	live := []*ethtypes.Log{newLog(2)}
+	sendTxEvent(t, es, backend.commit(live...), live)
+	time.Sleep(100 * time.Millisecond)
+
+	live = []*ethtypes.Log{newLog(3)}
+	sendTxEvent(t, es, backend.commit(live...), live)
+
+	log := next(5 * time.Second)
+	require.NotNil(t, log, "timeout waiting for log")
+	require.Equal(t, common.Hash{3}, log.TxHash)
+	require.Equal(t, uint64(3), log.BlockNumber)
+}
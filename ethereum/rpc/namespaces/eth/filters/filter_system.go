@@ -50,8 +50,16 @@ type EventSystem struct {
 	install   chan *Subscription // install filter for event notification
 	uninstall chan *Subscription // remove filter for event notification
 	eventBus  pubsub.EventBus
+
+	// closed and replaced every time the Tendermint WS connection is re-established
+	reconnected    chan struct{}
+	reconnectedMux *sync.RWMutex
 }
 
+// connectionCheckInterval defines how often the status of the Tendermint WS connection is checked.
+// It must be lower than the minimum reconnection backoff of the WS client (1s).
+var connectionCheckInterval = 500 * time.Millisecond
+
 // NewEventSystem creates a new manager that listens for event on the given mux,
 // parses and filters them. It uses the all map to retrieve filter changes. The
 // work loop holds its own index that is used to forward events to filters.
@@ -75,10 +83,14 @@ func NewEventSystem(logger log.Logger, tmWSClient *rpcclient.WSClient) *EventSys
 		install:    make(chan *Subscription),
 		uninstall:  make(chan *Subscription),
 		eventBus:   pubsub.NewEventBus(),
+
+		reconnected:    make(chan struct{}),
+		reconnectedMux: new(sync.RWMutex),
 	}
 
 	go es.eventLoop()
 	go es.consumeEvents()
+	go es.monitorConnection()
 	return es
 }
 
@@ -88,40 +100,85 @@ func (es *EventSystem) WithContext(ctx context.Context) {
 	es.ctx = ctx
 }
 
-// subscribe performs a new event subscription to a given Tendermint event.
-// The subscription creates a unidirectional receive event channel to receive the ResultEvent.
-func (es *EventSystem) subscribe(sub *Subscription) (*Subscription, context.CancelFunc, error) {
-	var (
-		err      error
-		cancelFn context.CancelFunc
-	)
-
-	es.ctx, cancelFn = context.WithCancel(context.Background())
-
-	existingSubs := es.eventBus.Topics()
-	for _, topic := range existingSubs {
-		if topic == sub.event {
-			eventCh, err := es.eventBus.Subscribe(sub.event)
-			if err != nil {
-				err := errors.Wrapf(err, "failed to subscribe to topic: %s", sub.event)
-				return nil, cancelFn, err
-			}
+// Reconnected returns a channel that is closed once the Tendermint WS connection is re-established
+// after a disconnect. The events emitted while the connection was down are lost, so subscribers
+// should backfill them from the backend and request a new channel to be notified of the next
+// reconnection.
+func (es *EventSystem) Reconnected() <-chan struct{} {
+	es.reconnectedMux.RLock()
+	defer es.reconnectedMux.RUnlock()
+
+	return es.reconnected
+}
+
+// monitorConnection periodically checks the status of the Tendermint WS client. The client redials
+// on its own after a connection failure, but the queries subscribed on the previous connection are
+// lost, so they are subscribed again once the connection is re-established.
+func (es *EventSystem) monitorConnection() {
+	ticker := time.NewTicker(connectionCheckInterval)
+	defer ticker.Stop()
 
-			sub.eventCh = eventCh
-			return sub, cancelFn, nil
+	connected := es.tmWSClient.IsActive()
+
+	for range ticker.C {
+		active := es.tmWSClient.IsActive()
+
+		switch {
+		case connected && !active:
+			es.logger.Error("Tendermint WS connection lost, events will be backfilled after reconnecting")
+		case !connected && active:
+			es.logger.Info("Tendermint WS connection re-established")
+			es.resubscribe()
 		}
+
+		connected = active
 	}
+}
 
-	switch sub.typ {
-	case filters.LogsSubscription:
-		err = es.tmWSClient.Subscribe(es.ctx, sub.event)
-	case filters.BlocksSubscription:
-		err = es.tmWSClient.Subscribe(es.ctx, sub.event)
-	default:
-		err = fmt.Errorf("invalid filter subscription type %d", sub.typ)
+// Resubscribe subscribes again to the Tendermint queries of all the active topics, whose subscriptions
+// are cancelled when the in-process node is restarted without closing the WS connection.
+func (es *EventSystem) Resubscribe() {
+	es.logger.Info("subscribing again to the Tendermint queries after the node restart")
+	es.resubscribe()
+}
+
+// resubscribe notifies the subscribers of a reconnection and subscribes again to the Tendermint
+// queries of all the active topics. Subscribers are notified before the queries are subscribed so
+// that the events they receive afterwards are never older than the ones they backfill.
+func (es *EventSystem) resubscribe() {
+	es.reconnectedMux.Lock()
+	close(es.reconnected)
+	es.reconnected = make(chan struct{})
+	es.reconnectedMux.Unlock()
+
+	es.indexMux.RLock()
+	topics := make([]string, 0, len(es.topicChans))
+	for topic := range es.topicChans {
+		topics = append(topics, topic)
 	}
+	es.indexMux.RUnlock()
 
-	if err != nil {
+	for _, topic := range topics {
+		if err := es.tmWSClient.Subscribe(es.ctx, topic); err != nil {
+			es.logger.Error("failed to subscribe again to query", "query", topic, "error", err.Error())
+		}
+	}
+}
+
+// subscribe performs a new event subscription to a given Tendermint event.
+// The subscription creates a unidirectional receive event channel to receive the ResultEvent.
+// The Tendermint query is only subscribed once per event topic and shared by all the
+// subscriptions to that topic. The subscription is removed when the returned function is called
+// or when the context of the event system is done.
+func (es *EventSystem) subscribe(sub *Subscription) (*Subscription, context.CancelFunc, error) {
+	ctx, cancelFn := context.WithCancel(es.ctx)
+
+	switch sub.typ {
+	case filters.LogsSubscription,
+		filters.BlocksSubscription,
+		filters.PendingTransactionsSubscription:
+	default:
+		err := fmt.Errorf("invalid filter subscription type %d", sub.typ)
 		sub.err <- err
 		return nil, cancelFn, err
 	}
@@ -130,13 +187,25 @@ func (es *EventSystem) subscribe(sub *Subscription) (*Subscription, context.Canc
 	es.install <- sub
 	<-sub.installed
 
-	eventCh, err := es.eventBus.Subscribe(sub.event)
+	if sub.installErr != nil {
+		sub.err <- sub.installErr
+		return nil, cancelFn, sub.installErr
+	}
+
+	eventCh, unsubscribe, err := es.eventBus.Subscribe(sub.event)
 	if err != nil {
 		err := errors.Wrapf(err, "failed to subscribe to topic after installed: %s", sub.event)
 		return sub, cancelFn, err
 	}
 
 	sub.eventCh = eventCh
+	sub.unsubscribe = unsubscribe
+
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe(es)
+	}()
+
 	return sub, cancelFn, nil
 }
 
@@ -220,12 +289,23 @@ func (es *EventSystem) eventLoop() {
 		select {
 		case f := <-es.install:
 			es.indexMux.Lock()
-			es.index[f.typ][f.id] = f
-			ch := make(chan coretypes.ResultEvent)
-			es.topicChans[f.event] = ch
-			if err := es.eventBus.AddTopic(f.event, ch); err != nil {
-				es.logger.Error("failed to add event topic to event bus", "topic", f.event, "error", err.Error())
+			if _, ok := es.topicChans[f.event]; !ok {
+				// the query is subscribed by the event loop, as it is unsubscribed on the removal of the
+				// last subscriber of the topic, so that both can't be interleaved
+				if err := es.tmWSClient.Subscribe(es.ctx, f.event); err != nil {
+					es.indexMux.Unlock()
+					f.installErr = err
+					close(f.installed)
+					continue
+				}
+
+				ch := make(chan coretypes.ResultEvent)
+				es.topicChans[f.event] = ch
+				if err := es.eventBus.AddTopic(f.event, ch); err != nil {
+					es.logger.Error("failed to add event topic to event bus", "topic", f.event, "error", err.Error())
+				}
 			}
+			es.index[f.typ][f.id] = f
 			es.indexMux.Unlock()
 			close(f.installed)
 		case f := <-es.uninstall:
@@ -0,0 +1,134 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+// tmServer is a Tendermint WebSocket endpoint that records the queries subscribed by the clients.
+type tmServer struct {
+	mu         sync.Mutex
+	subscribed map[string]bool
+}
+
+func (s *tmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Query string `json:"query"`
+			} `json:"params"`
+		}
+
+		_, bz, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err := json.Unmarshal(bz, &req); err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		switch req.Method {
+		case "subscribe":
+			s.subscribed[req.Params.Query] = true
+		case "unsubscribe":
+			s.subscribed[req.Params.Query] = false
+		}
+		s.mu.Unlock()
+	}
+}
+
+// isSubscribed returns true if the query is subscribed.
+func (s *tmServer) isSubscribed(query string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.subscribed[query]
+}
+
+// newTestEventSystem returns an event system connected to a Tendermint WebSocket endpoint, which
+// records the subscribed queries. The events are sent through the responses channel of the client.
+func newTestEventSystem(t *testing.T) (*EventSystem, *tmServer) {
+	tm := &tmServer{subscribed: make(map[string]bool)}
+	srv := httptest.NewServer(tm)
+	t.Cleanup(srv.Close)
+
+	client, err := rpcclient.NewWS(srv.URL, "/websocket")
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() { _ = client.Stop() })
+
+	return NewEventSystem(log.NewNopLogger(), client), tm
+}
+
+func TestEventSystemSubscribeWhileUninstalling(t *testing.T) {
+	es, tm := newTestEventSystem(t)
+
+	sub, _, err := es.SubscribeNewHeads()
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		// the last subscriber of the topic is uninstalled while a new one subscribes
+		sub.Unsubscribe(es)
+		sub, _, err = es.SubscribeNewHeads()
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return tm.isSubscribed(headerEvents)
+		}, time.Second, 5*time.Millisecond, "the query of the new subscription was unsubscribed")
+	}
+
+	sub.Unsubscribe(es)
+	require.Eventually(t, func() bool {
+		return !tm.isSubscribed(headerEvents)
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestEventSystemSubscriptionCancel(t *testing.T) {
+	es, tm := newTestEventSystem(t)
+
+	sub, cancelSub, err := es.SubscribeNewHeads()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return tm.isSubscribed(headerEvents)
+	}, time.Second, 5*time.Millisecond)
+
+	// cancelling the subscription removes it and unsubscribes the query of its last subscriber
+	cancelSub()
+	require.Eventually(t, func() bool {
+		return !tm.isSubscribed(headerEvents)
+	}, time.Second, 5*time.Millisecond)
+
+	// the subscription is only removed once
+	sub.Unsubscribe(es)
+
+	other, cancelOther, err := es.SubscribeNewHeads()
+	require.NoError(t, err)
+	defer cancelOther()
+
+	require.Eventually(t, func() bool {
+		return tm.isSubscribed(headerEvents)
+	}, time.Second, 5*time.Millisecond)
+
+	sub.Unsubscribe(es)
+	time.Sleep(50 * time.Millisecond)
+	require.True(t, tm.isSubscribed(headerEvents), "the subscription was removed twice")
+	require.NotNil(t, other.Event())
+}
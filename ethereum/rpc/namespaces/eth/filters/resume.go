@@ -0,0 +1,184 @@
+package filters
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+)
+
+// maxResumeBlocks defines the maximum number of blocks that are backfilled to a subscriber, either
+// when resuming from the block provided on the subscription or after a Tendermint reconnection.
+const maxResumeBlocks = 10000
+
+// HeadsCriteria defines the optional parameters of the newHeads subscription.
+type HeadsCriteria struct {
+	// FromBlock defines the height from which the headers are sent to the subscriber. Headers of
+	// blocks that have already been committed are backfilled before any new header.
+	FromBlock *types.BlockNumber `json:"fromBlock"`
+}
+
+// logID uniquely identifies a log within a block.
+type logID struct {
+	txHash common.Hash
+	index  uint
+}
+
+// logsCursor tracks the logs sent to a subscriber so that the logs backfilled from the backend
+// are never sent twice. Tendermint provides instant finality, so blocks are never reorganized and
+// the logs sent to a subscriber never need to be sent again with the removed flag set.
+type logsCursor struct {
+	// height of the latest block with logs sent to the subscriber
+	height int64
+	// complete is true when all the matching logs up to height have been sent
+	complete bool
+	// logs sent at height
+	delivered map[logID]struct{}
+}
+
+// newLogsCursor returns a cursor for which all the logs up to the given height have been sent.
+func newLogsCursor(height int64) *logsCursor {
+	return &logsCursor{
+		height:    height,
+		complete:  true,
+		delivered: make(map[logID]struct{}),
+	}
+}
+
+// next returns the height from which logs must be backfilled.
+func (c *logsCursor) next() int64 {
+	if c.complete {
+		return c.height + 1
+	}
+	return c.height
+}
+
+// shouldSend returns true if the log hasn't been sent yet, recording it as sent.
+func (c *logsCursor) shouldSend(log *ethtypes.Log) bool {
+	height := int64(log.BlockNumber)
+	id := logID{txHash: log.TxHash, index: log.Index}
+
+	switch {
+	case height < c.height, height == c.height && c.complete:
+		// all the logs up to the height of a complete cursor have been sent
+		return false
+	case height > c.height:
+		c.height = height
+		c.complete = false
+		c.delivered = make(map[logID]struct{})
+	default:
+		if _, ok := c.delivered[id]; ok {
+			return false
+		}
+	}
+
+	c.delivered[id] = struct{}{}
+	return true
+}
+
+// advance marks all the logs up to the given height as sent.
+func (c *logsCursor) advance(height int64) {
+	if height > c.height {
+		c.height = height
+		c.delivered = make(map[logID]struct{})
+	}
+	c.complete = true
+}
+
+// latestHeight returns the height of the latest committed block.
+func (api *PublicFilterAPI) latestHeight() (int64, error) {
+	height, err := api.backend.BlockNumber()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to fetch latest block number")
+	}
+
+	return int64(height), nil
+}
+
+// resumeHeight returns the height of the last block already seen by a subscriber that resumes
+// from the given block. Blocks after it are backfilled.
+func resumeHeight(fromBlock *types.BlockNumber, head int64) (int64, error) {
+	if fromBlock == nil || *fromBlock < 0 {
+		return head, nil
+	}
+
+	from := fromBlock.Int64()
+	if head-from > maxResumeBlocks {
+		return 0, errors.Errorf("cannot resume from block %d, more than %d blocks behind the latest block %d", from, maxResumeBlocks, head)
+	}
+
+	return from - 1, nil
+}
+
+// backfillStart returns the first height to be backfilled, skipping the blocks that exceed the
+// maximum backfill range.
+func (api *PublicFilterAPI) backfillStart(from, head int64) int64 {
+	if head-from+1 <= maxResumeBlocks {
+		return from
+	}
+
+	start := head - maxResumeBlocks + 1
+	api.logger.Error("subscriber is too far behind, skipping blocks", "from", from, "to", start-1)
+	return start
+}
+
+// headersAfter returns the headers of the blocks committed after the given height.
+func (api *PublicFilterAPI) headersAfter(height int64) ([]*ethtypes.Header, error) {
+	head, err := api.latestHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []*ethtypes.Header{}
+	for h := api.backfillStart(height+1, head); h <= head; h++ {
+		header, err := api.backend.HeaderByNumber(types.BlockNumber(h))
+		if err != nil {
+			return headers, errors.Wrapf(err, "failed to fetch header by number %d", h)
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers, nil
+}
+
+// rangeEnd returns the last block of the range of the criteria, or -1 if the range has no end.
+func rangeEnd(crit filters.FilterCriteria) int64 {
+	if crit.ToBlock == nil || crit.ToBlock.Int64() < 0 {
+		return -1
+	}
+	return crit.ToBlock.Int64()
+}
+
+// logsSince returns the logs matching the criteria from the given height up to the latest block,
+// or up to the to block of the criteria if it is lower, along with the latest block height.
+func (api *PublicFilterAPI) logsSince(from int64, crit filters.FilterCriteria) ([]*ethtypes.Log, int64, error) {
+	head, err := api.latestHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	to := head
+	if end := rangeEnd(crit); end >= 0 && end < to {
+		to = end
+	}
+
+	from = api.backfillStart(from, head)
+	if crit.FromBlock != nil && crit.FromBlock.Int64() > from {
+		from = crit.FromBlock.Int64()
+	}
+
+	if from > to {
+		return []*ethtypes.Log{}, head, nil
+	}
+
+	logs, err := NewRangeFilter(api.logger, api.backend, from, to, crit.Addresses, crit.Topics).Logs(api.events.ctx)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to fetch logs in range [%d, %d]", from, to)
+	}
+
+	return logs, head, nil
+}
@@ -0,0 +1,79 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+)
+
+func TestLogsCursor(t *testing.T) {
+	newLog := func(height uint64, tx byte, index uint) *ethtypes.Log {
+		return &ethtypes.Log{BlockNumber: height, TxHash: common.Hash{tx}, Index: index}
+	}
+
+	cursor := newLogsCursor(10)
+	require.Equal(t, int64(11), cursor.next())
+
+	// the logs up to the height of the cursor have been sent
+	require.False(t, cursor.shouldSend(newLog(10, 1, 0)))
+
+	// live logs
+	require.True(t, cursor.shouldSend(newLog(12, 1, 0)))
+	require.True(t, cursor.shouldSend(newLog(12, 1, 1)))
+	require.Equal(t, int64(12), cursor.next())
+
+	// backfilled logs, including the ones already sent
+	require.False(t, cursor.shouldSend(newLog(11, 2, 0)))
+	require.False(t, cursor.shouldSend(newLog(12, 1, 0)))
+	require.True(t, cursor.shouldSend(newLog(12, 3, 2)))
+	require.True(t, cursor.shouldSend(newLog(14, 4, 0)))
+	cursor.advance(14)
+	require.Equal(t, int64(15), cursor.next())
+
+	// late live logs of a backfilled block
+	require.False(t, cursor.shouldSend(newLog(14, 4, 0)))
+
+	// no logs at the latest block
+	cursor.advance(20)
+	require.Equal(t, int64(21), cursor.next())
+	require.True(t, cursor.shouldSend(newLog(21, 5, 0)))
+}
+
+func TestResumeHeight(t *testing.T) {
+	latest := types.EthLatestBlockNumber
+	recent := types.BlockNumber(95)
+	future := types.BlockNumber(120)
+	old := types.BlockNumber(1)
+
+	testCases := []struct {
+		name      string
+		fromBlock *types.BlockNumber
+		head      int64
+		expHeight int64
+		expPass   bool
+	}{
+		{"no from block", nil, 100, 100, true},
+		{"latest", &latest, 100, 100, true},
+		{"resume", &recent, 100, 94, true},
+		{"future", &future, 100, 119, true},
+		{"too old", &old, maxResumeBlocks + 10, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			height, err := resumeHeight(tc.fromBlock, tc.head)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expHeight, height)
+		})
+	}
+}
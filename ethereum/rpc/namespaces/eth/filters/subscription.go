@@ -1,6 +1,7 @@
 package filters
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -8,21 +9,27 @@ import (
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/rpc"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/pubsub"
 )
 
 // Subscription defines a wrapper for the private subscription
 type Subscription struct {
-	id        rpc.ID
-	typ       filters.Type
-	event     string
-	created   time.Time
-	logsCrit  filters.FilterCriteria
-	logs      chan []*ethtypes.Log
-	hashes    chan []common.Hash
-	headers   chan *ethtypes.Header
-	installed chan struct{} // closed when the filter is installed
-	eventCh   <-chan coretypes.ResultEvent
-	err       chan error
+	id         rpc.ID
+	typ        filters.Type
+	event      string
+	created    time.Time
+	logsCrit   filters.FilterCriteria
+	logs       chan []*ethtypes.Log
+	hashes     chan []common.Hash
+	headers    chan *ethtypes.Header
+	installed  chan struct{} // closed when the filter is installed
+	installErr error         // set if the Tendermint query failed to be subscribed on install
+	eventCh    <-chan coretypes.ResultEvent
+	err        chan error
+
+	unsubscribe  pubsub.UnsubscribeFunc // removes eventCh from the event bus topic
+	unsubscribed int32                  // set to 1 once the subscription is removed
 }
 
 // ID returns the underlying subscription RPC identifier.
@@ -31,8 +38,17 @@ func (s Subscription) ID() rpc.ID {
 }
 
 // Unsubscribe from the current subscription to Tendermint Websocket. It sends an error to the
-// subscription error channel if unsubscription fails.
+// subscription error channel if unsubscription fails. The subscription is only removed once, the
+// next calls are no-ops.
 func (s *Subscription) Unsubscribe(es *EventSystem) {
+	if !atomic.CompareAndSwapInt32(&s.unsubscribed, 0, 1) {
+		return
+	}
+
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+
 	go func() {
 	uninstallLoop:
 		for {
@@ -0,0 +1,141 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DevNode controls the block production and the block time of the in-process node of a
+// development chain.
+type DevNode interface {
+	// Mine produces a block with the transactions of the mempool and returns once it's committed.
+	Mine(ctx context.Context) error
+	// SetAutomine enables or disables the production of a block for each transaction received.
+	SetAutomine(enabled bool)
+	// SetIntervalMining produces blocks at the given interval, it's disabled if the interval is 0.
+	SetIntervalMining(interval time.Duration)
+	// IncreaseTime moves the time of the next blocks forward and returns the total time offset.
+	IncreaseTime(ctx context.Context, d time.Duration) (time.Duration, error)
+	// SetNextBlockTimestamp sets the time of the next block, the time of the following blocks
+	// continues from it.
+	SetNextBlockTimestamp(ctx context.Context, timestamp time.Time) error
+	// Snapshot records the state of the chain at the latest block and returns the id of the snapshot.
+	Snapshot() uint64
+	// Revert reverts the chain to the state of a snapshot and deletes it, along with the snapshots
+	// taken after it. It returns false if the snapshot doesn't exist.
+	Revert(ctx context.Context, id uint64) (bool, error)
+}
+
+// API is the dev-only evm prefixed set of APIs of the Hardhat and Ganache development networks,
+// which controls the in-process node of a development chain.
+type API struct {
+	logger log.Logger
+	node   DevNode
+}
+
+// NewAPI creates an instance of the evm API.
+func NewAPI(logger log.Logger, node DevNode) *API {
+	return &API{
+		logger: logger.With("api", "evm"),
+		node:   node,
+	}
+}
+
+// Mine produces a block and returns once it's committed. If a timestamp is provided, it's the
+// timestamp of the block.
+func (api *API) Mine(ctx context.Context, timestamp *Quantity) (string, error) {
+	api.logger.Debug("evm_mine", "timestamp", timestamp)
+
+	if timestamp != nil {
+		if err := api.node.SetNextBlockTimestamp(ctx, time.Unix(int64(*timestamp), 0)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := api.node.Mine(ctx); err != nil {
+		return "", err
+	}
+
+	return "0x0", nil
+}
+
+// SetAutomine enables or disables the production of a block for each transaction received.
+func (api *API) SetAutomine(enabled bool) bool {
+	api.logger.Debug("evm_setAutomine", "enabled", enabled)
+	api.node.SetAutomine(enabled)
+	return true
+}
+
+// SetIntervalMining produces blocks at the given interval in milliseconds. The interval mining is
+// disabled if the interval is 0.
+func (api *API) SetIntervalMining(interval Quantity) bool {
+	api.logger.Debug("evm_setIntervalMining", "interval", interval)
+	api.node.SetIntervalMining(time.Duration(interval) * time.Millisecond)
+	return true
+}
+
+// IncreaseTime moves the time of the next blocks forward by the given number of seconds and returns
+// the total time offset in seconds.
+func (api *API) IncreaseTime(ctx context.Context, seconds Quantity) (int64, error) {
+	api.logger.Debug("evm_increaseTime", "seconds", seconds)
+
+	offset, err := api.node.IncreaseTime(ctx, time.Duration(seconds)*time.Second)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(offset / time.Second), nil
+}
+
+// SetNextBlockTimestamp sets the timestamp in seconds of the next block. The timestamps of the
+// following blocks continue from it.
+func (api *API) SetNextBlockTimestamp(ctx context.Context, timestamp Quantity) error {
+	api.logger.Debug("evm_setNextBlockTimestamp", "timestamp", timestamp)
+	return api.node.SetNextBlockTimestamp(ctx, time.Unix(int64(timestamp), 0))
+}
+
+// Snapshot records the state of the chain at the latest block and returns the id of the snapshot.
+func (api *API) Snapshot() hexutil.Uint64 {
+	api.logger.Debug("evm_snapshot")
+	return hexutil.Uint64(api.node.Snapshot())
+}
+
+// Revert reverts the chain to the state of the given snapshot, which is deleted along with the
+// snapshots taken after it. The latest block is then the snapshot block, and the pending
+// transactions are dropped. It returns false if the snapshot doesn't exist.
+func (api *API) Revert(ctx context.Context, id Quantity) (bool, error) {
+	api.logger.Debug("evm_revert", "id", id)
+	return api.node.Revert(ctx, uint64(id))
+}
+
+// Quantity is an unsigned integer parameter, encoded either as a JSON number or as a hex or decimal
+// string, as sent by the different Ethereum development tools.
+type Quantity uint64
+
+// UnmarshalJSON parses a quantity from a JSON number or string.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	input := strings.TrimSpace(string(data))
+	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+		input = input[1 : len(input)-1]
+	}
+
+	value, err := hexutil.DecodeUint64(input)
+	if errors.Is(err, hexutil.ErrMissingPrefix) {
+		value, err = strconv.ParseUint(input, 10, 64)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid quantity %s: %w", string(data), err)
+	}
+
+	*q = Quantity(value)
+	return nil
+}
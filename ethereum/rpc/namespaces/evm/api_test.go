@@ -0,0 +1,38 @@
+package evm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantityUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		msg      string
+		input    string
+		expValue Quantity
+		expPass  bool
+	}{
+		{"number", `3600`, 3600, true},
+		{"hex string", `"0xe10"`, 3600, true},
+		{"decimal string", `"3600"`, 3600, true},
+		{"zero", `"0x0"`, 0, true},
+		{"negative number", `-1`, 0, false},
+		{"invalid hex", `"0xzz"`, 0, false},
+		{"invalid string", `"ten"`, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			var q Quantity
+			err := json.Unmarshal([]byte(tc.input), &q)
+			if tc.expPass {
+				require.NoError(t, err)
+				require.Equal(t, tc.expValue, q)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
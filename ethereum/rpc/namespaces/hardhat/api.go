@@ -0,0 +1,111 @@
+package hardhat
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/evm"
+)
+
+// DevNode is the in-process node of a development chain, whose accounts can be impersonated and whose
+// state can be written outside of transactions.
+type DevNode interface {
+	evm.DevNode
+	DevState
+}
+
+// DevState is the dev-only state of the application of a development chain.
+type DevState interface {
+	// Impersonate accepts the unsigned transactions of the given account, which are sent by
+	// eth_sendTransaction without its key.
+	Impersonate(addr common.Address)
+	// StopImpersonating rejects the unsigned transactions of the given account again.
+	StopImpersonating(addr common.Address)
+	// IsImpersonated returns true if the given account is impersonated.
+	IsImpersonated(addr common.Address) bool
+	// SetBalance sets the balance of an account on the next block.
+	SetBalance(addr common.Address, balance *big.Int)
+	// SetCode sets the code of an account on the next block.
+	SetCode(addr common.Address, code []byte)
+	// SetNonce sets the nonce of an account on the next block.
+	SetNonce(addr common.Address, nonce uint64)
+	// SetStorageAt sets a storage slot of an account on the next block.
+	SetStorageAt(addr common.Address, key, value common.Hash)
+}
+
+// API is the dev-only set of APIs of the Hardhat and Anvil development networks, served on both the
+// hardhat and anvil namespaces, which impersonates accounts and writes the state of a development
+// chain.
+type API struct {
+	logger log.Logger
+	node   DevNode
+}
+
+// NewAPI creates an instance of the hardhat API.
+func NewAPI(logger log.Logger, node DevNode) *API {
+	return &API{
+		logger: logger.With("api", "hardhat"),
+		node:   node,
+	}
+}
+
+// ImpersonateAccount allows eth_sendTransaction to send the transactions of the given account without
+// its key.
+func (api *API) ImpersonateAccount(addr common.Address) bool {
+	api.logger.Debug("hardhat_impersonateAccount", "address", addr)
+	api.node.Impersonate(addr)
+	return true
+}
+
+// StopImpersonatingAccount stops the impersonation of the given account.
+func (api *API) StopImpersonatingAccount(addr common.Address) bool {
+	api.logger.Debug("hardhat_stopImpersonatingAccount", "address", addr)
+	api.node.StopImpersonating(addr)
+	return true
+}
+
+// SetBalance sets the balance of an account. The state can only be written by a block, which is
+// produced before returning.
+func (api *API) SetBalance(ctx context.Context, addr common.Address, balance hexutil.Big) (bool, error) {
+	api.logger.Debug("hardhat_setBalance", "address", addr, "balance", balance)
+	api.node.SetBalance(addr, balance.ToInt())
+	return api.mine(ctx)
+}
+
+// SetCode sets the code of an account. The state can only be written by a block, which is produced
+// before returning.
+func (api *API) SetCode(ctx context.Context, addr common.Address, code hexutil.Bytes) (bool, error) {
+	api.logger.Debug("hardhat_setCode", "address", addr)
+	api.node.SetCode(addr, code)
+	return api.mine(ctx)
+}
+
+// SetNonce sets the nonce of an account. The state can only be written by a block, which is produced
+// before returning.
+func (api *API) SetNonce(ctx context.Context, addr common.Address, nonce evm.Quantity) (bool, error) {
+	api.logger.Debug("hardhat_setNonce", "address", addr, "nonce", nonce)
+	api.node.SetNonce(addr, uint64(nonce))
+	return api.mine(ctx)
+}
+
+// SetStorageAt sets a storage slot of an account. The state can only be written by a block, which is
+// produced before returning.
+func (api *API) SetStorageAt(ctx context.Context, addr common.Address, slot hexutil.Big, value common.Hash) (bool, error) {
+	api.logger.Debug("hardhat_setStorageAt", "address", addr, "slot", slot, "value", value)
+	api.node.SetStorageAt(addr, common.BigToHash(slot.ToInt()), value)
+	return api.mine(ctx)
+}
+
+// mine produces the block writing the pending state.
+func (api *API) mine(ctx context.Context) (bool, error) {
+	if err := api.node.Mine(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
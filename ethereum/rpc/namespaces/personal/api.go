@@ -96,6 +96,15 @@ func (api *PrivateAccountAPI) ListAccounts() ([]common.Address, error) {
 	api.logger.Debug("personal_listAccounts")
 	addrs := []common.Address{}
 
+	if signer := api.backend.ExternalSigner(); signer != nil {
+		signerAddrs, err := signer.Accounts(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		return append(addrs, signerAddrs...), nil
+	}
+
 	list, err := api.clientCtx.Keyring.List()
 	if err != nil {
 		return nil, err
@@ -153,6 +162,11 @@ func (api *PrivateAccountAPI) UnlockAccount(_ context.Context, addr common.Addre
 func (api *PrivateAccountAPI) SendTransaction(_ context.Context, args rpctypes.SendTxArgs, pwrd string) (common.Hash, error) {
 	api.logger.Debug("personal_sendTransaction", "address", args.To.String())
 
+	// the external signer checks the account itself
+	if api.backend.ExternalSigner() != nil {
+		return api.backend.SendTransaction(args)
+	}
+
 	addr := sdk.AccAddress(args.From.Bytes())
 
 	// check if the key is on the keyring
@@ -173,9 +187,19 @@ func (api *PrivateAccountAPI) SendTransaction(_ context.Context, args rpctypes.S
 // The key used to calculate the signature is decrypted with the given password.
 //
 // https://github.com/ethereum/go-ethereum/wiki/Management-APIs#personal_sign
-func (api *PrivateAccountAPI) Sign(_ context.Context, data hexutil.Bytes, addr common.Address, pwrd string) (hexutil.Bytes, error) {
+func (api *PrivateAccountAPI) Sign(ctx context.Context, data hexutil.Bytes, addr common.Address, pwrd string) (hexutil.Bytes, error) {
 	api.logger.Debug("personal_sign", "data", data, "address", addr.String())
 
+	if signer := api.backend.ExternalSigner(); signer != nil {
+		sig, err := signer.SignText(ctx, addr, data)
+		if err != nil {
+			api.logger.Error("external signer failed to sign data", "data", data, "address", addr.String(), "error", err.Error())
+			return nil, err
+		}
+
+		return sig, nil
+	}
+
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 
 	sig, _, err := api.clientCtx.Keyring.SignByAddress(cosmosAddr, accounts.TextHash(data))
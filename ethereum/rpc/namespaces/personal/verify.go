@@ -0,0 +1,259 @@
+package personal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rpctypes "github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/types"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// eip1271ABI defines the ABI of the EIP-1271 signature validation method of contract accounts.
+const eip1271ABI = `[{"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"magicValue","type":"bytes4"}],"stateMutability":"view","type":"function"}]`
+
+var (
+	eip1271Contract abi.ABI
+
+	// eip1271MagicValue is the value returned by isValidSignature for a valid signature, which is the
+	// selector of the method.
+	eip1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+)
+
+func init() {
+	var err error
+	eip1271Contract, err = abi.JSON(strings.NewReader(eip1271ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// VerifySignature returns true if the signature is a valid signature of the given address for:
+// keccak256("\x19Ethereum Signed Message:\n" + len(message) + message))
+//
+// The signature is verified according to the type of the account:
+//   - contract accounts are called through EIP-1271 isValidSignature(bytes32,bytes) with the hash above
+//   - multisig accounts, whose public key is found in the keyring or on chain, take the amino encoded
+//     legacy multisignature of the eth_secp256k1 members over the prefixed message
+//   - externally owned accounts take a 65 bytes signature in the same format as personal_sign
+func (api *PrivateAccountAPI) VerifySignature(ctx context.Context, addr common.Address, data, sig hexutil.Bytes) (bool, error) {
+	api.logger.Debug("personal_verifySignature", "address", addr.String(), "data", data, "sig", sig)
+
+	code, err := api.getCode(addr)
+	if err != nil {
+		return false, err
+	}
+
+	if len(code) > 0 {
+		return api.verifyContractSignature(addr, data, sig)
+	}
+
+	multisigPubKey, err := api.getMultisigPubKey(addr)
+	if err != nil {
+		return false, err
+	}
+
+	if multisigPubKey != nil {
+		if err := VerifyMultisignature(multisigPubKey, data, sig); err != nil {
+			api.logger.Debug("invalid multisignature", "address", addr.String(), "error", err.Error())
+			return false, nil
+		}
+
+		return true, nil
+	}
+
+	signer, err := api.EcRecover(ctx, data, common.CopyBytes(sig))
+	if err != nil {
+		return false, err
+	}
+
+	return signer == addr, nil
+}
+
+// getCode returns the code of the given address at the latest block.
+func (api *PrivateAccountAPI) getCode(addr common.Address) ([]byte, error) {
+	var res *evmtypes.QueryCodeResponse
+	err := api.backend.QueryState(rpctypes.EthLatestBlockNumber, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.Code(ctx, &evmtypes.QueryCodeRequest{Address: addr.String()})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Code, nil
+}
+
+// verifyContractSignature calls the EIP-1271 isValidSignature method of the contract at the given
+// address and returns true if it returns the magic value. A reverted call is an invalid signature.
+func (api *PrivateAccountAPI) verifyContractSignature(addr common.Address, data, sig []byte) (bool, error) {
+	var hash [32]byte
+	copy(hash[:], accounts.TextHash(data))
+
+	input, err := eip1271Contract.Pack("isValidSignature", hash, sig)
+	if err != nil {
+		return false, err
+	}
+
+	inputBytes := hexutil.Bytes(input)
+	args, err := json.Marshal(&evmtypes.CallArgs{To: &addr, Data: &inputBytes})
+	if err != nil {
+		return false, err
+	}
+
+	req := evmtypes.EthCallRequest{Args: args, GasCap: api.backend.RPCGasCap()}
+
+	var res *evmtypes.MsgEthereumTxResponse
+	err = api.backend.QueryState(rpctypes.EthLatestBlockNumber, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.EthCall(ctx, &req)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if res.Failed() {
+		api.logger.Debug("isValidSignature call failed", "address", addr.String(), "error", res.VmError)
+		return false, nil
+	}
+
+	return IsEIP1271MagicValue(res.Ret), nil
+}
+
+// IsEIP1271MagicValue returns true if the given return data of isValidSignature is the magic value
+// of a valid signature.
+func IsEIP1271MagicValue(ret []byte) bool {
+	outputs, err := eip1271Contract.Unpack("isValidSignature", ret)
+	if err != nil || len(outputs) != 1 {
+		return false
+	}
+
+	magicValue, ok := outputs[0].([4]byte)
+	return ok && bytes.Equal(magicValue[:], eip1271MagicValue[:])
+}
+
+// getMultisigPubKey returns the multisig public key of the given address from the keyring or, if not
+// found, from its account. It returns nil if the address is not a known multisig account.
+func (api *PrivateAccountAPI) getMultisigPubKey(addr common.Address) (multisig.PubKey, error) {
+	accAddr := sdk.AccAddress(addr.Bytes())
+
+	if info, err := api.clientCtx.Keyring.KeyByAddress(accAddr); err == nil {
+		if pubKey, ok := info.GetPubKey().(multisig.PubKey); ok {
+			return pubKey, nil
+		}
+	}
+
+	var res *authtypes.QueryAccountResponse
+	err := api.backend.QueryState(rpctypes.EthLatestBlockNumber, func(ctx context.Context, queryClient *rpctypes.QueryClient) (err error) {
+		res, err = queryClient.Auth.Account(ctx, &authtypes.QueryAccountRequest{Address: accAddr.String()})
+		return err
+	})
+	switch {
+	case errors.Is(err, sdkerrors.ErrKeyNotFound), status.Code(err) == codes.NotFound:
+		// the account doesn't exist
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var account authtypes.AccountI
+	if err := api.clientCtx.InterfaceRegistry.UnpackAny(res.Account, &account); err != nil {
+		return nil, err
+	}
+
+	pubKey, ok := account.GetPubKey().(multisig.PubKey)
+	if !ok {
+		return nil, nil
+	}
+
+	return pubKey, nil
+}
+
+// VerifyMultisignature verifies the amino encoded legacy multisignature of the given multisig public
+// key, whose members signed keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)).
+func VerifyMultisignature(pubKey multisig.PubKey, data, sig []byte) error {
+	sigData, err := decodeMultisignature(pubKey, sig)
+	if err != nil {
+		return err
+	}
+
+	// the members verify the signature over the hash of the sign bytes
+	_, msg := accounts.TextAndHash(data)
+	getSignBytes := func(signing.SignMode) ([]byte, error) {
+		return []byte(msg), nil
+	}
+
+	return pubKey.VerifyMultisignature(getSignBytes, sigData)
+}
+
+// decodeMultisignature decodes the amino encoded legacy multisignature of the given multisig public
+// key, including the nested multisignatures.
+func decodeMultisignature(pubKey multisig.PubKey, sig []byte) (*signing.MultiSignatureData, error) {
+	var aminoSig multisig.AminoMultisignature
+	if err := legacy.Cdc.Unmarshal(sig, &aminoSig); err != nil {
+		return nil, fmt.Errorf("invalid multisignature: %w", err)
+	}
+
+	if aminoSig.BitArray == nil {
+		return nil, errors.New("invalid multisignature: missing bit array")
+	}
+
+	pubKeys := pubKey.GetPubKeys()
+	if aminoSig.BitArray.Count() != len(pubKeys) {
+		return nil, fmt.Errorf("invalid multisignature: bit array size %d, expected %d", aminoSig.BitArray.Count(), len(pubKeys))
+	}
+
+	sigData := &signing.MultiSignatureData{
+		BitArray:   aminoSig.BitArray,
+		Signatures: make([]signing.SignatureData, 0, len(aminoSig.Sigs)),
+	}
+
+	sigIdx := 0
+	for i, memberPubKey := range pubKeys {
+		if !aminoSig.BitArray.GetIndex(i) {
+			continue
+		}
+
+		if sigIdx >= len(aminoSig.Sigs) {
+			return nil, fmt.Errorf("invalid multisignature: missing signature %d", sigIdx)
+		}
+
+		memberSig := aminoSig.Sigs[sigIdx]
+		sigIdx++
+
+		nestedPubKey, ok := memberPubKey.(multisig.PubKey)
+		if !ok {
+			sigData.Signatures = append(sigData.Signatures, &signing.SingleSignatureData{
+				SignMode:  signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+				Signature: memberSig,
+			})
+			continue
+		}
+
+		nestedSigData, err := decodeMultisignature(nestedPubKey, memberSig)
+		if err != nil {
+			return nil, err
+		}
+
+		sigData.Signatures = append(sigData.Signatures, nestedSigData)
+	}
+
+	return sigData, nil
+}
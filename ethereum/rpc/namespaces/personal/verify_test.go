@@ -0,0 +1,120 @@
+package personal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+)
+
+func generateKeys(t *testing.T, n int) ([]*ethsecp256k1.PrivKey, []cryptotypes.PubKey) {
+	privKeys := make([]*ethsecp256k1.PrivKey, n)
+	pubKeys := make([]cryptotypes.PubKey, n)
+	for i := 0; i < n; i++ {
+		privKey, err := ethsecp256k1.GenerateKey()
+		require.NoError(t, err)
+		privKeys[i] = privKey
+		pubKeys[i] = privKey.PubKey()
+	}
+
+	return privKeys, pubKeys
+}
+
+func signMultisig(t *testing.T, pubKey *kmultisig.LegacyAminoPubKey, data []byte, signers []*ethsecp256k1.PrivKey) []byte {
+	sigData := multisig.NewMultisig(len(pubKey.GetPubKeys()))
+	for _, privKey := range signers {
+		sig, err := privKey.Sign(accounts.TextHash(data))
+		require.NoError(t, err)
+
+		single := &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, Signature: sig}
+		require.NoError(t, multisig.AddSignatureFromPubKey(sigData, single, privKey.PubKey(), pubKey.GetPubKeys()))
+	}
+
+	aminoSig, err := legacytx.MultiSignatureDataToAminoMultisignature(legacy.Cdc, sigData)
+	require.NoError(t, err)
+
+	return legacy.Cdc.MustMarshal(aminoSig)
+}
+
+func TestVerifyMultisignature(t *testing.T) {
+	data := []byte("login nonce 42")
+	privKeys, pubKeys := generateKeys(t, 3)
+	pubKey := kmultisig.NewLegacyAminoPubKey(2, pubKeys)
+
+	testCases := []struct {
+		msg     string
+		signers []*ethsecp256k1.PrivKey
+		data    []byte
+		expPass bool
+	}{
+		{"first two signers", []*ethsecp256k1.PrivKey{privKeys[0], privKeys[1]}, data, true},
+		{"non contiguous signers", []*ethsecp256k1.PrivKey{privKeys[0], privKeys[2]}, data, true},
+		{"all signers", []*ethsecp256k1.PrivKey{privKeys[0], privKeys[1], privKeys[2]}, data, true},
+		{"below threshold", []*ethsecp256k1.PrivKey{privKeys[1]}, data, false},
+		{"different data", []*ethsecp256k1.PrivKey{privKeys[0], privKeys[1]}, []byte("other"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			sig := signMultisig(t, pubKey, tc.data, tc.signers)
+			err := VerifyMultisignature(pubKey, data, sig)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// malformed signature
+	require.Error(t, VerifyMultisignature(pubKey, data, []byte{1, 2, 3}))
+
+	// nested multisig
+	nestedPrivKeys, nestedPubKeys := generateKeys(t, 2)
+	nestedPubKey := kmultisig.NewLegacyAminoPubKey(1, nestedPubKeys)
+	parentPubKey := kmultisig.NewLegacyAminoPubKey(2, []cryptotypes.PubKey{pubKeys[0], nestedPubKey})
+
+	nestedSig := signMultisig(t, nestedPubKey, data, []*ethsecp256k1.PrivKey{nestedPrivKeys[1]})
+	firstSig, err := privKeys[0].Sign(accounts.TextHash(data))
+	require.NoError(t, err)
+
+	sigData := multisig.NewMultisig(2)
+	multisig.AddSignature(sigData, &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, Signature: firstSig}, 0)
+	nestedSigData, err := decodeMultisignature(nestedPubKey, nestedSig)
+	require.NoError(t, err)
+	multisig.AddSignature(sigData, nestedSigData, 1)
+
+	aminoSig, err := legacytx.MultiSignatureDataToAminoMultisignature(legacy.Cdc, sigData)
+	require.NoError(t, err)
+	require.NoError(t, VerifyMultisignature(parentPubKey, data, legacy.Cdc.MustMarshal(aminoSig)))
+}
+
+func TestIsEIP1271MagicValue(t *testing.T) {
+	testCases := []struct {
+		msg      string
+		ret      string
+		expValid bool
+	}{
+		{"magic value", "0x1626ba7e00000000000000000000000000000000000000000000000000000000", true},
+		{"invalid value", "0xffffffff00000000000000000000000000000000000000000000000000000000", false},
+		{"empty return data", "0x", false},
+		{"short return data", "0x1626ba7e", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			require.Equal(t, tc.expValid, IsEIP1271MagicValue(hexutil.MustDecode(tc.ret)))
+		})
+	}
+}
@@ -2,31 +2,36 @@ package pubsub
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
+// UnsubscribeFunc removes a subscriber channel from its topic.
+type UnsubscribeFunc func()
+
 type EventBus interface {
 	AddTopic(name string, src <-chan coretypes.ResultEvent) error
 	RemoveTopic(name string)
-	Subscribe(name string) (<-chan coretypes.ResultEvent, error)
+	Subscribe(name string) (<-chan coretypes.ResultEvent, UnsubscribeFunc, error)
 	Topics() []string
 }
 
 type memEventBus struct {
-	topics         map[string]<-chan coretypes.ResultEvent
-	topicsMux      *sync.RWMutex
-	subscribers    map[string][]chan<- coretypes.ResultEvent
-	subscribersMux *sync.RWMutex
+	topics          map[string]<-chan coretypes.ResultEvent
+	topicsMux       *sync.RWMutex
+	subscribers     map[string]map[uint64]chan<- coretypes.ResultEvent
+	subscribersMux  *sync.RWMutex
+	currentUniqueID uint64
 }
 
 func NewEventBus() EventBus {
 	return &memEventBus{
 		topics:         make(map[string]<-chan coretypes.ResultEvent),
 		topicsMux:      new(sync.RWMutex),
-		subscribers:    make(map[string][]chan<- coretypes.ResultEvent),
+		subscribers:    make(map[string]map[uint64]chan<- coretypes.ResultEvent),
 		subscribersMux: new(sync.RWMutex),
 	}
 }
@@ -67,21 +72,35 @@ func (m *memEventBus) RemoveTopic(name string) {
 	m.topicsMux.Unlock()
 }
 
-func (m *memEventBus) Subscribe(name string) (<-chan coretypes.ResultEvent, error) {
+// Subscribe returns a channel that receives the events published on the given topic, along with a
+// function that removes the subscriber from the topic. The subscriber channel is not closed on
+// unsubscription.
+func (m *memEventBus) Subscribe(name string) (<-chan coretypes.ResultEvent, UnsubscribeFunc, error) {
 	m.topicsMux.RLock()
 	_, ok := m.topics[name]
 	m.topicsMux.RUnlock()
 
 	if !ok {
-		return nil, errors.Errorf("topic not found: %s", name)
+		return nil, nil, errors.Errorf("topic not found: %s", name)
 	}
 
 	ch := make(chan coretypes.ResultEvent)
 	m.subscribersMux.Lock()
 	defer m.subscribersMux.Unlock()
-	m.subscribers[name] = append(m.subscribers[name], ch)
 
-	return ch, nil
+	id := atomic.AddUint64(&m.currentUniqueID, 1)
+	if _, ok := m.subscribers[name]; !ok {
+		m.subscribers[name] = make(map[uint64]chan<- coretypes.ResultEvent)
+	}
+	m.subscribers[name][id] = ch
+
+	unsubscribe := func() {
+		m.subscribersMux.Lock()
+		defer m.subscribersMux.Unlock()
+		delete(m.subscribers[name], id)
+	}
+
+	return ch, unsubscribe, nil
 }
 
 func (m *memEventBus) publishTopic(name string, src <-chan coretypes.ResultEvent) {
@@ -112,10 +131,9 @@ func (m *memEventBus) closeAllSubscribers(name string) {
 
 func (m *memEventBus) publishAllSubscribers(name string, msg coretypes.ResultEvent) {
 	m.subscribersMux.RLock()
-	subsribers := m.subscribers[name]
-	m.subscribersMux.RUnlock()
+	defer m.subscribersMux.RUnlock()
 
-	for _, sub := range subsribers {
+	for _, sub := range m.subscribers[name] {
 		select {
 		case sub <- msg:
 		default:
@@ -1,5 +1,48 @@
 package pubsub
 
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func TestUnsubscribe(t *testing.T) {
+	q := NewEventBus()
+	src := make(chan coretypes.ResultEvent)
+	require.NoError(t, q.AddTopic("kek", src))
+
+	subCh, unsubscribe, err := q.Subscribe("kek")
+	require.NoError(t, err)
+
+	sub2Ch, _, err := q.Subscribe("kek")
+	require.NoError(t, err)
+
+	unsubscribe()
+
+	go func() {
+		src <- coretypes.ResultEvent{Query: "kek"}
+	}()
+
+	select {
+	case msg := <-sub2Ch:
+		require.Equal(t, "kek", msg.Query)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber didn't receive the event")
+	}
+
+	select {
+	case <-subCh:
+		t.Fatal("unsubscribed channel received an event")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, _, err = q.Subscribe("lol")
+	require.Error(t, err)
+}
+
 // func TestAddTopic(t *testing.T) {
 // 	q := NewEventBus()
 // 	err := q.AddTopic("kek", make(<-chan coretypes.ResultEvent))
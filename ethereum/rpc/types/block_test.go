@@ -1,7 +1,6 @@
 package types
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -88,15 +87,14 @@ func TestUnmarshalBlockNumberOrHash(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		fmt.Sprintf("Case %s", tc.msg)
 		// reset input
 		bnh = new(BlockNumberOrHash)
 		err := bnh.UnmarshalJSON(tc.input)
 		tc.malleate()
 		if tc.expPass {
-			require.NoError(t, err)
+			require.NoError(t, err, tc.msg)
 		} else {
-			require.Error(t, err)
+			require.Error(t, err, tc.msg)
 		}
 	}
 }
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// Metric keys and labels emitted by the JSON-RPC server. The counters and gauges are recorded
+// through the SDK telemetry package and are therefore served on the same endpoint as the rest of
+// the node metrics (i.e '/metrics?format=prometheus' on the API server) when telemetry is enabled.
+const (
+	MetricKeyJSONRPC = "json_rpc"
+
+	MetricLabelNamespace    = "namespace"
+	MetricLabelMethod       = "method"
+	MetricLabelSubscription = "subscription"
+)
+
+// requestDuration is the latency histogram of the JSON-RPC requests. The telemetry package only
+// records timings as Prometheus summaries, so the histogram is registered on the default Prometheus
+// registry, which is gathered by the telemetry endpoint.
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "json_rpc_request_duration_seconds",
+	Help:    "Latency of the JSON-RPC requests.",
+	Buckets: prometheus.DefBuckets,
+}, []string{MetricLabelNamespace, MetricLabelMethod})
+
+// RecordRequest increments the request counter of the given JSON-RPC method and records its
+// latency. The namespace label is the prefix of the method name (eg: eth for eth_getBalance).
+func RecordRequest(method string, elapsed time.Duration) {
+	namespace := method
+	if i := strings.Index(method, "_"); i > 0 {
+		namespace = method[:i]
+	}
+
+	labels := []metrics.Label{
+		telemetry.NewLabel(MetricLabelNamespace, namespace),
+		telemetry.NewLabel(MetricLabelMethod, method),
+	}
+
+	telemetry.IncrCounterWithLabels([]string{MetricKeyJSONRPC, "requests"}, 1, labels)
+	requestDuration.WithLabelValues(namespace, method).Observe(elapsed.Seconds())
+}
+
+// SetActiveSubscriptions records the number of active WebSocket subscriptions of the given
+// subscription type (newHeads, logs, newPendingTransactions, etc).
+func SetActiveSubscriptions(subscription string, count int) {
+	telemetry.SetGaugeWithLabels(
+		[]string{MetricKeyJSONRPC, "subscriptions", "active"},
+		float32(count),
+		[]metrics.Label{telemetry.NewLabel(MetricLabelSubscription, subscription)},
+	)
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// missingStateErrors are the messages of the errors returned by a node when the state of the queried
+// height was pruned or is not available yet.
+var missingStateErrors = []string{
+	"failed to load state at height",
+	"version does not exist",
+}
+
+// IsMissingStateError returns true if the error was returned by a gRPC query at a height whose state
+// is not available on the node.
+func IsMissingStateError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	for _, missingStateErr := range missingStateErrors {
+		if strings.Contains(msg, missingStateErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// EarliestStateHeight returns the earliest height whose state is retained by a node with the given
+// pruning options, a latest height and the earliest block height of its block store. The heights
+// kept by the pruning KeepEvery option before it are also available.
+func EarliestStateHeight(opts storetypes.PruningOptions, latest, base int64) int64 {
+	if base < 1 {
+		base = 1
+	}
+
+	// every height is kept
+	if opts.KeepEvery == 1 {
+		return base
+	}
+
+	// the state of the previous heights is pruned on commit when it falls out of the recent ones
+	earliest := latest - int64(opts.KeepRecent)
+	if earliest < base {
+		return base
+	}
+	return earliest
+}
+
+// IsStateAvailable returns true if the state at the given height is retained by a node with the given
+// pruning options, a latest height and the earliest block height of its block store.
+func IsStateAvailable(opts storetypes.PruningOptions, height, latest, base int64) bool {
+	switch {
+	case height > latest:
+		return false
+	case height >= EarliestStateHeight(opts, latest, base):
+		return true
+	case height < base:
+		return false
+	default:
+		return opts.KeepEvery > 0 && uint64(height)%opts.KeepEvery == 0
+	}
+}
+
+// MissingStateError is returned by the state queries at a height whose state was pruned from the node.
+// Its message follows the go-ethereum error returned for the state of a pruned block, so that clients
+// can tell it apart from other query errors.
+type MissingStateError struct {
+	Height         int64
+	EarliestHeight int64
+}
+
+// NewMissingStateError returns a MissingStateError for the given queried height and earliest available
+// height. The earliest height is omitted from the error if it's not positive.
+func NewMissingStateError(height, earliestHeight int64) *MissingStateError {
+	return &MissingStateError{
+		Height:         height,
+		EarliestHeight: earliestHeight,
+	}
+}
+
+func (e *MissingStateError) Error() string {
+	if e.EarliestHeight <= 0 {
+		return fmt.Sprintf("missing trie node: state at height %d is not available", e.Height)
+	}
+	return fmt.Sprintf(
+		"missing trie node: state at height %d is not available, the earliest available height is %d",
+		e.Height, e.EarliestHeight,
+	)
+}
+
+// ErrorCode returns the JSON-RPC error code of the go-ethereum server errors.
+func (e *MissingStateError) ErrorCode() int {
+	return -32000
+}
+
+// ErrorData returns the earliest height whose state is available.
+func (e *MissingStateError) ErrorData() interface{} {
+	if e.EarliestHeight <= 0 {
+		return nil
+	}
+	return hexutil.Uint64(e.EarliestHeight)
+}
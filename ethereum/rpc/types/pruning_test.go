@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+)
+
+func TestIsStateAvailable(t *testing.T) {
+	custom := storetypes.NewPruningOptions(100, 50, 10)
+
+	testCases := []struct {
+		msg         string
+		opts        storetypes.PruningOptions
+		height      int64
+		base        int64
+		expEarliest int64
+		expPass     bool
+	}{
+		{"nothing pruned", storetypes.PruneNothing, 1, 1, 1, true},
+		{"nothing pruned, before state sync snapshot", storetypes.PruneNothing, 10, 500, 500, false},
+		{"everything pruned, latest height", storetypes.PruneEverything, 1000, 1, 1000, true},
+		{"everything pruned, previous height", storetypes.PruneEverything, 999, 1, 1000, false},
+		{"custom, earliest recent height", custom, 900, 1, 900, true},
+		{"custom, pruned height", custom, 899, 1, 900, false},
+		{"custom, kept every height", custom, 850, 1, 900, true},
+		{"custom, kept every height before block store base", custom, 850, 860, 900, false},
+		{"custom, kept every height at block store base", custom, 800, 800, 900, true},
+		{"future height", storetypes.PruneNothing, 1001, 1, 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			require.Equal(t, tc.expEarliest, EarliestStateHeight(tc.opts, 1000, tc.base))
+			require.Equal(t, tc.expPass, IsStateAvailable(tc.opts, tc.height, 1000, tc.base))
+		})
+	}
+}
+
+func TestMissingStateError(t *testing.T) {
+	require.False(t, IsMissingStateError(nil))
+	require.False(t, IsMissingStateError(errors.New("rpc error: code = NotFound desc = account not found")))
+	require.True(t, IsMissingStateError(errors.New("failed to load state at height 10; version does not exist (latest height: 1000)")))
+
+	err := NewMissingStateError(10, 900)
+	require.Contains(t, err.Error(), "missing trie node")
+	require.Equal(t, -32000, err.ErrorCode())
+	require.Equal(t, hexutil.Uint64(900), err.ErrorData())
+
+	err = NewMissingStateError(10, 0)
+	require.Nil(t, err.ErrorData())
+}
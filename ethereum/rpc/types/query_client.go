@@ -3,7 +3,10 @@ package types
 import (
 	"fmt"
 
+	gogogrpc "github.com/gogo/protobuf/grpc"
+
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/proto/tendermint/crypto"
@@ -17,18 +20,27 @@ import (
 // QueryClient defines a gRPC Client used for:
 //  - Transaction simulation
 //  - EVM module queries
+//  - Account queries
 type QueryClient struct {
 	tx.ServiceClient
 	evmtypes.QueryClient
 	FeeMarket feemarkettypes.QueryClient
+	Auth      authtypes.QueryClient
 }
 
 // NewQueryClient creates a new gRPC query client
 func NewQueryClient(clientCtx client.Context) *QueryClient {
+	return NewQueryClientFromConn(clientCtx)
+}
+
+// NewQueryClientFromConn creates a new gRPC query client that sends the queries through the given
+// connection, such as the gRPC connection to another node.
+func NewQueryClientFromConn(conn gogogrpc.ClientConn) *QueryClient {
 	return &QueryClient{
-		ServiceClient: tx.NewServiceClient(clientCtx),
-		QueryClient:   evmtypes.NewQueryClient(clientCtx),
-		FeeMarket:     feemarkettypes.NewQueryClient(clientCtx),
+		ServiceClient: tx.NewServiceClient(conn),
+		QueryClient:   evmtypes.NewQueryClient(conn),
+		FeeMarket:     feemarkettypes.NewQueryClient(conn),
+		Auth:          authtypes.NewQueryClient(conn),
 	}
 }
 
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ExternalSigner is a client of a Clef-compatible external signer, which holds the account keys and
+// signs the transactions and data on behalf of the node. The signer API is served over IPC or HTTP.
+type ExternalSigner struct {
+	endpoint string
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// signTransactionResult defines the result of the account_signTransaction method.
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewExternalSigner creates a new client of the external signer at the given endpoint, which is
+// either an IPC path or an HTTP URL. The connection is established lazily on the first request, so
+// that the signer can be started after the node.
+func NewExternalSigner(endpoint string) *ExternalSigner {
+	return &ExternalSigner{
+		endpoint: endpoint,
+	}
+}
+
+// Endpoint returns the IPC path or HTTP URL of the external signer.
+func (s *ExternalSigner) Endpoint() string {
+	return s.endpoint
+}
+
+// call performs a JSON-RPC call to the external signer, connecting to it if needed.
+func (s *ExternalSigner) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	s.mu.Lock()
+	if s.client == nil {
+		client, err := rpc.DialContext(ctx, s.endpoint)
+		if err != nil {
+			s.mu.Unlock()
+			return fmt.Errorf("failed to connect to external signer %s: %w", s.endpoint, err)
+		}
+		s.client = client
+	}
+	client := s.client
+	s.mu.Unlock()
+
+	return client.CallContext(ctx, result, method, args...)
+}
+
+// Accounts returns the addresses of the accounts managed by the external signer.
+func (s *ExternalSigner) Accounts(ctx context.Context) ([]common.Address, error) {
+	var addresses []common.Address
+	if err := s.call(ctx, &addresses, "account_list"); err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// SignTransaction requests the external signer to sign the transaction built from the given
+// arguments, which must have all their defaults set. It returns the signed transaction, whose sender
+// is checked against the requested account.
+func (s *ExternalSigner) SignTransaction(ctx context.Context, args SendTxArgs, signer ethtypes.Signer) (*ethtypes.Transaction, error) {
+	// the signer requires the value to be set
+	if args.Value == nil {
+		args.Value = (*hexutil.Big)(new(big.Int))
+	}
+
+	var res signTransactionResult
+	if err := s.call(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, err
+	}
+
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(res.Raw); err != nil {
+		return nil, fmt.Errorf("invalid transaction returned by external signer: %w", err)
+	}
+
+	sender, err := ethtypes.Sender(signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction signature returned by external signer: %w", err)
+	}
+
+	if sender != args.From {
+		return nil, fmt.Errorf("external signer signed the transaction with %s, expected %s", sender, args.From)
+	}
+
+	return tx, nil
+}
+
+// SignText requests the external signer to sign the given data with the Ethereum signed message
+// prefix, as defined by EIP-191 for the 'text/plain' content type. The V value of the returned
+// signature is 27 or 28.
+func (s *ExternalSigner) SignText(ctx context.Context, address common.Address, data []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	mixedAddress := common.NewMixedcaseAddress(address)
+	if err := s.call(ctx, &signature, "account_signData", accounts.MimetypeTextPlain, &mixedAddress, hexutil.Bytes(data)); err != nil {
+		return nil, err
+	}
+
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d returned by external signer", len(signature))
+	}
+
+	// Clef returns the V value in the 27/28 form, other signers may return it in the 0/1 form
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	if signature[crypto.RecoveryIDOffset] != 27 && signature[crypto.RecoveryIDOffset] != 28 {
+		return nil, errors.New("invalid signature recovery id returned by external signer")
+	}
+
+	sig := common.CopyBytes(signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature returned by external signer: %w", err)
+	}
+
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != address {
+		return nil, fmt.Errorf("external signer signed the data with %s, expected %s", signer, address)
+	}
+
+	return signature, nil
+}
+
+// Close closes the connection to the external signer.
+func (s *ExternalSigner) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		s.client.Close()
+		s.client = nil
+	}
+}
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSigner implements the account namespace of a Clef-compatible signer with a single key.
+type stubSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *stubSigner) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *stubSigner) SignTransaction(args SendTxArgs) (map[string]interface{}, error) {
+	signer := ethtypes.LatestSignerForChainID(args.ChainID.ToInt())
+	tx, err := ethtypes.SignTx(args.ToTransaction().AsTransaction(), signer, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": tx}, nil
+}
+
+func (s *stubSigner) SignData(_ string, _ common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func newStubSignerServer(t *testing.T, key *ecdsa.PrivateKey) *rpc.Server {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", &stubSigner{key: key}))
+	t.Cleanup(server.Stop)
+	return server
+}
+
+func TestExternalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddress := crypto.PubkeyToAddress(other.PublicKey)
+
+	server := newStubSignerServer(t, key)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	ipcPath := filepath.Join(t.TempDir(), "clef.ipc")
+	listener, err := net.Listen("unix", ipcPath)
+	require.NoError(t, err)
+	go server.ServeListener(listener) // nolint: errcheck
+	t.Cleanup(func() { listener.Close() })
+
+	endpoints := map[string]string{
+		"http": httpServer.URL,
+		"ipc":  ipcPath,
+	}
+
+	for name, endpoint := range endpoints {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			signer := NewExternalSigner(endpoint)
+			defer signer.Close()
+
+			addresses, err := signer.Accounts(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []common.Address{address}, addresses)
+
+			chainID := big.NewInt(9000)
+			ethSigner := ethtypes.LatestSignerForChainID(chainID)
+			to := common.HexToAddress("0x1000000000000000000000000000000000000001")
+			nonce := hexutil.Uint64(3)
+			gas := hexutil.Uint64(21000)
+			args := SendTxArgs{
+				From:     address,
+				To:       &to,
+				Nonce:    &nonce,
+				Gas:      &gas,
+				GasPrice: (*hexutil.Big)(big.NewInt(1)),
+				ChainID:  (*hexutil.Big)(chainID),
+			}
+
+			tx, err := signer.SignTransaction(ctx, args, ethSigner)
+			require.NoError(t, err)
+			require.Equal(t, uint64(nonce), tx.Nonce())
+			require.Equal(t, to, *tx.To())
+			require.Equal(t, uint64(0), tx.Value().Uint64())
+
+			// the stub signs with its own key regardless of the requested account
+			args.From = otherAddress
+			_, err = signer.SignTransaction(ctx, args, ethSigner)
+			require.Error(t, err)
+
+			data := []byte("hello world")
+			sig, err := signer.SignText(ctx, address, data)
+			require.NoError(t, err)
+			require.Len(t, sig, crypto.SignatureLength)
+			require.Contains(t, []byte{27, 28}, sig[crypto.RecoveryIDOffset])
+
+			_, err = signer.SignText(ctx, otherAddress, data)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestExternalSignerUnavailable(t *testing.T) {
+	signer := NewExternalSigner(filepath.Join(t.TempDir(), "missing.ipc"))
+	_, err := signer.Accounts(context.Background())
+	require.Error(t, err)
+}
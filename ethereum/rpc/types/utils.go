@@ -33,6 +33,16 @@ func RawTxToEthTx(clientCtx client.Context, txBz tmtypes.Tx) (*evmtypes.MsgEther
 	return ethTx, nil
 }
 
+// GetTxSender returns the sender of the transaction. The sender of an unsigned transaction, which is only
+// executed on development chains, is the From field.
+func GetTxSender(msg *evmtypes.MsgEthereumTx, chainID *big.Int) (common.Address, error) {
+	if msg.From != "" && msg.IsUnsigned() {
+		return common.HexToAddress(msg.From), nil
+	}
+
+	return msg.GetSender(chainID)
+}
+
 // NewTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 func NewTransaction(tx *ethtypes.Transaction, blockHash common.Hash, blockNumber, index uint64) *RPCTransaction {
@@ -221,7 +231,7 @@ func NewTransactionFromMsg(
 	blockNumber, index uint64,
 	chainID *big.Int,
 ) (*RPCTransaction, error) {
-	from, err := msg.GetSender(chainID)
+	from, err := GetTxSender(msg, chainID)
 	if err != nil {
 		return nil, err
 	}
@@ -3,7 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"path"
+	stdstrings "strings"
 
 	"github.com/spf13/viper"
 
@@ -28,6 +31,10 @@ const (
 	DefaultEVMTracer = "json"
 
 	DefaultGasCap uint64 = 25000000
+
+	// DefaultResponseCacheSize is the default number of JSON-RPC responses cached per cache
+	// (historical and latest block responses). The cache is disabled by default.
+	DefaultResponseCacheSize = 0
 )
 
 var evmTracers = []string{DefaultEVMTracer, "markdown", "struct", "access_list"}
@@ -55,12 +62,26 @@ type JSONRPCConfig struct {
 	Address string `mapstructure:"address"`
 	// WsAddress defines the WebSocket server to listen on
 	WsAddress string `mapstructure:"ws-address"`
+	// IPCPath defines the path of the IPC socket to listen on. Relative paths are resolved against
+	// the node home directory. The IPC server is disabled if the path is empty.
+	IPCPath string `mapstructure:"ipc-path"`
 	// API defines a list of JSON-RPC namespaces that should be enabled
 	API []string `mapstructure:"api"`
 	// Enable defines if the EVM RPC server should be enabled.
 	Enable bool `mapstructure:"enable"`
 	// GasCap is the global gas cap for eth-call variants.
 	GasCap uint64 `mapstructure:"gas-cap"`
+	// CacheSize defines the number of responses of the eth namespace that are cached by the HTTP
+	// server. The WebSocket and IPC calls are not cached. The cache is disabled if the size is 0.
+	CacheSize int `mapstructure:"cache-size"`
+	// CacheMethods defines the eth methods whose responses are cached.
+	CacheMethods []string `mapstructure:"cache-methods"`
+	// ArchiveGRPCAddress defines the gRPC endpoint of an archive node that serves the state queries at
+	// the heights pruned from the local node. The archive proxy is disabled if the address is empty.
+	ArchiveGRPCAddress string `mapstructure:"archive-grpc-address"`
+	// ExternalSigner defines the IPC path or HTTP URL of a Clef-compatible external signer that signs the
+	// transactions and data instead of the node keyring. The keyring is used if the endpoint is empty.
+	ExternalSigner string `mapstructure:"external-signer"`
 }
 
 // TLSConfig defines the certificate and matching private key for the server.
@@ -137,14 +158,41 @@ func GetDefaultAPINamespaces() []string {
 	return []string{"eth", "net", "web3"}
 }
 
+// GetAllAPINamespaces returns the list of all the JSON-RPC namespaces served by the node
+func GetAllAPINamespaces() []string {
+	return []string{"web3", "eth", "personal", "net", "txpool", "debug", "miner"}
+}
+
+// GetDevAPINamespaces returns the list of the dev-only JSON-RPC namespaces, which are only served by
+// the nodes of development chains
+func GetDevAPINamespaces() []string {
+	return []string{"evm", "hardhat", "anvil"}
+}
+
+// GetDefaultCacheMethods returns the default list of JSON-RPC methods whose responses are cached
+func GetDefaultCacheMethods() []string {
+	return []string{
+		"eth_blockNumber",
+		"eth_call",
+		"eth_chainId",
+		"eth_getBalance",
+		"eth_getBlockByNumber",
+		"eth_getCode",
+		"eth_getStorageAt",
+		"eth_getTransactionCount",
+	}
+}
+
 // DefaultJSONRPCConfig returns an EVM config with the JSON-RPC API enabled by default
 func DefaultJSONRPCConfig() *JSONRPCConfig {
 	return &JSONRPCConfig{
-		Enable:    true,
-		API:       GetDefaultAPINamespaces(),
-		Address:   DefaultJSONRPCAddress,
-		WsAddress: DefaultJSONRPCWsAddress,
-		GasCap:    DefaultGasCap,
+		Enable:       true,
+		API:          GetDefaultAPINamespaces(),
+		Address:      DefaultJSONRPCAddress,
+		WsAddress:    DefaultJSONRPCWsAddress,
+		GasCap:       DefaultGasCap,
+		CacheSize:    DefaultResponseCacheSize,
+		CacheMethods: GetDefaultCacheMethods(),
 	}
 }
 
@@ -164,6 +212,28 @@ func (c JSONRPCConfig) Validate() error {
 		seenAPIs[api] = true
 	}
 
+	if c.CacheSize < 0 {
+		return errors.New("JSON-RPC cache size cannot be negative")
+	}
+
+	for _, method := range c.CacheMethods {
+		if !stdstrings.HasPrefix(method, "eth_") {
+			return fmt.Errorf("cannot cache method '%s', only eth namespace methods can be cached", method)
+		}
+	}
+
+	if c.ArchiveGRPCAddress != "" {
+		if _, _, err := net.SplitHostPort(c.ArchiveGRPCAddress); err != nil {
+			return fmt.Errorf("invalid archive gRPC address '%s': %w", c.ArchiveGRPCAddress, err)
+		}
+	}
+
+	if c.ExternalSigner != "" {
+		if u, err := url.Parse(c.ExternalSigner); err == nil && u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid external signer endpoint '%s', expected an IPC path or HTTP URL", c.ExternalSigner)
+		}
+	}
+
 	return nil
 }
 
@@ -202,11 +272,17 @@ func GetConfig(v *viper.Viper) Config {
 			Tracer: v.GetString("evm.tracer"),
 		},
 		JSONRPC: JSONRPCConfig{
-			Enable:    v.GetBool("json-rpc.enable"),
-			API:       v.GetStringSlice("json-rpc.api"),
-			Address:   v.GetString("json-rpc.address"),
-			WsAddress: v.GetString("json-rpc.ws-address"),
-			GasCap:    v.GetUint64("json-rpc.gas-cap"),
+			Enable:       v.GetBool("json-rpc.enable"),
+			API:          v.GetStringSlice("json-rpc.api"),
+			Address:      v.GetString("json-rpc.address"),
+			WsAddress:    v.GetString("json-rpc.ws-address"),
+			IPCPath:      v.GetString("json-rpc.ipc-path"),
+			GasCap:       v.GetUint64("json-rpc.gas-cap"),
+			CacheSize:    v.GetInt("json-rpc.cache-size"),
+			CacheMethods: v.GetStringSlice("json-rpc.cache-methods"),
+
+			ArchiveGRPCAddress: v.GetString("json-rpc.archive-grpc-address"),
+			ExternalSigner:     v.GetString("json-rpc.external-signer"),
 		},
 		TLS: TLSConfig{
 			CertificatePath: v.GetString("tls.certificate-path"),
@@ -28,6 +28,12 @@ address = "{{ .JSONRPC.Address }}"
 # Address defines the EVM WebSocket server address to bind to.
 ws-address = "{{ .JSONRPC.WsAddress }}"
 
+# IPCPath defines the path of the IPC socket (Unix domain socket or Windows named pipe) to listen on.
+# Relative paths are resolved against the node home directory. Leave empty to disable the IPC server.
+# All the enabled namespaces are served over IPC, access is restricted by the file permissions.
+# Example: "ethermint.ipc"
+ipc-path = "{{ .JSONRPC.IPCPath }}"
+
 # API defines a list of JSON-RPC namespaces that should be enabled
 # Example: "eth,txpool,personal,net,debug,web3"
 api = "{{range $index, $elmt := .JSONRPC.API}}{{if $index}},{{$elmt}}{{else}}{{$elmt}}{{end}}{{end}}"
@@ -35,6 +41,26 @@ api = "{{range $index, $elmt := .JSONRPC.API}}{{if $index}},{{$elmt}}{{else}}{{$
 # GasCap sets a cap on gas that can be used in eth_call/estimateGas (0=infinite). Default: 25,000,000.
 gas-cap = {{ .JSONRPC.GasCap }}
 
+# CacheSize defines the number of eth namespace responses cached by the HTTP server (0=disabled).
+# Responses for a past block height or hash are cached permanently, responses for the latest block
+# are invalidated on every new block. The WebSocket and IPC calls are not cached.
+cache-size = {{ .JSONRPC.CacheSize }}
+
+# CacheMethods defines the list of eth namespace methods whose responses are cached.
+cache-methods = "{{range $index, $elmt := .JSONRPC.CacheMethods}}{{if $index}},{{$elmt}}{{else}}{{$elmt}}{{end}}{{end}}"
+
+# ArchiveGRPCAddress defines the gRPC endpoint (host:port) of an archive node. The state queries
+# (eth_getBalance, eth_getStorageAt, eth_getCode, eth_getTransactionCount, eth_call and
+# eth_estimateGas) at heights pruned from this node are forwarded to it. Leave empty to disable.
+archive-grpc-address = "{{ .JSONRPC.ArchiveGRPCAddress }}"
+
+# ExternalSigner defines the IPC path or HTTP URL of a Clef-compatible external signer. When set,
+# eth_sendTransaction, eth_sign and the personal namespace signing methods delegate the signing to the
+# account_signTransaction and account_signData methods of the signer instead of using the node keyring,
+# and eth_accounts returns the accounts of the signer. Leave empty to sign with the keyring.
+# Example: "/home/user/.clef/clef.ipc" or "http://localhost:8550"
+external-signer = "{{ .JSONRPC.ExternalSigner }}"
+
 ###############################################################################
 ###                             TLS Configuration                           ###
 ###############################################################################
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	tmconfig "github.com/tendermint/tendermint/config"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	ethermintclient "github.com/Electronic-Signatures-Industries/ancon-evm/client"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/server/config"
+	srvflags "github.com/Electronic-Signatures-Industries/ancon-evm/server/flags"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+const (
+	flagDevAccounts  = "accounts"
+	flagDevBalance   = "balance"
+	flagDevDataDir   = "data-dir"
+	flagDevBlockTime = "block-time"
+
+	// devChainID is the default chain-id of the development chain
+	devChainID = "ethermint_9000-1"
+	// devNodeDirPrefix and devNodeDaemonHome define the home directory of the development node,
+	// relative to the data directory, as initialized by the testnet command.
+	devNodeDirPrefix  = "node"
+	devNodeDaemonHome = "ethermintd"
+	// devConsensusTimeout is the timeout of the propose, prevote and precommit steps of the
+	// development node, which is the only validator of the chain.
+	devConsensusTimeout = 100 * time.Millisecond
+)
+
+// DevCmd runs a single validator development chain in-process, with prefunded accounts derived from
+// the well-known development mnemonic and all the JSON-RPC namespaces enabled.
+func DevCmd(
+	appCreator types.AppCreator, mbm module.BasicManager, genBalIterator banktypes.GenesisBalancesIterator,
+) *cobra.Command {
+	defaultBalance := sdk.NewCoin(
+		ethermint.AttoPhoton, sdk.TokensFromConsensusPower(10000, ethermint.PowerReduction),
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Run a single node development chain",
+		Long: fmt.Sprintf(`Run a single validator development chain in-process, with prefunded accounts and all the
+JSON-RPC namespaces enabled.
+
+The accounts are derived from the mnemonic %q along the
+HD path m/44'/60'/0'/0/x, so that they have the same addresses as the Hardhat and Anvil accounts.
+Their keys are imported into the keyring of the node, which signs the eth_sendTransaction requests.
+
+By default, a block is produced as soon as a transaction is received. The --%s flag produces
+blocks at a fixed interval instead. The block production, the block time and the state are controlled
+through the dev-only evm JSON-RPC namespace (evm_mine, evm_setAutomine, evm_setIntervalMining,
+evm_increaseTime, evm_setNextBlockTimestamp, evm_snapshot and evm_revert). The accounts are
+impersonated and the state is written through the dev-only hardhat and anvil namespaces
+(impersonateAccount, stopImpersonatingAccount, setBalance, setCode, setNonce and setStorageAt), the
+state writes being applied by a new block.
+
+The chain is stored in a temporary directory that is removed on exit, unless --%s is provided,
+in which case the chain is initialized on the first run and resumed on the next ones.
+
+WARNING: the private keys of the development accounts are public, they must never hold real funds.
+`, ethermintclient.DevMnemonic, flagDevBlockTime, flagDevDataDir),
+		Example: "ethermintd dev --accounts 5 --block-time 2s",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			numAccounts, _ := cmd.Flags().GetInt(flagDevAccounts)
+			balanceStr, _ := cmd.Flags().GetString(flagDevBalance)
+			chainID, _ := cmd.Flags().GetString(flags.FlagChainID)
+			dataDir, _ := cmd.Flags().GetString(flagDevDataDir)
+			blockTime, _ := cmd.Flags().GetDuration(flagDevBlockTime)
+
+			if numAccounts < 0 {
+				return fmt.Errorf("number of accounts cannot be negative, got %d", numAccounts)
+			}
+
+			if blockTime < 0 {
+				return fmt.Errorf("block time cannot be negative, got %s", blockTime)
+			}
+
+			balance, err := sdk.ParseCoinNormalized(balanceStr)
+			if err != nil {
+				return err
+			}
+
+			devAccounts, err := ethermintclient.DevAccounts(numAccounts)
+			if err != nil {
+				return err
+			}
+
+			if dataDir == "" {
+				dataDir, err = os.MkdirTemp("", "ethermint-dev")
+				if err != nil {
+					return err
+				}
+
+				defer os.RemoveAll(dataDir)
+			}
+
+			nodeHome := filepath.Join(dataDir, fmt.Sprintf("%s0", devNodeDirPrefix), devNodeDaemonHome)
+
+			// the node configuration is derived from the flags on every run
+			tmCfg := tmconfig.DefaultConfig()
+			tmCfg.Consensus.TimeoutPropose = devConsensusTimeout
+			tmCfg.Consensus.TimeoutPrevote = devConsensusTimeout
+			tmCfg.Consensus.TimeoutPrecommit = devConsensusTimeout
+			// the blocks are produced on demand by the dev node
+			tmCfg.Consensus.TimeoutCommit = 0
+			tmCfg.Consensus.SkipTimeoutCommit = true
+			// the node isn't the validator of the chain and has no peer to sync the blocks from
+			tmCfg.FastSyncMode = false
+			tmCfg.P2P.ListenAddress = "tcp://127.0.0.1:26656"
+
+			if _, err := os.Stat(filepath.Join(nodeHome, "config", "genesis.json")); os.IsNotExist(err) {
+				prefunded := make([]banktypes.Balance, len(devAccounts))
+				for i, account := range devAccounts {
+					prefunded[i] = banktypes.Balance{
+						Address: sdk.AccAddress(account.Address.Bytes()).String(),
+						Coins:   sdk.NewCoins(balance),
+					}
+				}
+
+				err := ethermintclient.InitTestnet(
+					clientCtx, cmd, tmCfg, mbm, genBalIterator, dataDir, chainID, balance.Denom, "0"+balance.Denom,
+					devNodeDirPrefix, devNodeDaemonHome, keyring.BackendTest, string(hd.EthSecp256k1Type),
+					[]string{"127.0.0.1"}, 1, prefunded,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			tmCfg.SetRoot(nodeHome)
+			tmCfg.RPC.ListenAddress = "tcp://127.0.0.1:26657"
+
+			kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, nodeHome, nil, hd.EthSecp256k1Option())
+			if err != nil {
+				return err
+			}
+
+			for i, account := range devAccounts {
+				if _, err := kr.KeyByAddress(sdk.AccAddress(account.Address.Bytes())); err == nil {
+					continue
+				}
+
+				name := fmt.Sprintf("dev%d", i)
+				if _, err := kr.NewAccount(name, ethermintclient.DevMnemonic, "", account.HDPath, hd.EthSecp256k1); err != nil {
+					return err
+				}
+			}
+
+			v := viper.New()
+			v.SetConfigFile(filepath.Join(nodeHome, "config", "app.toml"))
+			if err := v.ReadInConfig(); err != nil {
+				return err
+			}
+
+			jsonRPCAddress, _ := cmd.Flags().GetString(srvflags.JSONRPCAddress)
+			jsonWsAddress, _ := cmd.Flags().GetString(srvflags.JSONWsAddress)
+
+			v.Set(flags.FlagHome, nodeHome)
+			v.Set(srvflags.JSONRPCEnable, true)
+			v.Set(srvflags.Dev, true)
+			// the state sync snapshots aren't supported by the multistore of development chains
+			v.Set(server.FlagStateSyncSnapshotInterval, 0)
+			v.Set(flagDevBlockTime, blockTime)
+			v.Set(srvflags.JSONRPCAPI, append(config.GetAllAPINamespaces(), config.GetDevAPINamespaces()...))
+			v.Set(srvflags.JSONRPCAddress, jsonRPCAddress)
+			v.Set(srvflags.JSONWsAddress, jsonWsAddress)
+
+			serverCtx = server.NewContext(v, tmCfg, serverCtx.Logger)
+			clientCtx = clientCtx.
+				WithHomeDir(nodeHome).
+				WithKeyringDir(nodeHome).
+				WithKeyring(kr).
+				WithChainID(chainID)
+
+			printDevAccounts(cmd, devAccounts, balance, chainID, jsonRPCAddress)
+
+			err = startInProcess(serverCtx, clientCtx, appCreator)
+			errCode, ok := err.(server.ErrorCode)
+			if !ok {
+				return err
+			}
+
+			serverCtx.Logger.Debug(fmt.Sprintf("received quit signal: %d", errCode.Code))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(flagDevAccounts, 10, "Number of prefunded development accounts")
+	cmd.Flags().String(flagDevBalance, defaultBalance.String(), "Initial balance of each development account")
+	cmd.Flags().String(flags.FlagChainID, devChainID, "Chain ID of the development chain")
+	cmd.Flags().String(flagDevDataDir, "", "Directory to store the development chain in, a temporary directory removed on exit if empty")
+	cmd.Flags().Duration(flagDevBlockTime, 0, "Interval at which blocks are produced (0=a block per transaction)")
+	cmd.Flags().String(srvflags.JSONRPCAddress, config.DefaultJSONRPCAddress, "the JSON-RPC server address to listen on")
+	cmd.Flags().String(srvflags.JSONWsAddress, config.DefaultJSONRPCWsAddress, "the JSON-RPC WS server address to listen on")
+	return cmd
+}
+
+// printDevAccounts prints the addresses and private keys of the development accounts.
+func printDevAccounts(
+	cmd *cobra.Command, accounts []ethermintclient.DevAccount, balance sdk.Coin, chainID, jsonRPCAddress string,
+) {
+	cmd.Printf("Available Accounts\n==================\n\n")
+	for i, account := range accounts {
+		cmd.Printf("(%d) %s (%s) %s\n", i, account.Address.Hex(), sdk.AccAddress(account.Address.Bytes()), balance)
+	}
+
+	cmd.Printf("\nPrivate Keys\n==================\n\n")
+	for i, account := range accounts {
+		cmd.Printf("(%d) %s\n", i, hexutil.Encode(account.PrivKey.Bytes()))
+	}
+
+	cmd.Printf("\nHD Wallet\n==================\n\n")
+	cmd.Printf("Mnemonic:     %s\n", ethermintclient.DevMnemonic)
+	cmd.Printf("HD Path:      m/44'/60'/0'/0/x\n\n")
+	cmd.Printf("Chain ID:     %s\n", chainID)
+	cmd.Printf("JSON-RPC:     http://%s\n\n", jsonRPCAddress)
+	cmd.Printf("WARNING: these accounts and their private keys are publicly known, never send real funds to them.\n\n")
+}
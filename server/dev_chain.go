@@ -0,0 +1,482 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/iavl"
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/google/orderedcode"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmconfig "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/node"
+	pvm "github.com/tendermint/tendermint/privval"
+	tmstateproto "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmstoreproto "github.com/tendermint/tendermint/proto/tendermint/store"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/server/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/hardhat"
+)
+
+// devApplication is the application of a development chain, whose multistore is rolled back to
+// revert the chain and whose dev-only state is written by the JSON-RPC server.
+type devApplication interface {
+	types.Application
+	hardhat.DevState
+
+	CommitMultiStore() sdk.CommitMultiStore
+	// DiscardPending drops the pending dev-only state writes and the state of the transactions checked
+	// since the last block.
+	DiscardPending()
+}
+
+// devChain runs the in-process node of a development chain. The node is stopped to roll back the
+// node and the application to a previous height, then a new node is started at that height.
+// NOTE: it's not safe for concurrent use, the calls are serialized by the dev node.
+type devChain struct {
+	cfg     *tmconfig.Config
+	logger  log.Logger
+	app     devApplication
+	appDB   dbm.DB
+	pv      *pvm.FilePV
+	dbs     node.DBProvider
+	newNode func() (*node.Node, error)
+	node    *node.Node
+}
+
+// Node returns the running node of the chain.
+func (c *devChain) Node() *node.Node {
+	return c.node
+}
+
+// Mempool returns the mempool of the running node.
+func (c *devChain) Mempool() mempool.Mempool {
+	return c.node.Mempool()
+}
+
+// Height returns the height of the last block committed.
+func (c *devChain) Height() int64 {
+	return c.node.BlockStore().Height()
+}
+
+// Discard drops the transactions of the mempool and the pending dev-only state writes of the
+// application, along with the state of the transactions it checked.
+func (c *devChain) Discard() {
+	mp := c.node.Mempool()
+	mp.Flush()
+
+	// no transaction is checked while the application discards their state
+	mp.Lock()
+	defer mp.Unlock()
+	c.app.DiscardPending()
+}
+
+// Stop stops the running node.
+func (c *devChain) Stop() error {
+	return c.node.Stop()
+}
+
+// Rollback rolls back the application and the stopped node to the given height, then starts a new
+// node which resumes the chain from that height. The pending dev-only state writes are dropped along
+// with the transactions of the stopped node.
+func (c *devChain) Rollback(height int64) error {
+	c.logger.Info("rolling back the chain", "height", height, "latest", c.Height())
+
+	// the state is loaded before any rollback, since it fails if the height can't be rolled back to
+	state, err := loadRollbackState(c.cfg, c.dbs, height)
+	if err != nil {
+		return fmt.Errorf("failed to load the state at height %d: %w", height, err)
+	}
+
+	if err := rollbackApp(c.appDB, c.app.CommitMultiStore(), height); err != nil {
+		return fmt.Errorf("failed to roll back the application: %w", err)
+	}
+
+	// the transactions of the stopped node are dropped
+	c.app.DiscardPending()
+
+	if err := rollbackNode(c.cfg, c.dbs, state); err != nil {
+		return fmt.Errorf("failed to roll back the node: %w", err)
+	}
+
+	// the blocks of the heights rolled back are signed again
+	c.pv.Reset()
+
+	tmNode, err := c.newNode()
+	if err != nil {
+		return err
+	}
+
+	c.node = tmNode
+	return tmNode.Start()
+}
+
+// devDBProvider opens each database of the node once, since the databases aren't closed when the node
+// is stopped and can't be opened again by the next node.
+type devDBProvider struct {
+	mtx sync.Mutex
+	dbs map[string]dbm.DB
+}
+
+func newDevDBProvider() *devDBProvider {
+	return &devDBProvider{
+		dbs: make(map[string]dbm.DB),
+	}
+}
+
+// DB implements node.DBProvider.
+func (p *devDBProvider) DB(ctx *node.DBContext) (dbm.DB, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if db, ok := p.dbs[ctx.ID]; ok {
+		return db, nil
+	}
+
+	db, err := node.DefaultDBProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dbs[ctx.ID] = db
+	return db, nil
+}
+
+// rollbackApp deletes the versions of the stores after the given height and loads the multistore at
+// that height.
+func rollbackApp(db dbm.DB, cms sdk.CommitMultiStore, height int64) error {
+	bz, err := db.Get(commitInfoKey(height))
+	if err != nil {
+		return err
+	} else if bz == nil {
+		return fmt.Errorf("no commit info found for height %d", height)
+	}
+
+	var cInfo storetypes.CommitInfo
+	if err := cInfo.Unmarshal(bz); err != nil {
+		return err
+	}
+
+	for _, storeInfo := range cInfo.StoreInfos {
+		tree, err := iavl.NewMutableTree(dbm.NewPrefixDB(db, storePrefix(storeInfo.Name)), 0)
+		if err != nil {
+			return err
+		}
+
+		// the memory stores aren't persisted
+		if latest, err := tree.Load(); err != nil {
+			return err
+		} else if latest == 0 {
+			continue
+		}
+
+		if _, err := tree.LoadVersionForOverwriting(height); err != nil {
+			return fmt.Errorf("failed to roll back store %s: %w", storeInfo.Name, err)
+		}
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	for version := height + 1; version <= cms.LastCommitID().Version; version++ {
+		if err := batch.Delete(commitInfoKey(version)); err != nil {
+			return err
+		}
+	}
+
+	bz, err = gogotypes.StdInt64Marshal(height)
+	if err != nil {
+		return err
+	}
+
+	if err := batch.Set([]byte(latestVersionKey), bz); err != nil {
+		return err
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	return cms.LoadVersion(height)
+}
+
+// loadRollbackState returns the state of the node once the block of the given height was committed.
+// The results and the app hash of the block are committed by the header of the next block.
+func loadRollbackState(cfg *tmconfig.Config, dbProvider node.DBProvider, height int64) (sm.State, error) {
+	stateDB, err := dbProvider(&node.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	blockStoreDB, err := dbProvider(&node.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	stateStore := sm.NewStore(stateDB)
+	blockStore := tmstore.NewBlockStore(blockStoreDB)
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	blockMeta := blockStore.LoadBlockMeta(height)
+	nextBlockMeta := blockStore.LoadBlockMeta(height + 1)
+	if blockMeta == nil || nextBlockMeta == nil {
+		return sm.State{}, fmt.Errorf("blocks %d and %d not found", height, height+1)
+	}
+
+	// the next validators and the consensus params of the state are saved with the heights they apply to
+	var (
+		validatorsInfo      tmstateproto.ValidatorsInfo
+		consensusParamsInfo tmstateproto.ConsensusParamsInfo
+	)
+
+	if err := loadProto(stateDB, validatorsKey(height+2), &validatorsInfo); err != nil {
+		return sm.State{}, err
+	}
+
+	if err := loadProto(stateDB, consensusParamsKey(height+1), &consensusParamsInfo); err != nil {
+		return sm.State{}, err
+	}
+
+	if state.LastValidators, err = stateStore.LoadValidators(height); err != nil {
+		return sm.State{}, err
+	}
+
+	if state.Validators, err = stateStore.LoadValidators(height + 1); err != nil {
+		return sm.State{}, err
+	}
+
+	if state.NextValidators, err = stateStore.LoadValidators(height + 2); err != nil {
+		return sm.State{}, err
+	}
+
+	if state.ConsensusParams, err = stateStore.LoadConsensusParams(height + 1); err != nil {
+		return sm.State{}, err
+	}
+
+	state.LastBlockHeight = height
+	state.LastBlockID = blockMeta.BlockID
+	state.LastBlockTime = blockMeta.Header.Time
+	state.LastHeightValidatorsChanged = validatorsInfo.LastHeightChanged
+	state.LastHeightConsensusParamsChanged = consensusParamsInfo.LastHeightChanged
+	state.LastResultsHash = nextBlockMeta.Header.LastResultsHash
+	state.AppHash = nextBlockMeta.Header.AppHash
+
+	return state, nil
+}
+
+// loadProto unmarshals the value of a key, which must be set.
+func loadProto(db dbm.DB, key []byte, msg proto.Message) error {
+	bz, err := db.Get(key)
+	if err != nil {
+		return err
+	} else if bz == nil {
+		return fmt.Errorf("key %s not found", key)
+	}
+
+	return proto.Unmarshal(bz, msg)
+}
+
+// rollbackNode saves the given state and rolls back the blocks, the indexed transactions and the
+// consensus WAL of the node to the height of the state.
+func rollbackNode(cfg *tmconfig.Config, dbProvider node.DBProvider, state sm.State) error {
+	stateDB, err := dbProvider(&node.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return err
+	}
+
+	blockStoreDB, err := dbProvider(&node.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return err
+	}
+
+	if err := sm.NewStore(stateDB).Save(state); err != nil {
+		return err
+	}
+
+	height := state.LastBlockHeight
+	if err := rollbackBlockStore(blockStoreDB, height); err != nil {
+		return err
+	}
+
+	if cfg.TxIndex.Indexer == "kv" {
+		txIndexDB, err := dbProvider(&node.DBContext{ID: "tx_index", Config: cfg})
+		if err != nil {
+			return err
+		}
+
+		if err := rollbackTxIndex(txIndexDB, height); err != nil {
+			return err
+		}
+	}
+
+	return rollbackWAL(cfg.Consensus.WalFile(), height)
+}
+
+// rollbackBlockStore deletes the blocks after the given height. The commit of the block of the given
+// height is deleted too, since it's saved with the next block.
+func rollbackBlockStore(db dbm.DB, height int64) error {
+	blockStore := tmstore.NewBlockStore(db)
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	for h := height + 1; h <= blockStore.Height(); h++ {
+		keys := [][]byte{blockMetaKey(h), blockCommitKey(h - 1), seenCommitKey(h)}
+
+		if blockMeta := blockStore.LoadBlockMeta(h); blockMeta != nil {
+			keys = append(keys, blockHashKey(blockMeta.BlockID.Hash))
+			for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
+				keys = append(keys, blockPartKey(h, i))
+			}
+		}
+
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	tmstore.SaveBlockStoreState(&tmstoreproto.BlockStoreState{Base: blockStore.Base(), Height: height}, db)
+	return nil
+}
+
+// rollbackTxIndex deletes the transactions and the block events indexed after the given height. A
+// transaction is indexed by its hash, and its events are indexed by keys whose value is its hash,
+// which are deleted along with it whatever the values of the events.
+func rollbackTxIndex(db dbm.DB, height int64) error {
+	hashes := make(map[string]bool)
+
+	keys, err := filterKeys(db, func(key, value []byte) bool {
+		if strings.HasPrefix(string(key), blockEventsPrefix) {
+			return blockEventHeight(key[len(blockEventsPrefix):]) > height
+		}
+
+		if txHeight, ok := indexedTxHeight(key, value); ok && txHeight > height {
+			hashes[string(key)] = true
+			return true
+		}
+
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	eventKeys, err := filterKeys(db, func(key, value []byte) bool {
+		return hashes[string(value)] && !hashes[string(key)]
+	})
+	if err != nil {
+		return err
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	for _, key := range append(keys, eventKeys...) {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
+// filterKeys returns the keys of a database whose entry matches the given filter.
+func filterKeys(db dbm.DB, filter func(key, value []byte) bool) ([][]byte, error) {
+	it, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		if filter(it.Key(), it.Value()) {
+			keys = append(keys, append([]byte{}, it.Key()...))
+		}
+	}
+
+	return keys, it.Error()
+}
+
+// indexedTxHeight returns the height of an indexed transaction, if the key is the hash of the
+// transaction of the result it's indexed with.
+func indexedTxHeight(key, value []byte) (int64, bool) {
+	if len(key) != tmhash.Size {
+		return 0, false
+	}
+
+	var txResult abci.TxResult
+	if err := txResult.Unmarshal(value); err != nil || !bytes.Equal(tmhash.Sum(txResult.Tx), key) {
+		return 0, false
+	}
+
+	return txResult.Height, true
+}
+
+// blockEventHeight returns the height of a key of the block indexer, which is either the height key
+// or an event key, or 0 if the key can't be parsed.
+func blockEventHeight(key []byte) int64 {
+	var (
+		compositeKey, value, eventType string
+		height                         int64
+	)
+
+	if rest, err := orderedcode.Parse(string(key), &compositeKey, &value, &height, &eventType); err == nil && rest == "" {
+		return height
+	}
+
+	if rest, err := orderedcode.Parse(string(key), &compositeKey, &height); err == nil && rest == "" {
+		return height
+	}
+
+	return 0
+}
+
+// rollbackWAL replaces the consensus WAL by a WAL ending at the given height, from which the consensus
+// of the next height starts.
+func rollbackWAL(walFile string, height int64) error {
+	if err := os.RemoveAll(filepath.Dir(walFile)); err != nil {
+		return err
+	}
+
+	wal, err := consensus.NewWAL(walFile)
+	if err != nil {
+		return err
+	}
+
+	if err := wal.Start(); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = wal.Stop()
+	}()
+
+	return wal.WriteSync(consensus.EndHeightMessage{Height: height})
+}
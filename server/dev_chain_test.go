@@ -0,0 +1,183 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmstateproto "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+)
+
+// countKeys returns the number of keys of a database.
+func countKeys(t *testing.T, db dbm.DB) int {
+	keys, err := filterKeys(db, func(_, _ []byte) bool { return true })
+	require.NoError(t, err)
+	return len(keys)
+}
+
+func TestRollbackTxIndex(t *testing.T) {
+	db := dbm.NewMemDB()
+	txIndexer := kv.NewTxIndex(db)
+	blockIndexer := blockidxkv.New(dbm.NewPrefixDB(db, []byte(blockEventsPrefix)))
+
+	// the values of the events contain the separator of the keys
+	attribute := func(key, value string) abci.EventAttribute {
+		return abci.EventAttribute{Key: []byte(key), Value: []byte(value), Index: true}
+	}
+	events := []abci.Event{
+		{Type: "message", Attributes: []abci.EventAttribute{attribute("action", "/ethermint.evm.v1.MsgEthereumTx")}},
+		{Type: "tx", Attributes: []abci.EventAttribute{
+			attribute("acc_seq", "ethm1addr/3"),
+			attribute("signature", "ab/cd+ef/gh=="),
+		}},
+	}
+
+	var hashes [][]byte
+	for height := int64(1); height <= 3; height++ {
+		txResult := &abci.TxResult{
+			Height: height,
+			Tx:     []byte(fmt.Sprintf("tx%d", height)),
+			Result: abci.ResponseDeliverTx{Events: events},
+		}
+		require.NoError(t, txIndexer.Index(txResult))
+		hashes = append(hashes, tmtypes.Tx(txResult.Tx).Hash())
+
+		require.NoError(t, blockIndexer.Index(tmtypes.EventDataNewBlockHeader{
+			Header: tmtypes.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{attribute("sender", "ethm1/addr")}},
+			}},
+		}))
+	}
+
+	keys := countKeys(t, db)
+	require.NoError(t, rollbackTxIndex(db, 1))
+
+	// the transaction, its height and event keys, the height key and the block event of the first
+	// height are kept
+	require.Equal(t, keys/3, countKeys(t, db))
+
+	for i, hash := range hashes {
+		txResult, err := txIndexer.Get(hash)
+		require.NoError(t, err)
+		require.Equal(t, i == 0, txResult != nil)
+
+		has, err := blockIndexer.Has(int64(i + 1))
+		require.NoError(t, err)
+		require.Equal(t, i == 0, has)
+	}
+
+	eventKeys, err := filterKeys(db, func(_, value []byte) bool { return string(value) == string(hashes[0]) })
+	require.NoError(t, err)
+	require.Len(t, eventKeys, 4)
+}
+
+func TestRollbackBlockStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	blockStore := tmstore.NewBlockStore(db)
+
+	lastCommit := tmtypes.NewCommit(0, 0, tmtypes.BlockID{}, nil)
+	for height := int64(1); height <= 3; height++ {
+		block := tmtypes.MakeBlock(height, []tmtypes.Tx{[]byte("tx")}, lastCommit, nil)
+		block.ProposerAddress = ed25519.GenPrivKey().PubKey().Address()
+		parts := block.MakePartSet(tmtypes.BlockPartSizeBytes)
+		blockID := tmtypes.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+		lastCommit = tmtypes.NewCommit(height, 0, blockID, []tmtypes.CommitSig{tmtypes.NewCommitSigAbsent()})
+		blockStore.SaveBlock(block, parts, lastCommit)
+	}
+
+	blockHash := blockStore.LoadBlockMeta(2).BlockID.Hash
+	require.NoError(t, rollbackBlockStore(db, 1))
+
+	blockStore = tmstore.NewBlockStore(db)
+	require.Equal(t, int64(1), blockStore.Height())
+	require.NotNil(t, blockStore.LoadBlock(1))
+	require.NotNil(t, blockStore.LoadSeenCommit(1))
+
+	// the commit of the first block is saved with the second block
+	require.Nil(t, blockStore.LoadBlockCommit(1))
+	require.Nil(t, blockStore.LoadBlockByHash(blockHash))
+
+	for height := int64(2); height <= 3; height++ {
+		require.Nil(t, blockStore.LoadBlockMeta(height))
+		require.Nil(t, blockStore.LoadBlockPart(height, 0))
+		require.Nil(t, blockStore.LoadSeenCommit(height))
+	}
+
+	// the meta, part, hash and seen commit of the first block, and the state of the store are kept
+	require.Equal(t, 5, countKeys(t, db))
+}
+
+func TestStateStoreKeys(t *testing.T) {
+	genDoc := &tmtypes.GenesisDoc{
+		ChainID:     devChainID,
+		GenesisTime: tmtime.Now(),
+		Validators:  []tmtypes.GenesisValidator{{PubKey: ed25519.GenPrivKey().PubKey(), Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	state.LastBlockHeight = 5
+	require.NoError(t, sm.NewStore(db).Save(state))
+
+	// the next validators apply to the height after the next one
+	var validatorsInfo tmstateproto.ValidatorsInfo
+	require.NoError(t, loadProto(db, validatorsKey(7), &validatorsInfo))
+	require.Equal(t, state.LastHeightValidatorsChanged, validatorsInfo.LastHeightChanged)
+
+	var consensusParamsInfo tmstateproto.ConsensusParamsInfo
+	require.NoError(t, loadProto(db, consensusParamsKey(6), &consensusParamsInfo))
+	require.Equal(t, state.LastHeightConsensusParamsChanged, consensusParamsInfo.LastHeightChanged)
+}
+
+func TestRollbackApp(t *testing.T) {
+	db := dbm.NewMemDB()
+	key := storetypes.NewKVStoreKey("test")
+
+	newStore := func() storetypes.CommitMultiStore {
+		cms := store.NewCommitMultiStore(db)
+		cms.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+		require.NoError(t, cms.LoadLatestVersion())
+		return cms
+	}
+
+	cms := newStore()
+	for version := 1; version <= 3; version++ {
+		cms.GetKVStore(key).Set([]byte("key"), []byte(fmt.Sprintf("value%d", version)))
+		cms.Commit()
+	}
+
+	require.NoError(t, rollbackApp(db, cms, 1))
+	require.Equal(t, int64(1), cms.LastCommitID().Version)
+	require.Equal(t, []byte("value1"), cms.GetKVStore(key).Get([]byte("key")))
+
+	for version := int64(2); version <= 3; version++ {
+		has, err := db.Has(commitInfoKey(version))
+		require.NoError(t, err)
+		require.False(t, has)
+	}
+
+	// the rolled back version is the latest version of the multistore, whose next version replaces
+	// the versions rolled back
+	cms = newStore()
+	require.Equal(t, int64(1), cms.LastCommitID().Version)
+	cms.GetKVStore(key).Set([]byte("key"), []byte("value"))
+	require.Equal(t, int64(2), cms.Commit().Version)
+}
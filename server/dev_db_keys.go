@@ -0,0 +1,62 @@
+package server
+
+import "fmt"
+
+// The keys below are the unexported keys of the databases rolled back by development chains. They
+// are copied from Tendermint v0.34.13 (store/store.go, state/store.go and node/node.go) and from the
+// Cosmos SDK v0.44.1 (store/rootmulti/store.go), and must be checked against both sources whenever
+// either dependency is upgraded. The tests of the rollbacks write the databases through the stores of
+// these versions, so that they fail if a key changes. The transactions indexed by the tx indexer of
+// state/txindex/kv/kv.go are deleted by hash instead, whatever the keys of their events.
+
+const (
+	// latestVersionKey is the key of the latest version of the rootmulti store
+	latestVersionKey = "s/latest"
+	// blockEventsPrefix is the prefix of the block indexer in the tx index database
+	blockEventsPrefix = "block_events"
+)
+
+// commitInfoKey is the key of the commit info of a version of the rootmulti store.
+func commitInfoKey(version int64) []byte {
+	return []byte(fmt.Sprintf("s/%d", version))
+}
+
+// storePrefix is the prefix of a store of the rootmulti store.
+func storePrefix(name string) []byte {
+	return []byte(fmt.Sprintf("s/k:%s/", name))
+}
+
+// blockMetaKey is the key of the meta of a block in the block store.
+func blockMetaKey(height int64) []byte {
+	return []byte(fmt.Sprintf("H:%v", height))
+}
+
+// blockPartKey is the key of a part of a block in the block store.
+func blockPartKey(height int64, index int) []byte {
+	return []byte(fmt.Sprintf("P:%v:%v", height, index))
+}
+
+// blockCommitKey is the key of the commit of a block in the block store, saved with the next block.
+func blockCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("C:%v", height))
+}
+
+// seenCommitKey is the key of the commit of a block seen by the node in the block store.
+func seenCommitKey(height int64) []byte {
+	return []byte(fmt.Sprintf("SC:%v", height))
+}
+
+// blockHashKey is the key of the height of a block by hash in the block store.
+func blockHashKey(hash []byte) []byte {
+	return []byte(fmt.Sprintf("BH:%x", hash))
+}
+
+// validatorsKey is the key of the validators of a height in the state store.
+func validatorsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("validatorsKey:%v", height))
+}
+
+// consensusParamsKey is the key of the consensus params of a height in the state store.
+func consensusParamsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("consensusParamsKey:%v", height))
+}
@@ -0,0 +1,547 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	pvm "github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/hardhat"
+)
+
+const (
+	// devPollInterval is the interval at which the mempool is polled for transactions to mine when the
+	// automine is enabled, and at which the commit of a block is polled.
+	devPollInterval = 10 * time.Millisecond
+	// devCommitTimeout is the maximum time to wait for the node to commit a block once it's voted.
+	devCommitTimeout = 10 * time.Second
+)
+
+var errDevNodeStopped = errors.New("dev node stopped")
+
+var _ hardhat.DevNode = (*devNode)(nil)
+
+// devNode produces the blocks of a development chain, whose single validator key is held by the node.
+// The Tendermint node runs as a full node, so it doesn't produce blocks on its own:
+//   - A block is produced either on request, for the transactions of the mempool or at the mining
+//     interval. The dev node proposes the block with the transactions of the mempool and votes for it
+//     with the validator key, then the block is committed by the consensus of the node.
+//   - The time of a block is the median time of the precommits of the previous block. The precommits
+//     are signed with the time of the dev clock, which is shifted by the time offset. The time of a
+//     block is thus the time of the dev clock when the previous block was committed.
+//   - A snapshot records the height of the last block committed. The chain is reverted to a snapshot
+//     by rolling back the node and the application to that height, then a new node is started which
+//     resumes the chain from that height.
+//   - The accounts are impersonated and the state is written by the application of the chain, the
+//     state writes are applied at the beginning of the next block.
+type devNode struct {
+	// DevState is the dev-only state of the application, set along with the chain
+	hardhat.DevState
+
+	logger log.Logger
+	pv     *pvm.FilePV
+	chain  *devChain
+
+	// resetMtx serializes the blocks, the snapshots and the reverts of the chain
+	resetMtx     sync.Mutex
+	snapshots    map[uint64]devSnapshot
+	lastSnapshot uint64
+	// onRestart are called once the chain is restarted
+	onRestart []func()
+
+	mtx      sync.Mutex
+	automine bool
+	interval time.Duration
+	// offset is the difference between the dev clock and the wall clock
+	offset time.Duration
+	// nextTime is the time of the next precommit, if it's set
+	nextTime *time.Time
+	// pendingTime is the time of the last precommit, which is the time of the next block
+	pendingTime time.Time
+	// lastBlock is the wall clock time of the last block commit
+	lastBlock time.Time
+	// requests are the mining requests, each one is released once a block is committed
+	requests []chan error
+
+	wake     chan struct{}
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// devSnapshot is a snapshot of the chain, to which the chain can be reverted.
+type devSnapshot struct {
+	height int64
+	offset time.Duration
+}
+
+// newDevNode creates the block producer of a development chain, which signs the blocks with the given
+// validator key. The blocks are produced at the given interval, or for each transaction received if
+// it's 0.
+func newDevNode(pv *pvm.FilePV, logger log.Logger, interval time.Duration) *devNode {
+	return &devNode{
+		pv:        pv,
+		logger:    logger.With("module", "dev-node"),
+		automine:  interval == 0,
+		interval:  interval,
+		snapshots: make(map[uint64]devSnapshot),
+		lastBlock: time.Now(),
+		wake:      make(chan struct{}, 1),
+		quit:      make(chan struct{}),
+	}
+}
+
+// SetChain sets the chain whose blocks are produced by the node.
+func (n *devNode) SetChain(chain *devChain) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.chain = chain
+	n.DevState = chain.app
+}
+
+// OnRestart registers a function called once the chain is restarted to revert it.
+func (n *devNode) OnRestart(fn func()) {
+	n.resetMtx.Lock()
+	defer n.resetMtx.Unlock()
+	n.onRestart = append(n.onRestart, fn)
+}
+
+// Start starts producing the blocks of the chain, whose node must be running. The first block is
+// produced right away if the chain has none, so that the genesis state can be queried.
+func (n *devNode) Start() {
+	if n.chain.Height() == 0 {
+		n.mtx.Lock()
+		n.requests = append(n.requests, make(chan error, 1))
+		n.mtx.Unlock()
+	}
+
+	go n.run()
+}
+
+// Stop stops producing blocks and releases the pending mining requests. It returns once the block or
+// the revert in progress, if any, is completed.
+func (n *devNode) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.quit)
+	})
+
+	n.resetMtx.Lock()
+	defer n.resetMtx.Unlock()
+}
+
+// run produces a block whenever one must be produced, until the node is stopped.
+func (n *devNode) run() {
+	ticker := time.NewTicker(devPollInterval)
+	defer ticker.Stop()
+
+	for {
+		n.mineIfNeeded()
+
+		select {
+		case <-n.wake:
+		case <-ticker.C:
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// mineIfNeeded produces the next block if it must be produced, and releases the mining request it's
+// produced for, if any.
+func (n *devNode) mineIfNeeded() {
+	n.resetMtx.Lock()
+	defer n.resetMtx.Unlock()
+
+	select {
+	case <-n.quit:
+		return
+	default:
+	}
+
+	request, ok := n.shouldMine()
+	if !ok {
+		return
+	}
+
+	err := n.mine()
+	if err != nil {
+		n.logger.Error("failed to mine block", "error", err.Error())
+	}
+
+	if request != nil {
+		request <- err
+	}
+}
+
+// shouldMine returns true if the next block must be produced, either because it's requested, the
+// automine is enabled and the mempool has transactions, or the mining interval has elapsed since the
+// last block. It returns the mining request to release, if the block is requested.
+// NOTE: the reset mutex must be locked.
+func (n *devNode) shouldMine() (chan error, bool) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	switch {
+	case len(n.requests) > 0:
+		request := n.requests[0]
+		n.requests = n.requests[1:]
+		n.logger.Debug("mining requested block")
+		return request, true
+	case n.automine && n.chain.Mempool().Size() > 0:
+		n.logger.Debug("mining block for mempool transactions", "txs", n.chain.Mempool().Size())
+		return nil, true
+	case n.interval > 0 && time.Since(n.lastBlock) >= n.interval:
+		n.logger.Debug("mining block at interval", "interval", n.interval)
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// mine proposes the next block with the transactions of the mempool and votes for it, then waits until
+// the node commits it.
+// NOTE: the reset mutex must be locked.
+func (n *devNode) mine() error {
+	tmNode := n.chain.Node()
+	consensusState := tmNode.ConsensusState()
+	state := consensusState.GetState()
+	height := state.LastBlockHeight + 1
+
+	lastCommit := tmtypes.NewCommit(0, 0, tmtypes.BlockID{}, nil)
+	if height > state.InitialHeight {
+		if lastCommit = tmNode.BlockStore().LoadSeenCommit(state.LastBlockHeight); lastCommit == nil {
+			return fmt.Errorf("commit of block %d not found", state.LastBlockHeight)
+		}
+	}
+
+	params := state.ConsensusParams.Block
+	txs := tmNode.Mempool().ReapMaxBytesMaxGas(
+		tmtypes.MaxDataBytesNoEvidence(params.MaxBytes, state.Validators.Size()), params.MaxGas,
+	)
+
+	proposer := state.Validators.GetProposer().Address
+	block, parts := state.MakeBlock(height, txs, lastCommit, nil, proposer)
+	blockID := tmtypes.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+	proposal := tmtypes.NewProposal(height, 0, -1, blockID)
+	proposalProto := proposal.ToProto()
+	if err := n.pv.SignProposal(state.ChainID, proposalProto); err != nil {
+		return err
+	}
+	proposal.Signature = proposalProto.Signature
+
+	prevote, err := n.signVote(state, tmproto.PrevoteType, blockID, tmtime.Now())
+	if err != nil {
+		return err
+	}
+
+	// the block time must increase
+	n.mtx.Lock()
+	precommitTime := n.nextBlockTime(block.Time.Add(time.Millisecond))
+	n.mtx.Unlock()
+
+	precommit, err := n.signVote(state, tmproto.PrecommitType, blockID, precommitTime)
+	if err != nil {
+		return err
+	}
+
+	// the messages are processed in order by the consensus, as the ones of the validator of the node
+	if err := consensusState.SetProposalAndBlock(proposal, block, parts, ""); err != nil {
+		return err
+	}
+
+	for _, vote := range []*tmtypes.Vote{prevote, precommit} {
+		if _, err := consensusState.AddVote(vote, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := n.waitForCommit(height); err != nil {
+		return err
+	}
+
+	n.mtx.Lock()
+	n.pendingTime = precommit.Timestamp
+	n.lastBlock = time.Now()
+	n.mtx.Unlock()
+
+	n.logger.Debug("block mined", "height", height, "txs", len(txs))
+	return nil
+}
+
+// signVote signs a vote of the validator for the given block.
+func (n *devNode) signVote(
+	state sm.State, voteType tmproto.SignedMsgType, blockID tmtypes.BlockID, timestamp time.Time,
+) (*tmtypes.Vote, error) {
+	address := n.pv.GetAddress()
+	index, _ := state.Validators.GetByAddress(address)
+
+	vote := &tmtypes.Vote{
+		Type:             voteType,
+		Height:           state.LastBlockHeight + 1,
+		Round:            0,
+		BlockID:          blockID,
+		Timestamp:        timestamp,
+		ValidatorAddress: address,
+		ValidatorIndex:   index,
+	}
+
+	voteProto := vote.ToProto()
+	if err := n.pv.SignVote(state.ChainID, voteProto); err != nil {
+		return nil, err
+	}
+
+	// the private validator keeps the time of a vote signed again
+	vote.Signature = voteProto.Signature
+	vote.Timestamp = voteProto.Timestamp
+	return vote, nil
+}
+
+// waitForCommit waits until the node commits the block of the given height.
+func (n *devNode) waitForCommit(height int64) error {
+	ticker := time.NewTicker(devPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(devCommitTimeout)
+	defer timeout.Stop()
+
+	for n.chain.Node().ConsensusState().GetState().LastBlockHeight < height {
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return fmt.Errorf("block %d not committed after %s", height, devCommitTimeout)
+		case <-n.quit:
+			return errDevNodeStopped
+		}
+	}
+
+	return nil
+}
+
+// nextBlockTime returns the time of the dev clock, which must be after the given minimum time.
+// NOTE: the mutex must be locked.
+func (n *devNode) nextBlockTime(minTime time.Time) time.Time {
+	now := tmtime.Now()
+
+	blockTime := now.Add(n.offset)
+	if n.nextTime != nil {
+		// the time of the following blocks continues from the time set
+		blockTime = *n.nextTime
+		n.offset = blockTime.Sub(now)
+		n.nextTime = nil
+	}
+
+	if blockTime.Before(minTime) {
+		return tmtime.Canonical(minTime)
+	}
+
+	return tmtime.Canonical(blockTime)
+}
+
+// notify wakes up the block production.
+func (n *devNode) notify() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Mine implements evm.DevNode.
+func (n *devNode) Mine(ctx context.Context) error {
+	done := make(chan error, 1)
+
+	n.mtx.Lock()
+	n.requests = append(n.requests, done)
+	n.mtx.Unlock()
+
+	n.notify()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-n.quit:
+		return errDevNodeStopped
+	}
+}
+
+// SetAutomine implements evm.DevNode.
+func (n *devNode) SetAutomine(enabled bool) {
+	n.mtx.Lock()
+	n.automine = enabled
+	n.mtx.Unlock()
+
+	n.notify()
+}
+
+// SetIntervalMining implements evm.DevNode.
+func (n *devNode) SetIntervalMining(interval time.Duration) {
+	n.mtx.Lock()
+	n.interval = interval
+	n.mtx.Unlock()
+
+	n.notify()
+}
+
+// IncreaseTime implements evm.DevNode. The time of the next block is already set by the precommit of
+// the last block, so a block is produced for the time offset to apply to the next block.
+func (n *devNode) IncreaseTime(ctx context.Context, d time.Duration) (time.Duration, error) {
+	if d < 0 {
+		return 0, fmt.Errorf("time increase cannot be negative, got %s", d)
+	}
+
+	n.mtx.Lock()
+	n.offset += d
+	if n.nextTime != nil {
+		nextTime := n.nextTime.Add(d)
+		n.nextTime = &nextTime
+	}
+	offset := n.offset
+	n.mtx.Unlock()
+
+	if err := n.Mine(ctx); err != nil {
+		return 0, err
+	}
+
+	return offset, nil
+}
+
+// SetNextBlockTimestamp implements evm.DevNode. The time of the next block is already set by the
+// precommit of the last block, so a block is produced for the timestamp to apply to the next block.
+func (n *devNode) SetNextBlockTimestamp(ctx context.Context, timestamp time.Time) error {
+	n.mtx.Lock()
+	if !timestamp.After(n.pendingTime) {
+		n.mtx.Unlock()
+		return fmt.Errorf(
+			"timestamp %d must be greater than the timestamp %d of the pending block",
+			timestamp.Unix(), n.pendingTime.Unix(),
+		)
+	}
+
+	timestamp = timestamp.UTC()
+	n.nextTime = &timestamp
+	n.mtx.Unlock()
+
+	return n.Mine(ctx)
+}
+
+// Snapshot implements evm.DevNode.
+func (n *devNode) Snapshot() uint64 {
+	n.resetMtx.Lock()
+	defer n.resetMtx.Unlock()
+
+	height := n.chain.Height()
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	n.lastSnapshot++
+	n.snapshots[n.lastSnapshot] = devSnapshot{
+		height: height,
+		offset: n.offset,
+	}
+
+	n.logger.Debug("chain snapshot", "id", n.lastSnapshot, "height", height)
+	return n.lastSnapshot
+}
+
+// Revert implements evm.DevNode. The snapshot and the snapshots taken after it are deleted. The
+// transactions of the mempool and the pending state writes are dropped, and no block is produced.
+func (n *devNode) Revert(_ context.Context, id uint64) (bool, error) {
+	n.resetMtx.Lock()
+	defer n.resetMtx.Unlock()
+
+	select {
+	case <-n.quit:
+		return false, errDevNodeStopped
+	default:
+	}
+
+	n.mtx.Lock()
+	snapshot, ok := n.snapshots[id]
+	if ok {
+		for snapshotID := range n.snapshots {
+			if snapshotID >= id {
+				delete(n.snapshots, snapshotID)
+			}
+		}
+
+		// the time of the blocks mined after the revert continues from the snapshot
+		n.offset = snapshot.offset
+		n.nextTime = nil
+	}
+	n.mtx.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	if snapshot.height == n.chain.Height() {
+		n.chain.Discard()
+		return true, nil
+	}
+
+	if err := n.restart(snapshot.height); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// restart stops the chain, rolls it back to the given height and starts it again.
+// NOTE: the reset mutex must be locked.
+func (n *devNode) restart(height int64) error {
+	if err := n.chain.Stop(); err != nil {
+		return err
+	}
+
+	if err := n.chain.Rollback(height); err != nil {
+		return err
+	}
+
+	for _, fn := range n.onRestart {
+		fn()
+	}
+
+	return nil
+}
+
+// devFullNodePV is the private validator of the Tendermint node of a development chain. Its key isn't
+// the key of a validator, so that the node runs as a full node whose blocks are produced by the dev
+// node.
+type devFullNodePV struct {
+	pubKey crypto.PubKey
+}
+
+var _ tmtypes.PrivValidator = devFullNodePV{}
+
+func newDevFullNodePV() devFullNodePV {
+	return devFullNodePV{
+		pubKey: ed25519.GenPrivKey().PubKey(),
+	}
+}
+
+// GetPubKey implements tmtypes.PrivValidator.
+func (pv devFullNodePV) GetPubKey() (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// SignVote implements tmtypes.PrivValidator.
+func (devFullNodePV) SignVote(string, *tmproto.Vote) error {
+	return errors.New("the node of a development chain doesn't vote")
+}
+
+// SignProposal implements tmtypes.PrivValidator.
+func (devFullNodePV) SignProposal(string, *tmproto.Proposal) error {
+	return errors.New("the node of a development chain doesn't propose blocks")
+}
@@ -0,0 +1,308 @@
+package server
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmconfig "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	pvm "github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/simapp/params"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/app"
+	ethermintclient "github.com/Electronic-Signatures-Industries/ancon-evm/client"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/hd"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+	srvflags "github.com/Electronic-Signatures-Industries/ancon-evm/server/flags"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// appOptions are the options of the application of the test chain.
+type appOptions map[string]interface{}
+
+func (o appOptions) Get(key string) interface{} {
+	return o[key]
+}
+
+// devTestChain is a development chain whose blocks are produced by the dev node under test.
+type devTestChain struct {
+	node    *devNode
+	chain   *devChain
+	app     *app.EthermintApp
+	account ethermintclient.DevAccount
+	chainID *big.Int
+	encCfg  params.EncodingConfig
+}
+
+// newDevTestChain starts a development chain with a prefunded development account, whose blocks are
+// produced on demand.
+func newDevTestChain(t *testing.T) *devTestChain {
+	encCfg := encoding.MakeConfig(app.ModuleBasics)
+	clientCtx := client.Context{}.
+		WithCodec(encCfg.Marshaler).
+		WithInterfaceRegistry(encCfg.InterfaceRegistry).
+		WithTxConfig(encCfg.TxConfig).
+		WithLegacyAmino(encCfg.Amino).
+		WithAccountRetriever(authtypes.AccountRetriever{})
+
+	accounts, err := ethermintclient.DevAccounts(1)
+	require.NoError(t, err)
+
+	balance := sdk.NewCoin(ethermint.AttoPhoton, sdk.TokensFromConsensusPower(100, ethermint.PowerReduction))
+	prefunded := []banktypes.Balance{{
+		Address: sdk.AccAddress(accounts[0].Address.Bytes()).String(),
+		Coins:   sdk.NewCoins(balance),
+	}}
+
+	dataDir := t.TempDir()
+	tmCfg := tmconfig.DefaultConfig()
+	tmCfg.Consensus.TimeoutCommit = 0
+	tmCfg.Consensus.SkipTimeoutCommit = true
+	tmCfg.FastSyncMode = false
+	tmCfg.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	tmCfg.P2P.PexReactor = false
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err = ethermintclient.InitTestnet(
+		clientCtx, cmd, tmCfg, app.ModuleBasics, banktypes.GenesisBalancesIterator{}, dataDir, devChainID,
+		balance.Denom, "0"+balance.Denom, devNodeDirPrefix, devNodeDaemonHome, keyring.BackendTest,
+		string(hd.EthSecp256k1Type), []string{"127.0.0.1"}, 1, prefunded,
+	)
+	require.NoError(t, err)
+
+	nodeHome := filepath.Join(dataDir, devNodeDirPrefix+"0", devNodeDaemonHome)
+	tmCfg.SetRoot(nodeHome)
+	tmCfg.RPC.ListenAddress = ""
+	tmCfg.Instrumentation.Prometheus = false
+
+	appDB := dbm.NewMemDB()
+	ethermintApp := app.NewEthermintApp(
+		log.NewNopLogger(), appDB, nil, true, map[int64]bool{}, nodeHome, 0, encCfg,
+		appOptions{srvflags.Dev: true},
+	)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(tmCfg.NodeKeyFile())
+	require.NoError(t, err)
+
+	filePV := pvm.LoadOrGenFilePV(tmCfg.PrivValidatorKeyFile(), tmCfg.PrivValidatorStateFile())
+	dbProvider := newDevDBProvider().DB
+	fullNodePV := newDevFullNodePV()
+
+	newNode := func() (*node.Node, error) {
+		return node.NewNode(
+			tmCfg, fullNodePV, nodeKey, proxy.NewLocalClientCreator(ethermintApp),
+			node.DefaultGenesisDocProviderFunc(tmCfg), dbProvider,
+			node.DefaultMetricsProvider(tmCfg.Instrumentation), log.NewNopLogger(),
+		)
+	}
+
+	tmNode, err := newNode()
+	require.NoError(t, err)
+
+	chain := &devChain{
+		cfg:     tmCfg,
+		logger:  log.NewNopLogger(),
+		app:     ethermintApp,
+		appDB:   appDB,
+		pv:      filePV,
+		dbs:     dbProvider,
+		newNode: newNode,
+		node:    tmNode,
+	}
+
+	devNode := newDevNode(filePV, log.NewNopLogger(), 0)
+	devNode.SetChain(chain)
+
+	require.NoError(t, tmNode.Start())
+	devNode.Start()
+
+	t.Cleanup(func() {
+		devNode.Stop()
+		_ = chain.Stop()
+	})
+
+	tc := &devTestChain{
+		node:    devNode,
+		chain:   chain,
+		app:     ethermintApp,
+		account: accounts[0],
+		chainID: big.NewInt(9000),
+		encCfg:  encCfg,
+	}
+
+	// the first block is produced on start
+	tc.waitForHeight(t, 1)
+	return tc
+}
+
+// waitForHeight waits until the chain commits the block of the given height, which is saved by the
+// block store before it's applied.
+func (tc *devTestChain) waitForHeight(t *testing.T, height int64) {
+	require.Eventually(t, func() bool {
+		return tc.chain.Node().ConsensusState().GetState().LastBlockHeight >= height
+	}, 10*time.Second, devPollInterval)
+	require.Equal(t, height, tc.chain.Height())
+}
+
+// signedTx returns an encoded ethereum transfer of the development account with the given nonce.
+func (tc *devTestChain) signedTx(t *testing.T, nonce uint64) []byte {
+	to := common.BigToAddress(big.NewInt(1))
+	msg := evmtypes.NewTx(tc.chainID, nonce, &to, big.NewInt(1), 21000, big.NewInt(1e10), nil, nil)
+	msg.From = tc.account.Address.Hex()
+	require.NoError(t, msg.Sign(ethtypes.LatestSignerForChainID(tc.chainID), tests.NewSigner(tc.account.PrivKey)))
+
+	builder, ok := tc.encCfg.TxConfig.NewTxBuilder().(authtx.ExtensionOptionsTxBuilder)
+	require.True(t, ok)
+
+	option, err := codectypes.NewAnyWithValue(&evmtypes.ExtensionOptionsEthereumTx{})
+	require.NoError(t, err)
+
+	builder.SetExtensionOptions(option)
+	require.NoError(t, builder.SetMsgs(msg))
+
+	txData, err := evmtypes.UnpackTxData(msg.Data)
+	require.NoError(t, err)
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(ethermint.AttoPhoton, sdk.NewIntFromBigInt(txData.Fee()))))
+	builder.SetGasLimit(msg.GetGas())
+
+	bz, err := tc.encCfg.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+	return bz
+}
+
+// checkTx adds a transaction to the mempool of the chain and returns the code of its check.
+func (tc *devTestChain) checkTx(t *testing.T, tx []byte) uint32 {
+	code := make(chan uint32, 1)
+	err := tc.chain.Mempool().CheckTx(tx, func(res *abci.Response) {
+		code <- res.GetCheckTx().Code
+	}, mempool.TxInfo{})
+	require.NoError(t, err)
+	return <-code
+}
+
+// balance returns the committed balance of the development account.
+func (tc *devTestChain) balance() sdk.Int {
+	ctx := tc.app.NewUncachedContext(false, tmproto.Header{})
+	return tc.app.BankKeeper.GetBalance(ctx, sdk.AccAddress(tc.account.Address.Bytes()), ethermint.AttoPhoton).Amount
+}
+
+func TestDevNodeMine(t *testing.T) {
+	tc := newDevTestChain(t)
+
+	// no block is produced without transactions
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(1), tc.chain.Height())
+
+	require.NoError(t, tc.node.Mine(context.Background()))
+	require.Equal(t, int64(2), tc.chain.Height())
+
+	// the transactions are kept in the mempool until a block is mined
+	tc.node.SetAutomine(false)
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tc.signedTx(t, 0)))
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(2), tc.chain.Height())
+	require.Equal(t, 1, tc.chain.Mempool().Size())
+
+	require.NoError(t, tc.node.Mine(context.Background()))
+	require.Equal(t, int64(3), tc.chain.Height())
+	require.Equal(t, 0, tc.chain.Mempool().Size())
+	require.Len(t, tc.chain.Node().BlockStore().LoadBlock(3).Txs, 1)
+
+	// a block is produced for each transaction received
+	tc.node.SetAutomine(true)
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tc.signedTx(t, 1)))
+	tc.waitForHeight(t, 4)
+	require.Len(t, tc.chain.Node().BlockStore().LoadBlock(4).Txs, 1)
+}
+
+func TestDevNodeRevert(t *testing.T) {
+	tc := newDevTestChain(t)
+
+	id := tc.node.Snapshot()
+	balance := tc.balance()
+
+	tx := tc.signedTx(t, 0)
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tx))
+	tc.waitForHeight(t, 2)
+	require.NoError(t, tc.node.Mine(context.Background()))
+	require.Equal(t, int64(3), tc.chain.Height())
+	require.True(t, tc.balance().LT(balance))
+
+	reverted, err := tc.node.Revert(context.Background(), id)
+	require.NoError(t, err)
+	require.True(t, reverted)
+
+	// the chain is back at the snapshot, without producing a block
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(1), tc.chain.Height())
+	require.Equal(t, balance, tc.balance())
+
+	// the snapshot is deleted once reverted to
+	reverted, err = tc.node.Revert(context.Background(), id)
+	require.NoError(t, err)
+	require.False(t, reverted)
+
+	// the transaction reverted is accepted again
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tx))
+	tc.waitForHeight(t, 2)
+	require.True(t, tc.balance().LT(balance))
+}
+
+func TestDevNodeRevertPending(t *testing.T) {
+	tc := newDevTestChain(t)
+	tc.node.SetAutomine(false)
+
+	id := tc.node.Snapshot()
+	balance := tc.balance()
+
+	// the pending transactions and state writes are dropped, since no block is rolled back
+	tx := tc.signedTx(t, 0)
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tx))
+	tc.app.SetBalance(tc.account.Address, big.NewInt(1))
+
+	reverted, err := tc.node.Revert(context.Background(), id)
+	require.NoError(t, err)
+	require.True(t, reverted)
+	require.Equal(t, int64(1), tc.chain.Height())
+	require.Equal(t, 0, tc.chain.Mempool().Size())
+
+	require.NoError(t, tc.node.Mine(context.Background()))
+	require.Equal(t, int64(2), tc.chain.Height())
+	require.Empty(t, tc.chain.Node().BlockStore().LoadBlock(2).Txs)
+	require.Equal(t, balance, tc.balance())
+
+	// the state of the transaction dropped is discarded, so the same nonce is accepted again
+	require.Equal(t, abci.CodeTypeOK, tc.checkTx(t, tx))
+	require.NoError(t, tc.node.Mine(context.Background()))
+	require.Len(t, tc.chain.Node().BlockStore().LoadBlock(3).Txs, 1)
+}
@@ -30,7 +30,21 @@ const (
 	JSONRPCAPI     = "json-rpc.api"
 	JSONRPCAddress = "json-rpc.address"
 	JSONWsAddress  = "json-rpc.ws-address"
+	JSONRPCIPCPath = "json-rpc.ipc-path"
 	JSONRPCGasCap  = "json-rpc.gas-cap"
+
+	JSONRPCCacheSize    = "json-rpc.cache-size"
+	JSONRPCCacheMethods = "json-rpc.cache-methods"
+
+	JSONRPCArchiveGRPCAddress = "json-rpc.archive-grpc-address"
+	JSONRPCExternalSigner     = "json-rpc.external-signer"
+)
+
+// Dev mode flags
+const (
+	// Dev runs the node as the single validator of a development chain, whose block production, block
+	// time and state are controlled through the dev-only JSON-RPC namespaces.
+	Dev = "dev"
 )
 
 // EVM flags
@@ -0,0 +1,31 @@
+//go:build !windows
+// +build !windows
+
+package server
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// ipcListen creates the Unix socket of the IPC server, which is only accessible by its owner.
+func ipcListen(endpoint string) (net.Listener, error) {
+	// ensure the directory exists and remove the socket left over by a previous run
+	if err := os.MkdirAll(filepath.Dir(endpoint), 0o751); err != nil {
+		return nil, err
+	}
+	_ = os.Remove(endpoint)
+
+	l, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(endpoint, 0o600); err != nil {
+		l.Close() // nolint: errcheck
+		return nil, err
+	}
+
+	return l, nil
+}
@@ -0,0 +1,15 @@
+//go:build windows
+// +build windows
+
+package server
+
+import (
+	"net"
+
+	"gopkg.in/natefinch/npipe.v2"
+)
+
+// ipcListen creates the named pipe of the IPC server.
+func ipcListen(endpoint string) (net.Listener, error) {
+	return npipe.Listen(endpoint)
+}
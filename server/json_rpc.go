@@ -1,13 +1,19 @@
 package server
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/eth/filters"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/hardhat"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/server/types"
@@ -16,14 +22,22 @@ import (
 	"github.com/Electronic-Signatures-Industries/ancon-evm/server/config"
 )
 
-// StartJSONRPC starts the JSON-RPC server
-func StartJSONRPC(ctx *server.Context, clientCtx client.Context, tmRPCAddr, tmEndpoint string, config config.Config) (*http.Server, chan struct{}, error) {
+// StartJSONRPC starts the JSON-RPC server. The dev-only namespaces are served if devRPCNode is not nil.
+func StartJSONRPC(
+	ctx *server.Context, clientCtx client.Context, tmRPCAddr, tmEndpoint string, devRPCNode hardhat.DevNode, config config.Config,
+) (*http.Server, chan struct{}, error) {
 	tmWsClient := ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
 
 	rpcServer := ethrpc.NewServer()
 
 	rpcAPIArr := config.JSONRPC.API
-	apis := rpc.GetRPCAPIs(ctx, clientCtx, tmWsClient, rpcAPIArr)
+	events := filters.NewEventSystem(ctx.Logger, tmWsClient)
+	apis := rpc.GetRPCAPIs(ctx, clientCtx, events, devRPCNode, rpcAPIArr)
+
+	if node, ok := devRPCNode.(*devNode); ok {
+		// the subscriptions to the stopped node are cancelled when the dev node reverts the chain
+		node.OnRestart(events.Resubscribe)
+	}
 
 	for _, api := range apis {
 		if err := rpcServer.RegisterName(api.Namespace, api.Service); err != nil {
@@ -36,8 +50,28 @@ func StartJSONRPC(ctx *server.Context, clientCtx client.Context, tmRPCAddr, tmEn
 		}
 	}
 
+	var (
+		handler http.Handler = rpcServer
+		cache   *rpc.ResponseCache
+	)
+	if config.JSONRPC.CacheSize > 0 {
+		var err error
+		cache, err = rpc.NewResponseCache(ctx.Logger, events, config.JSONRPC.CacheSize, config.JSONRPC.CacheMethods)
+		if err != nil {
+			ctx.Logger.Error("failed to create JSON-RPC response cache", "error", err.Error())
+			return nil, nil, err
+		}
+
+		handler = cache.Handler(handler)
+
+		if node, ok := devRPCNode.(*devNode); ok {
+			// the cached responses may describe the blocks rolled back by the dev node
+			node.OnRestart(cache.Reset)
+		}
+	}
+
 	r := mux.NewRouter()
-	r.HandleFunc("/", rpcServer.ServeHTTP).Methods("POST")
+	r.Handle("/", rpc.MetricsHandler(handler)).Methods("POST")
 
 	handlerWithCors := cors.Default()
 	if config.API.EnableUnsafeCORS {
@@ -73,9 +107,70 @@ func StartJSONRPC(ctx *server.Context, clientCtx client.Context, tmRPCAddr, tmEn
 
 	ctx.Logger.Info("Starting JSON WebSocket server", "address", config.JSONRPC.WsAddress)
 
-	// allocate separate WS connection to Tendermint
-	tmWsClient = ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
-	wsSrv := rpc.NewWebsocketsServer(ctx.Logger, tmWsClient, config)
+	wsSrv := rpc.NewWebsocketsServer(ctx.Logger, rpcServer, config)
 	wsSrv.Start()
+
+	ipcListener, ipcServer, err := startIPC(ctx, config, apis)
+	if err != nil {
+		ctx.Logger.Error("failed to start JSON-RPC IPC server", "error", err.Error())
+		wsSrv.Shutdown(context.Background()) // nolint: errcheck
+		httpSrv.Close()                      // nolint: errcheck
+		return nil, nil, err
+	}
+
+	// stop the WebSocket and IPC servers and close the active connections and subscriptions along with
+	// the HTTP server
+	httpSrv.RegisterOnShutdown(func() {
+		shutdownCtx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		if err := wsSrv.Shutdown(shutdownCtx); err != nil {
+			ctx.Logger.Error("WebSocket server shutdown produced a warning", "error", err.Error())
+		}
+
+		if ipcListener != nil {
+			ipcListener.Close() // nolint: errcheck
+			ipcServer.Stop()
+		}
+
+		if cache != nil {
+			cache.Stop()
+		}
+
+		rpcServer.Stop()
+	})
+
 	return httpSrv, httpSrvDone, nil
 }
+
+// startIPC starts the JSON-RPC IPC server if an IPC path is configured. The returned listener and
+// server are nil otherwise.
+func startIPC(ctx *server.Context, config config.Config, apis []ethrpc.API) (net.Listener, *ethrpc.Server, error) {
+	if config.JSONRPC.IPCPath == "" {
+		return nil, nil, nil
+	}
+
+	ipcPath := config.JSONRPC.IPCPath
+	if !filepath.IsAbs(ipcPath) && runtime.GOOS != "windows" {
+		ipcPath = filepath.Join(ctx.Config.RootDir, ipcPath)
+	}
+
+	ctx.Logger.Info("Starting JSON-RPC IPC server", "path", ipcPath)
+
+	// the socket is only accessible by the node operator, so the private namespaces are served as well
+	ipcServer := ethrpc.NewServer()
+	for _, api := range apis {
+		if err := ipcServer.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	listener, err := ipcListen(ipcPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go rpc.ServeListener(ipcServer, listener) // nolint: errcheck
+
+	return listener, ipcServer, nil
+}
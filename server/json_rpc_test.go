@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/server/config"
+)
+
+type echoService struct{}
+
+func (echoService) Echo(s string) string {
+	return s
+}
+
+func TestStartIPC(t *testing.T) {
+	ctx := server.NewDefaultContext()
+	ctx.Config.RootDir = t.TempDir()
+
+	cfg := config.DefaultConfig()
+	apis := []ethrpc.API{
+		{Namespace: "personal", Version: "1.0", Service: echoService{}, Public: false},
+	}
+
+	// the IPC server is disabled by default
+	listener, ipcServer, err := startIPC(ctx, *cfg, apis)
+	require.NoError(t, err)
+	require.Nil(t, listener)
+	require.Nil(t, ipcServer)
+
+	// relative paths are resolved against the home directory
+	cfg.JSONRPC.IPCPath = filepath.Join("data", "ethermint.ipc")
+	listener, ipcServer, err = startIPC(ctx, *cfg, apis)
+	require.NoError(t, err)
+	defer ipcServer.Stop()
+	defer listener.Close()
+
+	endpoint := filepath.Join(ctx.Config.RootDir, "data", "ethermint.ipc")
+	info, err := os.Stat(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "the socket is only accessible by its owner")
+
+	client, err := ethrpc.DialIPC(context.Background(), endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// the private namespaces are served
+	var res string
+	require.NoError(t, client.Call(&res, "personal_echo", "hello"))
+	require.Equal(t, "hello", res)
+}
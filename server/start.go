@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/rpc/client/local"
+	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/server/rosetta"
@@ -41,6 +43,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	ethdebug "github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/debug"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/hardhat"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/log"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/server/config"
 	srvflags "github.com/Electronic-Signatures-Industries/ancon-evm/server/flags"
@@ -72,6 +75,12 @@ the halt-height or if the current block time is greater than or equal to the hal
 node will attempt to gracefully shutdown and the block will not be committed. In addition, the node
 will not be able to commit subsequent blocks.
 
+The '--dev' flag runs the node as the only validator of a development chain. The blocks are produced
+on demand and the chain can be snapshotted and reverted through the dev-only evm JSON-RPC namespace.
+The accounts can be impersonated and the state written through the dev-only hardhat and anvil
+namespaces. The dev-only namespaces must be enabled with '--json-rpc.api'. All the historic states are
+kept in dev mode, and the unsigned transactions of the impersonated accounts are accepted.
+
 For profiling and benchmarking purposes, CPU profiling can be enabled via the '--cpu-profile' flag
 which accepts a path for the resulting pprof file.
 `,
@@ -134,6 +143,8 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Uint(server.FlagInvCheckPeriod, 0, "Assert registered invariants every N blocks")
 	cmd.Flags().Uint64(server.FlagMinRetainBlocks, 0, "Minimum block height offset during ABCI commit to prune Tendermint blocks")
 
+	cmd.Flags().Bool(srvflags.Dev, false, "Run the only validator of a development chain, whose blocks are produced on demand and whose state can be reverted through the dev-only evm JSON-RPC namespace")
+
 	cmd.Flags().Bool(srvflags.GRPCEnable, true, "Define if the gRPC server should be enabled")
 	cmd.Flags().String(srvflags.GRPCAddress, serverconfig.DefaultGRPCAddress, "the gRPC server address to listen on")
 	cmd.Flags().Bool(srvflags.GRPCWebEnable, true, "Define if the gRPC-Web server should be enabled. (Note: gRPC must also be enabled.)")
@@ -143,7 +154,12 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().StringSlice(srvflags.JSONRPCAPI, config.GetDefaultAPINamespaces(), "Defines a list of JSON-RPC namespaces that should be enabled")
 	cmd.Flags().String(srvflags.JSONRPCAddress, config.DefaultJSONRPCAddress, "the JSON-RPC server address to listen on")
 	cmd.Flags().String(srvflags.JSONWsAddress, config.DefaultJSONRPCWsAddress, "the JSON-RPC WS server address to listen on")
+	cmd.Flags().String(srvflags.JSONRPCIPCPath, "", "the JSON-RPC IPC socket path to listen on, relative to the home directory (empty=disabled)")
 	cmd.Flags().Uint64(srvflags.JSONRPCGasCap, config.DefaultGasCap, "Sets a cap on gas that can be used in eth_call/estimateGas (0=infinite)")
+	cmd.Flags().Int(srvflags.JSONRPCCacheSize, config.DefaultResponseCacheSize, "Sets the number of eth namespace responses cached by the JSON-RPC server (0=disabled)")
+	cmd.Flags().StringSlice(srvflags.JSONRPCCacheMethods, config.GetDefaultCacheMethods(), "Defines the list of eth namespace methods whose responses are cached")
+	cmd.Flags().String(srvflags.JSONRPCArchiveGRPCAddress, "", "the gRPC address of an archive node serving the state queries at pruned heights (empty=disabled)")
+	cmd.Flags().String(srvflags.JSONRPCExternalSigner, "", "the IPC path or HTTP URL of a Clef-compatible external signer used to sign the JSON-RPC transactions and data instead of the keyring (empty=disabled)")
 
 	cmd.Flags().String(srvflags.EVMTracer, config.DefaultEVMTracer, "the EVM tracer type to collect execution traces from the EVM transaction execution (json|struct|access_list|markdown)")
 
@@ -228,6 +244,18 @@ func startInProcess(ctx *server.Context, clientCtx client.Context, appCreator ty
 		}
 	}
 
+	devMode := ctx.Viper.GetBool(srvflags.Dev)
+	if devMode {
+		logger.Info("starting node in dev mode")
+
+		// the chain is reverted to the heights kept by the multistore, without the inter-block cache
+		// of the stores loaded before the revert
+		ctx.Viper.Set(server.FlagPruning, storetypes.PruningOptionNothing)
+		ctx.Viper.Set(server.FlagInterBlockCache, false)
+		// the metrics can't be registered again by the node started after a revert
+		cfg.Instrumentation.Prometheus = false
+	}
+
 	traceWriterFile := ctx.Viper.GetString(srvflags.TraceStore)
 	db, err := openDB(home)
 	if err != nil {
@@ -268,27 +296,77 @@ func startInProcess(ctx *server.Context, clientCtx client.Context, appCreator ty
 		return err
 	}
 
-	genDocProvider := node.DefaultGenesisDocProviderFunc(cfg)
-	tmNode, err := node.NewNode(
-		cfg,
-		pvm.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile()),
-		nodeKey,
-		proxy.NewLocalClientCreator(app),
-		genDocProvider,
-		node.DefaultDBProvider,
-		node.DefaultMetricsProvider(cfg.Instrumentation),
-		ctx.Logger.With("server", "node"),
+	var (
+		filePV                              = pvm.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
+		privValidator tmtypes.PrivValidator = filePV
+		dbProvider    node.DBProvider       = node.DefaultDBProvider
+		devNode       *devNode
+		// devRPCNode is only set in dev mode, since a nil devNode isn't a nil interface
+		devRPCNode hardhat.DevNode
 	)
+
+	if devMode {
+		// the node runs as a full node, whose blocks are produced by the dev node with the validator key
+		devNode = newDevNode(filePV, logger, ctx.Viper.GetDuration(flagDevBlockTime))
+		privValidator, devRPCNode = newDevFullNodePV(), devNode
+		dbProvider = newDevDBProvider().DB
+	}
+
+	genDocProvider := node.DefaultGenesisDocProviderFunc(cfg)
+	newNode := func() (*node.Node, error) {
+		return node.NewNode(
+			cfg,
+			privValidator,
+			nodeKey,
+			proxy.NewLocalClientCreator(app),
+			genDocProvider,
+			dbProvider,
+			node.DefaultMetricsProvider(cfg.Instrumentation),
+			ctx.Logger.With("server", "node"),
+		)
+	}
+
+	tmNode, err := newNode()
 	if err != nil {
 		logger.Error("failed init node", "error", err.Error())
 		return err
 	}
 
+	var devChainNode *devChain
+	if devNode != nil {
+		devApp, ok := app.(devApplication)
+		if !ok {
+			return errors.New("dev mode requires an application exposing its commit multistore")
+		}
+
+		devChainNode = &devChain{
+			cfg:     cfg,
+			logger:  logger.With("module", "dev-chain"),
+			app:     devApp,
+			appDB:   db,
+			pv:      filePV,
+			dbs:     dbProvider,
+			newNode: newNode,
+			node:    tmNode,
+		}
+		devNode.SetChain(devChainNode)
+	}
+
 	if err := tmNode.Start(); err != nil {
 		logger.Error("failed start tendermint server", "error", err.Error())
 		return err
 	}
 
+	if devNode != nil {
+		validators := tmNode.ConsensusState().GetState().Validators
+		if validators.Size() != 1 || !validators.HasAddress(filePV.GetAddress()) {
+			_ = tmNode.Stop()
+			return errors.New("dev mode requires a chain whose single validator is the validator of the node")
+		}
+
+		devNode.Start()
+	}
+
 	// Add the tx service to the gRPC router. We only need to register this
 	// service if API or gRPC is enabled, and avoid doing so in the general
 	// case, because it spawns a new local tendermint RPC client.
@@ -397,13 +475,20 @@ func startInProcess(ctx *server.Context, clientCtx client.Context, appCreator ty
 
 		tmEndpoint := "/websocket"
 		tmRPCAddr := cfg.RPC.ListenAddress
-		httpSrv, httpSrvDone, err = StartJSONRPC(ctx, clientCtx, tmRPCAddr, tmEndpoint, config)
+		httpSrv, httpSrvDone, err = StartJSONRPC(ctx, clientCtx, tmRPCAddr, tmEndpoint, devRPCNode, config)
 		if err != nil {
 			return err
 		}
 	}
 
 	defer func() {
+		if devNode != nil {
+			// stop producing blocks
+			devNode.Stop()
+			// the node is replaced when the chain is reverted
+			tmNode = devChainNode.Node()
+		}
+
 		if tmNode.IsRunning() {
 			_ = tmNode.Stop()
 		}
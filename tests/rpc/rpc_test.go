@@ -582,6 +582,43 @@ func TestEth_GetTransactionReceipt_ContractDeployment(t *testing.T) {
 	require.NotNil(t, receipt["logs"])
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	hash, receipt := deployTestContract(t)
+
+	param := []string{receipt["blockHash"].(string)}
+	rpcRes := call(t, "eth_getBlockReceipts", param)
+	require.Nil(t, rpcRes.Error)
+
+	var receipts []map[string]interface{}
+	err := json.Unmarshal(rpcRes.Result, &receipts)
+	require.NoError(t, err)
+	require.NotEmpty(t, receipts)
+
+	found := false
+	for _, blockReceipt := range receipts {
+		if blockReceipt["transactionHash"].(string) != hash.String() {
+			continue
+		}
+
+		found = true
+		require.Equal(t, receipt["status"], blockReceipt["status"])
+		require.Equal(t, receipt["gasUsed"], blockReceipt["gasUsed"])
+		require.Equal(t, receipt["cumulativeGasUsed"], blockReceipt["cumulativeGasUsed"])
+		require.Equal(t, receipt["contractAddress"], blockReceipt["contractAddress"])
+		require.Len(t, blockReceipt["logs"], len(receipt["logs"].([]interface{})))
+	}
+	require.True(t, found)
+
+	// the receipts can also be queried by block number
+	rpcRes = call(t, "eth_getBlockReceipts", []string{receipt["blockNumber"].(string)})
+	require.Nil(t, rpcRes.Error)
+
+	var receiptsByNumber []map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &receiptsByNumber)
+	require.NoError(t, err)
+	require.Equal(t, receipts, receiptsByNumber)
+}
+
 func getTransactionReceipt(t *testing.T, hash hexutil.Bytes) map[string]interface{} {
 	param := []string{hash.String()}
 	rpcRes := call(t, "eth_getTransactionReceipt", param)
@@ -0,0 +1,44 @@
+// Package contracts provides the compiled smart contracts used by the tests and the simulation of
+// the EVM module.
+package contracts
+
+import (
+	// embed compiled smart contract
+	_ "embed"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CompiledContract contains the ABI and bytecode of a compiled smart contract.
+type CompiledContract struct {
+	ABI abi.ABI
+	Bin []byte
+}
+
+var (
+	//go:embed ERC20Contract.json
+	erc20JSON []byte
+
+	// ERC20Contract is the compiled test ERC20 contract, used by the tests and the simulation
+	// operations of the EVM module. Its constructor takes the initial account and balance.
+	ERC20Contract CompiledContract
+)
+
+func init() {
+	var tmp struct {
+		Abi string
+		Bin string
+	}
+
+	if err := json.Unmarshal(erc20JSON, &tmp); err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal([]byte(tmp.Abi), &ERC20Contract.ABI); err != nil {
+		panic(err)
+	}
+
+	ERC20Contract.Bin = common.FromHex(tmp.Bin)
+}
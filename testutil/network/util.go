@@ -31,6 +31,7 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/ethereum/rpc/namespaces/eth/filters"
 	ethsrv "github.com/Electronic-Signatures-Industries/ancon-evm/server"
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
 )
@@ -122,7 +123,8 @@ func startInProcess(cfg Config, val *Validator) error {
 		val.jsonRPC = jsonrpc.NewServer()
 
 		rpcAPIArr := val.AppConfig.JSONRPC.API
-		apis := rpc.GetRPCAPIs(val.Ctx, val.ClientCtx, tmWsClient, rpcAPIArr)
+		events := filters.NewEventSystem(val.Ctx.Logger, tmWsClient)
+		apis := rpc.GetRPCAPIs(val.Ctx, val.ClientCtx, events, nil, rpcAPIArr)
 
 		for _, api := range apis {
 			if err := val.jsonRPC.RegisterName(api.Namespace, api.Service); err != nil {
@@ -1,9 +1,27 @@
 package types
 
 import (
+	"fmt"
+
 	ethaccounts "github.com/ethereum/go-ethereum/accounts"
 )
 
+// HD path schemes used by the Ethereum wallets to derive the accounts of a mnemonic.
+const (
+	// HDPathSchemeBIP44 iterates over the address index of the BIP44 path: m/44'/60'/0'/0/x.
+	// It's used by MetaMask, geth and the keys add command.
+	HDPathSchemeBIP44 = "bip44"
+	// HDPathSchemeLedgerLive iterates over the account of the BIP44 path: m/44'/60'/x'/0/0.
+	// It's used by Ledger Live.
+	HDPathSchemeLedgerLive = "ledger-live"
+	// HDPathSchemeLegacy iterates over the last component of the legacy Ledger path: m/44'/60'/0'/x.
+	// It's used by MyEtherWallet and the legacy Ledger Chrome app.
+	HDPathSchemeLegacy = "legacy"
+)
+
+// HDPathSchemes defines the list of supported HD path schemes.
+var HDPathSchemes = []string{HDPathSchemeBIP44, HDPathSchemeLedgerLive, HDPathSchemeLegacy}
+
 var (
 	// Bip44CoinType satisfies EIP84. See https://github.com/ethereum/EIPs/issues/84 for more info.
 	Bip44CoinType uint32 = 60
@@ -30,3 +48,18 @@ func NewHDPathIterator(basePath string, ledgerIter bool) (HDPathIterator, error)
 
 	return ethaccounts.DefaultIterator(hdPath), nil
 }
+
+// NewHDPathSchemeIterator returns a function that iterates over the HD paths of the given scheme,
+// starting from the first account.
+func NewHDPathSchemeIterator(scheme string) (HDPathIterator, error) {
+	switch scheme {
+	case HDPathSchemeBIP44:
+		return ethaccounts.DefaultIterator(ethaccounts.DefaultBaseDerivationPath), nil
+	case HDPathSchemeLedgerLive:
+		return ethaccounts.LedgerLiveIterator(ethaccounts.DefaultBaseDerivationPath), nil
+	case HDPathSchemeLegacy:
+		return ethaccounts.DefaultIterator(ethaccounts.LegacyLedgerBaseDerivationPath), nil
+	default:
+		return nil, fmt.Errorf("invalid HD path scheme %s, available schemes: %v", scheme, HDPathSchemes)
+	}
+}
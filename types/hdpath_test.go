@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHDPathSchemeIterator(t *testing.T) {
+	testCases := []struct {
+		scheme   string
+		expPaths []string
+		expPass  bool
+	}{
+		{HDPathSchemeBIP44, []string{"m/44'/60'/0'/0/0", "m/44'/60'/0'/0/1", "m/44'/60'/0'/0/2"}, true},
+		{HDPathSchemeLedgerLive, []string{"m/44'/60'/0'/0/0", "m/44'/60'/1'/0/0", "m/44'/60'/2'/0/0"}, true},
+		{HDPathSchemeLegacy, []string{"m/44'/60'/0'/0", "m/44'/60'/0'/1", "m/44'/60'/0'/2"}, true},
+		{"invalid", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scheme, func(t *testing.T) {
+			iterator, err := NewHDPathSchemeIterator(tc.scheme)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, expPath := range tc.expPaths {
+				require.Equal(t, expPath, iterator().String())
+			}
+		})
+	}
+}
@@ -8,23 +8,29 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// BeginBlock sets the sdk Context and EIP155 chain id to the Keeper.
+// BeginBlock sets the sdk Context and EIP155 chain id to the Keeper and runs the begin block hooks.
 func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 	k.WithContext(ctx)
 	k.WithChainID(ctx)
+
+	k.BeginBlockEVM(ctx, req)
 }
 
-// EndBlock also retrieves the bloom filter value from the transient store and commits it to the
-// KVStore. The EVM end block logic doesn't update the validator set, thus it returns
-// an empty slice.
+// EndBlock runs the end block hooks, retrieves the bloom filter value from the transient store and commits it to the
+// KVStore, and updates the state trie when it is enabled. The EVM end block logic doesn't update
+// the validator set, thus it returns an empty slice.
 func (k *Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	infCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 	k.WithContext(infCtx)
 
+	k.EndBlockEVM(infCtx, req)
+
 	bloom := ethtypes.BytesToBloom(k.GetBlockBloomTransient().Bytes())
 	k.EmitBlockBloomEvent(infCtx, bloom)
 
+	k.UpdateStateTrie(infCtx)
+
 	k.WithContext(ctx)
 
 	return []abci.ValidatorUpdate{}
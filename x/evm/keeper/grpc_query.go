@@ -22,8 +22,11 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	ethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
@@ -228,11 +231,10 @@ func (k Keeper) EthCall(c context.Context, req *types.EthCallRequest) (*types.Ms
 	}
 
 	tracer := types.NewTracer(k.tracer, msg, ethCfg, k.Ctx().BlockHeight(), k.debug)
-	evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer)
+	evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer, statedb.New(&k))
 
 	// pass true means execute in query mode, which don't do actual gas refund.
 	res, err := k.ApplyMessage(evm, msg, ethCfg, true)
-	k.ctxStack.RevertAll()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -301,12 +303,9 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 		msg := args.ToMessage(req.GasCap)
 
 		tracer := types.NewTracer(k.tracer, msg, ethCfg, k.Ctx().BlockHeight(), k.debug)
-		evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer)
+		evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer, statedb.New(&k))
 		// pass true means execute in query mode, which don't do actual gas refund.
 		rsp, err := k.ApplyMessage(evm, msg, ethCfg, true)
-
-		k.ctxStack.RevertAll()
-
 		if err != nil {
 			if errors.Is(stacktrace.RootCause(err), core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -380,9 +379,15 @@ func (k *Keeper) traceTx(c context.Context, coinbase common.Address, signer etht
 		err    error
 	)
 
-	coreMessage, err := msg.AsMessage(signer)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	var coreMessage core.Message
+	if k.devMode && msg.IsUnsigned() {
+		// the sender of an unsigned transaction is the From field
+		coreMessage = types.NewUnsignedMessage(msg.AsTransaction(), common.HexToAddress(msg.From))
+	} else {
+		coreMessage, err = msg.AsMessage(signer)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
 
 	switch {
@@ -423,7 +428,7 @@ func (k *Keeper) traceTx(c context.Context, coinbase common.Address, signer etht
 		tracer = types.NewTracer(types.TracerStruct, coreMessage, ethCfg, ctx.BlockHeight(), true)
 	}
 
-	evm := k.NewEVM(coreMessage, ethCfg, params, coinbase, tracer)
+	evm := k.NewEVM(coreMessage, ethCfg, params, coinbase, tracer, statedb.New(k))
 
 	k.SetTxHashTransient(common.HexToHash(msg.Hash))
 	k.SetTxIndexTransient(txIndex)
@@ -456,3 +461,98 @@ func (k *Keeper) traceTx(c context.Context, coinbase common.Address, signer etht
 
 	return &result, nil
 }
+
+// StateRoot implements the Query/StateRoot gRPC method
+func (k Keeper) StateRoot(c context.Context, _ *types.QueryStateRootRequest) (*types.QueryStateRootResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+
+	root, found := k.GetStateRoot(ctx)
+	if !found {
+		return nil, status.Error(codes.NotFound, "state trie not found, check the enable state trie parameter")
+	}
+
+	return &types.QueryStateRootResponse{
+		Root: root.Hex(),
+	}, nil
+}
+
+// Proof implements the Query/Proof gRPC method
+func (k Keeper) Proof(c context.Context, req *types.QueryProofRequest) (*types.QueryProofResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if err := ethermint.ValidateAddress(req.Address); err != nil {
+		return nil, status.Error(
+			codes.InvalidArgument, err.Error(),
+		)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	root, found := k.GetStateRoot(ctx)
+	if !found {
+		return nil, status.Error(codes.NotFound, "state trie not found, check the enable state trie parameter")
+	}
+
+	addr := common.HexToAddress(req.Address)
+	db := k.stateTrieDatabase(ctx)
+
+	accountTrie, err := trie.NewSecure(root, db)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	account, err := getStateTrieAccount(accountTrie, addr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var accountProof stateTrieProof
+	if err := accountTrie.Prove(addr.Bytes(), 0, &accountProof); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	storageTrie, err := trie.NewSecure(account.Root, db)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	storageProofs := make([]types.StorageProof, len(req.StorageKeys))
+	for i, key := range req.StorageKeys {
+		hexKey := common.HexToHash(key)
+
+		enc, err := storageTrie.TryGet(hexKey.Bytes())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		var value []byte
+		if len(enc) > 0 {
+			if _, value, _, err = rlp.Split(enc); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		var storageProof stateTrieProof
+		if err := storageTrie.Prove(hexKey.Bytes(), 0, &storageProof); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		storageProofs[i] = types.StorageProof{
+			Key:   key,
+			Value: common.BytesToHash(value).Hex(),
+			Proof: storageProof,
+		}
+	}
+
+	return &types.QueryProofResponse{
+		StateRoot:     root.Hex(),
+		AccountProof:  accountProof,
+		Balance:       account.Balance.String(),
+		CodeHash:      common.BytesToHash(account.CodeHash).Hex(),
+		Nonce:         account.Nonce,
+		StorageHash:   account.Root.Hex(),
+		StorageProofs: storageProofs,
+	}, nil
+}
@@ -1,16 +1,22 @@
 package keeper
 
 import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 var _ types.EvmHooks = MultiEvmHooks{}
 
-// MultiEvmHooks combine multiple evm hooks, all hook functions are run in array sequence
+// MultiEvmHooks combine multiple evm hooks, all hook functions are run in array sequence. The failure
+// policy of each hook is applied individually: a failed hook with the HookFailureLog policy doesn't
+// prevent the next hooks from running.
 type MultiEvmHooks []types.EvmHooks
 
 // NewMultiEvmHooks combine multiple evm hooks
@@ -18,12 +24,94 @@ func NewMultiEvmHooks(hooks ...types.EvmHooks) MultiEvmHooks {
 	return hooks
 }
 
+// PreTxProcessing delegate the call to underlying hooks
+func (mh MultiEvmHooks) PreTxProcessing(ctx sdk.Context, msg core.Message) error {
+	for i := range mh {
+		hook := mh[i]
+		if err := runTxHook(ctx, hook, "pre tx processing", func(ctx sdk.Context) error {
+			return hook.PreTxProcessing(ctx, msg)
+		}); err != nil {
+			return sdkerrors.Wrapf(err, "EVM hook %T failed", hook)
+		}
+	}
+	return nil
+}
+
 // PostTxProcessing delegate the call to underlying hooks
-func (mh MultiEvmHooks) PostTxProcessing(ctx sdk.Context, txHash common.Hash, logs []*ethtypes.Log) error {
+func (mh MultiEvmHooks) PostTxProcessing(ctx sdk.Context, msg core.Message, receipt *ethtypes.Receipt) error {
 	for i := range mh {
-		if err := mh[i].PostTxProcessing(ctx, txHash, logs); err != nil {
-			return sdkerrors.Wrapf(err, "EVM hook %T failed", mh[i])
+		hook := mh[i]
+		if err := runTxHook(ctx, hook, "post tx processing", func(ctx sdk.Context) error {
+			return hook.PostTxProcessing(ctx, msg, receipt)
+		}); err != nil {
+			return sdkerrors.Wrapf(err, "EVM hook %T failed", hook)
 		}
 	}
 	return nil
 }
+
+// BeginBlockEVM delegate the call to underlying hooks
+func (mh MultiEvmHooks) BeginBlockEVM(ctx sdk.Context, req abci.RequestBeginBlock) error {
+	for i := range mh {
+		hook := mh[i]
+		runBlockHook(ctx, hook, "begin block", func(ctx sdk.Context) error {
+			return hook.BeginBlockEVM(ctx, req)
+		})
+	}
+	return nil
+}
+
+// EndBlockEVM delegate the call to underlying hooks
+func (mh MultiEvmHooks) EndBlockEVM(ctx sdk.Context, req abci.RequestEndBlock) error {
+	for i := range mh {
+		hook := mh[i]
+		runBlockHook(ctx, hook, "end block", func(ctx sdk.Context) error {
+			return hook.EndBlockEVM(ctx, req)
+		})
+	}
+	return nil
+}
+
+// FailurePolicy returns HookFailureRevert, as the errors of the underlying hooks are only returned
+// when their own policy is to revert the transaction.
+func (mh MultiEvmHooks) FailurePolicy() types.HookFailurePolicy {
+	return types.HookFailureRevert
+}
+
+// runHook runs the hook function on a branch of the context, which is written along with its events
+// if the hook succeeds and discarded otherwise.
+func runHook(ctx sdk.Context, fn func(ctx sdk.Context) error) error {
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+
+	if err := fn(cacheCtx); err != nil {
+		return err
+	}
+
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return nil
+}
+
+// runTxHook runs a transaction hook function and returns its error if the failure policy of the hook
+// is to revert the transaction. Otherwise the error is logged.
+func runTxHook(ctx sdk.Context, hook types.EvmHooks, name string, fn func(ctx sdk.Context) error) error {
+	err := runHook(ctx, fn)
+	if err == nil || hook.FailurePolicy() == types.HookFailureRevert {
+		return err
+	}
+
+	ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName)).Error(
+		"evm hook failed", "hook", fmt.Sprintf("%T", hook), "stage", name, "error", err.Error(),
+	)
+	return nil
+}
+
+// runBlockHook runs a block hook function and logs its error.
+func runBlockHook(ctx sdk.Context, hook types.EvmHooks, name string, fn func(ctx sdk.Context) error) {
+	if err := runHook(ctx, fn); err != nil {
+		ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName)).Error(
+			"evm hook failed", "hook", fmt.Sprintf("%T", hook), "stage", name, "error", err.Error(),
+		)
+	}
+}
@@ -1,76 +1,264 @@
 package keeper_test
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
-// LogRecordHook records all the logs
-type LogRecordHook struct {
-	Logs []*ethtypes.Log
+// hookStoreKey is the key written to the evm store by the test hooks
+var hookStoreKey = []byte("hook")
+
+// TestHook records the calls and can be set to fail at any stage. Before failing, it writes to the
+// store to check that the changes of failed hooks are discarded.
+type TestHook struct {
+	policy types.HookFailurePolicy
+	fail   map[string]bool
+
+	Msg      core.Message
+	Receipt  *ethtypes.Receipt
+	Calls    []string
+	storeKey sdk.StoreKey
+}
+
+func (suite *KeeperTestSuite) newTestHook(policy types.HookFailurePolicy, failStages ...string) *TestHook {
+	hook := &TestHook{
+		policy:   policy,
+		fail:     make(map[string]bool),
+		storeKey: suite.app.GetKey(types.StoreKey),
+	}
+	for _, stage := range failStages {
+		hook.fail[stage] = true
+	}
+	return hook
 }
 
-func (dh *LogRecordHook) PostTxProcessing(ctx sdk.Context, txHash common.Hash, logs []*ethtypes.Log) error {
-	dh.Logs = logs
+func (h *TestHook) run(ctx sdk.Context, stage string) error {
+	h.Calls = append(h.Calls, stage)
+	ctx.KVStore(h.storeKey).Set(hookStoreKey, []byte(stage))
+
+	if h.fail[stage] {
+		return errors.New(stage + " failed")
+	}
 	return nil
 }
 
-// FailureHook always fail
-type FailureHook struct{}
+func (h *TestHook) PreTxProcessing(ctx sdk.Context, msg core.Message) error {
+	h.Msg = msg
+	return h.run(ctx, "pre")
+}
+
+func (h *TestHook) PostTxProcessing(ctx sdk.Context, msg core.Message, receipt *ethtypes.Receipt) error {
+	h.Receipt = receipt
+	return h.run(ctx, "post")
+}
+
+func (h *TestHook) BeginBlockEVM(ctx sdk.Context, req abci.RequestBeginBlock) error {
+	return h.run(ctx, "begin")
+}
+
+func (h *TestHook) EndBlockEVM(ctx sdk.Context, req abci.RequestEndBlock) error {
+	return h.run(ctx, "end")
+}
 
-func (dh FailureHook) PostTxProcessing(ctx sdk.Context, txHash common.Hash, logs []*ethtypes.Log) error {
-	return errors.New("post tx processing failed")
+func (h *TestHook) FailurePolicy() types.HookFailurePolicy {
+	return h.policy
+}
+
+// transferTokenTx sends an ERC20 token transfer transaction from the suite address.
+func (suite *KeeperTestSuite) transferTokenTx(contract, to common.Address, amount *big.Int) (*types.MsgEthereumTx, *types.MsgEthereumTxResponse, error) {
+	chainID := suite.app.EvmKeeper.ChainID()
+
+	data, err := ContractABI.Pack("transfer", to, amount)
+	suite.Require().NoError(err)
+
+	nonce := suite.app.EvmKeeper.GetNonce(suite.address)
+	tx := types.NewTx(chainID, nonce, &contract, nil, 100000, nil, data, nil)
+	tx.From = suite.address.Hex()
+	suite.Require().NoError(tx.Sign(ethtypes.LatestSignerForChainID(chainID), suite.signer))
+
+	rsp, err := suite.app.EvmKeeper.EthereumTx(sdk.WrapSDKContext(suite.ctx), tx)
+	return tx, rsp, err
+}
+
+func (suite *KeeperTestSuite) tokenBalance(contract, account common.Address) *big.Int {
+	data, err := ContractABI.Pack("balanceOf", account)
+	suite.Require().NoError(err)
+
+	args, err := json.Marshal(&types.CallArgs{To: &contract, Data: (*hexutil.Bytes)(&data)})
+	suite.Require().NoError(err)
+
+	res, err := suite.queryClient.EthCall(sdk.WrapSDKContext(suite.ctx), &types.EthCallRequest{Args: args, GasCap: 25_000_000})
+	suite.Require().NoError(err)
+
+	balance, err := ContractABI.Unpack("balanceOf", res.Ret)
+	suite.Require().NoError(err)
+	return balance[0].(*big.Int)
 }
 
 func (suite *KeeperTestSuite) TestEvmHooks() {
+	recipient := common.BigToAddress(big.NewInt(1))
+	amount := big.NewInt(100)
+
 	testCases := []struct {
-		msg       string
-		setupHook func() types.EvmHooks
-		expFunc   func(hook types.EvmHooks, result error)
+		msg        string
+		setupHooks func() []*TestHook
+		expPass    bool
+		expFunc    func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool)
 	}{
 		{
-			"log collect hook",
-			func() types.EvmHooks {
-				return &LogRecordHook{}
+			"hook receives the message and receipt",
+			func() []*TestHook {
+				return []*TestHook{suite.newTestHook(types.HookFailureRevert)}
 			},
-			func(hook types.EvmHooks, result error) {
-				suite.Require().NoError(result)
-				suite.Require().Equal(1, len((hook.(*LogRecordHook).Logs)))
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Empty(res.VmError)
+				suite.Require().True(transferred)
+				suite.Require().Equal([]string{"pre", "post"}, hooks[0].Calls)
+				suite.Require().Equal(suite.address, hooks[0].Msg.From())
+
+				receipt := hooks[0].Receipt
+				suite.Require().Equal(ethtypes.ReceiptStatusSuccessful, receipt.Status)
+				suite.Require().Equal(res.GasUsed, receipt.GasUsed)
+				suite.Require().Equal(common.HexToHash(res.Hash), receipt.TxHash)
+				suite.Require().Len(receipt.Logs, 1)
+				suite.Require().Equal([]byte("post"), suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)).Get(hookStoreKey))
 			},
 		},
 		{
-			"always fail hook",
-			func() types.EvmHooks {
-				return &FailureHook{}
+			"failed post processing reverts the tx",
+			func() []*TestHook {
+				return []*TestHook{suite.newTestHook(types.HookFailureRevert, "post")}
 			},
-			func(hook types.EvmHooks, result error) {
-				suite.Require().Error(result)
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Equal(types.ErrPostTxProcessing.Error(), res.VmError)
+				suite.Require().False(transferred)
+			},
+		},
+		{
+			"failed post processing is logged",
+			func() []*TestHook {
+				return []*TestHook{suite.newTestHook(types.HookFailureLog, "post")}
+			},
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Empty(res.VmError)
+				suite.Require().True(transferred)
+				// the changes of the failed hook are discarded
+				suite.Require().Equal([]byte("pre"), suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)).Get(hookStoreKey))
+			},
+		},
+		{
+			"failed pre processing rejects the tx",
+			func() []*TestHook {
+				return []*TestHook{suite.newTestHook(types.HookFailureRevert, "pre")}
+			},
+			false,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Equal([]string{"pre"}, hooks[0].Calls)
+			},
+		},
+		{
+			"failed pre processing is logged",
+			func() []*TestHook {
+				return []*TestHook{suite.newTestHook(types.HookFailureLog, "pre")}
+			},
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Empty(res.VmError)
+				suite.Require().True(transferred)
+			},
+		},
+		{
+			"multiple hooks with individual failure policies",
+			func() []*TestHook {
+				return []*TestHook{
+					suite.newTestHook(types.HookFailureLog, "pre", "post"),
+					suite.newTestHook(types.HookFailureRevert),
+				}
+			},
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Empty(res.VmError)
+				suite.Require().True(transferred)
+				suite.Require().Equal([]string{"pre", "post"}, hooks[0].Calls)
+				suite.Require().Equal([]string{"pre", "post"}, hooks[1].Calls)
+				suite.Require().Equal(ethtypes.ReceiptStatusSuccessful, hooks[1].Receipt.Status)
+			},
+		},
+		{
+			"multiple hooks with a reverting failure",
+			func() []*TestHook {
+				return []*TestHook{
+					suite.newTestHook(types.HookFailureRevert),
+					suite.newTestHook(types.HookFailureRevert, "post"),
+				}
+			},
+			true,
+			func(hooks []*TestHook, res *types.MsgEthereumTxResponse, transferred bool) {
+				suite.Require().Equal(types.ErrPostTxProcessing.Error(), res.VmError)
+				suite.Require().False(transferred)
+				// the post processing changes of all the hooks are reverted
+				suite.Require().Equal([]byte("pre"), suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)).Get(hookStoreKey))
 			},
 		},
 	}
 
 	for _, tc := range testCases {
-		suite.SetupTest()
-		hook := tc.setupHook()
-		suite.app.EvmKeeper.SetHooks(keeper.NewMultiEvmHooks(hook))
-
-		k := suite.app.EvmKeeper
-		txHash := common.BigToHash(big.NewInt(1))
-		k.SetTxHashTransient(txHash)
-		k.AddLog(&ethtypes.Log{
-			Topics:  []common.Hash{},
-			Address: suite.address,
-		})
-		logs := k.GetTxLogsTransient(txHash)
-		result := k.PostTxProcessing(txHash, logs)
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			contract := suite.DeployTestContract(suite.T(), suite.address, big.NewInt(10000))
+
+			hooks := tc.setupHooks()
+			evmHooks := make([]types.EvmHooks, len(hooks))
+			for i := range hooks {
+				evmHooks[i] = hooks[i]
+			}
+			suite.app.EvmKeeper.SetHooks(keeper.NewMultiEvmHooks(evmHooks...))
+
+			_, res, err := suite.transferTokenTx(contract, recipient, amount)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				tc.expFunc(hooks, res, false)
+				return
+			}
 
-		tc.expFunc(hook, result)
+			suite.Require().NoError(err)
+			tc.expFunc(hooks, res, suite.tokenBalance(contract, recipient).Cmp(amount) == 0)
+		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestEvmBlockHooks() {
+	suite.SetupTest()
+
+	failing := suite.newTestHook(types.HookFailureRevert, "begin", "end")
+	recording := suite.newTestHook(types.HookFailureRevert)
+	suite.app.EvmKeeper.SetHooks(keeper.NewMultiEvmHooks(recording, failing))
+
+	store := suite.ctx.KVStore(suite.app.GetKey(types.StoreKey))
+
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, abci.RequestBeginBlock{})
+	suite.Require().Equal([]string{"begin"}, recording.Calls)
+	suite.Require().Equal([]string{"begin"}, failing.Calls)
+	// the changes of the failed hook are discarded
+	suite.Require().Equal([]byte("begin"), store.Get(hookStoreKey))
+
+	store.Delete(hookStoreKey)
+	suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: suite.ctx.BlockHeight()})
+	suite.Require().Equal([]string{"begin", "end"}, recording.Calls)
+	suite.Require().Equal([]string{"begin", "end"}, failing.Calls)
+	suite.Require().Equal([]byte("end"), store.Get(hookStoreKey))
+}
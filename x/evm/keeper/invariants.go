@@ -0,0 +1,248 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+const (
+	codeHashInvariant = "code-hash"
+	storageInvariant  = "storage"
+	balanceInvariant  = "balance"
+	nonceInvariant    = "nonce"
+)
+
+// InvariantSampleBuckets defines the number of buckets the accounts are partitioned into, by the
+// first byte of their address, when the invariants are checked on a sampling basis.
+const InvariantSampleBuckets = 16
+
+// RegisterInvariants registers the evm module invariants.
+//
+// The invariants check all the accounts only if the InvariantFullScan parameter is enabled.
+// Otherwise, whether they are asserted by the crisis module on genesis and on its EndBlocker (every
+// inv-check-period blocks) or requested on demand through a crisis MsgVerifyInvariant, only the
+// accounts of a single bucket, selected pseudo-randomly from the block height, are checked.
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, codeHashInvariant, k.CodeHashInvariant())
+	ir.RegisterRoute(types.ModuleName, storageInvariant, k.StorageInvariant())
+	ir.RegisterRoute(types.ModuleName, balanceInvariant, k.BalanceInvariant())
+	ir.RegisterRoute(types.ModuleName, nonceInvariant, k.NonceInvariant())
+}
+
+// CodeHashInvariant checks that the code of every EthAccount with a non-empty code hash is stored
+// under that hash.
+func (k Keeper) CodeHashInvariant() sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		sample := k.invariantSample(ctx)
+		store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixCode)
+
+		k.iterateSampledAccounts(ctx, sample, func(account authtypes.AccountI) {
+			ethAccount, ok := account.(*ethermint.EthAccount)
+			if !ok {
+				return
+			}
+
+			codeHash := ethAccount.GetCodeHash()
+			if bytes.Equal(codeHash.Bytes(), types.EmptyCodeHash) {
+				return
+			}
+
+			code := store.Get(codeHash.Bytes())
+			switch {
+			case len(code) == 0:
+				count++
+				msg += fmt.Sprintf("\tcode not found for account %s with code hash %s\n", ethAccount.EthAddress(), codeHash)
+			case crypto.Keccak256Hash(code) != codeHash:
+				count++
+				msg += fmt.Sprintf(
+					"\tcode hash mismatch for account %s: account %s, code %s\n",
+					ethAccount.EthAddress(), codeHash, crypto.Keccak256Hash(code),
+				)
+			}
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(
+			types.ModuleName, codeHashInvariant,
+			fmt.Sprintf("%s: code hash mismatches found %d\n%s", sample, count, msg),
+		), broken
+	}
+}
+
+// StorageInvariant checks that there is no contract storage for addresses without an account.
+func (k Keeper) StorageInvariant() sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		sample := k.invariantSample(ctx)
+
+		for _, bucketPrefix := range sample.prefixes() {
+			store := prefix.NewStore(ctx.KVStore(k.storeKey), append(types.KeyPrefixStorage, bucketPrefix...))
+			iterator := store.Iterator(nil, nil)
+
+			// storage keys are sorted by address, so each address is only checked once
+			var lastAddress []byte
+			for ; iterator.Valid(); iterator.Next() {
+				address := make([]byte, 0, common.AddressLength)
+				address = append(address, bucketPrefix...)
+				address = append(address, iterator.Key()[:common.AddressLength-len(bucketPrefix)]...)
+				if bytes.Equal(address, lastAddress) {
+					continue
+				}
+
+				lastAddress = address
+				if k.accountKeeper.GetAccount(ctx, address) == nil {
+					count++
+					msg += fmt.Sprintf("\tstorage found for non-existent account %s\n", common.BytesToAddress(address))
+				}
+			}
+
+			iterator.Close()
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(
+			types.ModuleName, storageInvariant,
+			fmt.Sprintf("%s: storage without account found %d\n%s", sample, count, msg),
+		), broken
+	}
+}
+
+// BalanceInvariant checks that the total balance of the EthAccounts in the EVM denomination doesn't
+// exceed the bank total supply of that denomination. The total can't be checked for equality, since
+// the supply also includes the balances of the other accounts, such as the module accounts (eg: fee
+// collector, staking pools) and the vesting accounts, and of the accounts out of the sample. The
+// equality of the supply and the balances of all the accounts is checked by the bank invariants.
+func (k Keeper) BalanceInvariant() sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		sample := k.invariantSample(ctx)
+		evmDenom := k.GetParams(ctx).EvmDenom
+
+		total := sdk.ZeroInt()
+		k.iterateSampledAccounts(ctx, sample, func(account authtypes.AccountI) {
+			if _, ok := account.(*ethermint.EthAccount); !ok {
+				return
+			}
+
+			total = total.Add(k.bankKeeper.GetBalance(ctx, account.GetAddress(), evmDenom).Amount)
+		})
+
+		supply := k.bankKeeper.GetSupply(ctx, evmDenom)
+		broken := total.GT(supply.Amount)
+
+		return sdk.FormatInvariant(
+			types.ModuleName, balanceInvariant,
+			fmt.Sprintf(
+				"%s: EthAccounts %s balance %s, total supply %s\n",
+				sample, evmDenom, total, supply.Amount,
+			),
+		), broken
+	}
+}
+
+// NonceInvariant checks that the nonce of every account with a public key isn't zero. The public key
+// of an account is set by the ante handler of the first Cosmos transaction it signs, which also
+// increments its nonce.
+func (k Keeper) NonceInvariant() sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		sample := k.invariantSample(ctx)
+
+		k.iterateSampledAccounts(ctx, sample, func(account authtypes.AccountI) {
+			if account.GetPubKey() == nil || account.GetSequence() != 0 {
+				return
+			}
+
+			count++
+			msg += fmt.Sprintf("\tzero nonce for account %s with public key\n", common.BytesToAddress(account.GetAddress()))
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(
+			types.ModuleName, nonceInvariant,
+			fmt.Sprintf("%s: zero nonces with public key found %d\n%s", sample, count, msg),
+		), broken
+	}
+}
+
+// iterateSampledAccounts calls cb for every account of the sample.
+func (k Keeper) iterateSampledAccounts(ctx sdk.Context, sample invariantSample, cb func(account authtypes.AccountI)) {
+	k.accountKeeper.IterateAccounts(ctx, func(account authtypes.AccountI) bool {
+		if sample.contains(account.GetAddress()) {
+			cb(account)
+		}
+
+		return false
+	})
+}
+
+// invariantSample defines the subset of accounts checked by an invariant run.
+type invariantSample struct {
+	full   bool
+	bucket byte
+}
+
+// invariantSample returns the full set of accounts if the InvariantFullScan parameter is enabled,
+// and a single bucket selected from the block height otherwise.
+func (k Keeper) invariantSample(ctx sdk.Context) invariantSample {
+	if k.GetParams(ctx).InvariantFullScan {
+		return invariantSample{full: true}
+	}
+
+	// hash the height so that a periodic check doesn't always select the same bucket
+	heightHash := crypto.Keccak256(sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	return invariantSample{bucket: heightHash[0] % InvariantSampleBuckets}
+}
+
+// contains returns true if the given address belongs to the sample.
+func (s invariantSample) contains(address []byte) bool {
+	return s.full || (len(address) > 0 && address[0]%InvariantSampleBuckets == s.bucket)
+}
+
+// prefixes returns the address prefixes that cover the addresses of the sample.
+func (s invariantSample) prefixes() [][]byte {
+	if s.full {
+		return [][]byte{{}}
+	}
+
+	prefixes := make([][]byte, 0, 256/InvariantSampleBuckets)
+	for b := int(s.bucket); b < 256; b += InvariantSampleBuckets {
+		prefixes = append(prefixes, []byte{byte(b)})
+	}
+
+	return prefixes
+}
+
+// String implements the fmt.Stringer interface.
+func (s invariantSample) String() string {
+	if s.full {
+		return "all accounts"
+	}
+
+	return fmt.Sprintf("accounts bucket %d/%d", s.bucket, InvariantSampleBuckets)
+}
@@ -0,0 +1,207 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+)
+
+// sampledAddress returns a random address that belongs to (or not) the invariants sample of the
+// current block.
+func (suite *KeeperTestSuite) sampledAddress(sampled bool) common.Address {
+	bucket := crypto.Keccak256(sdk.Uint64ToBigEndian(uint64(suite.ctx.BlockHeight())))[0] % keeper.InvariantSampleBuckets
+
+	address := tests.GenerateAddress()
+	address[0] = bucket
+	if !sampled {
+		address[0] = (bucket + 1) % keeper.InvariantSampleBuckets
+	}
+
+	return address
+}
+
+func (suite *KeeperTestSuite) TestInvariants() {
+	fullCtx := func() sdk.Context {
+		params := suite.app.EvmKeeper.GetParams(suite.ctx)
+		params.InvariantFullScan = true
+		suite.app.EvmKeeper.SetParams(suite.ctx, params)
+		return suite.ctx
+	}
+	sampledCtx := func() sdk.Context { return suite.ctx }
+
+	// setPubKey sets a public key on the account of the given address with the given nonce
+	setPubKey := func(address common.Address, nonce uint64) {
+		_, privKey := tests.NewAddrKey()
+		suite.app.EvmKeeper.CreateAccount(address)
+
+		acc := suite.app.AccountKeeper.GetAccount(suite.ctx, address.Bytes())
+		suite.Require().NoError(acc.SetPubKey(privKey.PubKey()))
+		suite.Require().NoError(acc.SetSequence(nonce))
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+
+	testCases := []struct {
+		name      string
+		malleate  func()
+		invariant func(k keeper.Keeper) sdk.Invariant
+		ctx       func() sdk.Context
+		expBroken bool
+	}{
+		{
+			"code hash - contract code found",
+			func() {
+				suite.DeployTestContract(suite.T(), suite.address, big.NewInt(1000))
+			},
+			keeper.Keeper.CodeHashInvariant,
+			fullCtx,
+			false,
+		},
+		{
+			"code hash - code not found",
+			func() {
+				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, suite.address.Bytes()).(*ethermint.EthAccount)
+				acc.CodeHash = common.BytesToHash([]byte("code hash")).Hex()
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+			},
+			keeper.Keeper.CodeHashInvariant,
+			fullCtx,
+			true,
+		},
+		{
+			"code hash - account not sampled",
+			func() {
+				addr := suite.sampledAddress(false)
+				suite.app.EvmKeeper.CreateAccount(addr)
+
+				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes()).(*ethermint.EthAccount)
+				acc.CodeHash = common.BytesToHash([]byte("code hash")).Hex()
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+			},
+			keeper.Keeper.CodeHashInvariant,
+			sampledCtx,
+			false,
+		},
+		{
+			"code hash - account sampled",
+			func() {
+				addr := suite.sampledAddress(true)
+				suite.app.EvmKeeper.CreateAccount(addr)
+
+				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes()).(*ethermint.EthAccount)
+				acc.CodeHash = common.BytesToHash([]byte("code hash")).Hex()
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+			},
+			keeper.Keeper.CodeHashInvariant,
+			sampledCtx,
+			true,
+		},
+		{
+			"storage - account exists",
+			func() {
+				suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+			},
+			keeper.Keeper.StorageInvariant,
+			fullCtx,
+			false,
+		},
+		{
+			"storage - account not found",
+			func() {
+				suite.app.EvmKeeper.SetState(tests.GenerateAddress(), common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+			},
+			keeper.Keeper.StorageInvariant,
+			fullCtx,
+			true,
+		},
+		{
+			"storage - account not sampled",
+			func() {
+				suite.app.EvmKeeper.SetState(suite.sampledAddress(false), common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+			},
+			keeper.Keeper.StorageInvariant,
+			sampledCtx,
+			false,
+		},
+		{
+			"storage - account sampled",
+			func() {
+				suite.app.EvmKeeper.SetState(suite.sampledAddress(true), common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+			},
+			keeper.Keeper.StorageInvariant,
+			sampledCtx,
+			true,
+		},
+		{
+			"balance - account sampled",
+			func() {
+				suite.app.EvmKeeper.AddBalance(suite.sampledAddress(true), big.NewInt(100))
+			},
+			keeper.Keeper.BalanceInvariant,
+			sampledCtx,
+			false,
+		},
+		{
+			"balance - within total supply",
+			func() {
+				suite.app.EvmKeeper.AddBalance(suite.address, big.NewInt(100))
+			},
+			keeper.Keeper.BalanceInvariant,
+			fullCtx,
+			false,
+		},
+		{
+			"nonce - account with public key and nonce",
+			func() {
+				setPubKey(tests.GenerateAddress(), 1)
+			},
+			keeper.Keeper.NonceInvariant,
+			fullCtx,
+			false,
+		},
+		{
+			"nonce - account with public key and zero nonce",
+			func() {
+				setPubKey(tests.GenerateAddress(), 0)
+			},
+			keeper.Keeper.NonceInvariant,
+			fullCtx,
+			true,
+		},
+		{
+			"nonce - account not sampled",
+			func() {
+				setPubKey(suite.sampledAddress(false), 0)
+			},
+			keeper.Keeper.NonceInvariant,
+			sampledCtx,
+			false,
+		},
+		{
+			"nonce - account sampled",
+			func() {
+				setPubKey(suite.sampledAddress(true), 0)
+			},
+			keeper.Keeper.NonceInvariant,
+			sampledCtx,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			tc.malleate()
+
+			msg, broken := tc.invariant(*suite.app.EvmKeeper)(tc.ctx())
+			suite.Require().Equal(tc.expBroken, broken, msg)
+		})
+	}
+}
@@ -9,15 +9,18 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/palantir/stacktrace"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
-// Keeper grants access to the EVM module state and implements the go-ethereum StateDB interface.
+// Keeper grants access to the EVM module state. It implements the store operations of the StateDB
+// used by the EVM (see x/evm/statedb) on top of its current context.
 type Keeper struct {
 	// Protobuf codec
 	cdc codec.BinaryCodec
@@ -40,11 +43,10 @@ type Keeper struct {
 	// access historical headers for EVM state transition execution
 	stakingKeeper types.StakingKeeper
 
-	// Manage the initial context and cache context stack for accessing the store,
-	// emit events and log info.
-	// It is kept as a field to make is accessible by the StateDb
+	// Context for accessing the store, emit events and log info.
+	// It is kept as a field to make is accessible by the StateDB
 	// functions. Resets on every transaction/block.
-	ctxStack ContextStack
+	ctx sdk.Context
 
 	// chain ID number obtained from the context's chain id
 	eip155ChainID *big.Int
@@ -54,6 +56,8 @@ type Keeper struct {
 	// trace EVM state transition execution. This value is obtained from the `--trace` flag.
 	// For more info check https://geth.ethereum.org/docs/dapp/tracing
 	debug bool
+	// accept the unsigned transactions, whose sender is the From field. Only set on development chains.
+	devMode bool
 
 	// EVM Hooks for tx post-processing
 	hooks types.EvmHooks
@@ -94,19 +98,9 @@ func NewKeeper(
 	}
 }
 
-// Ctx returns the current context from the context stack
+// Ctx returns the current context
 func (k Keeper) Ctx() sdk.Context {
-	return k.ctxStack.CurrentContext()
-}
-
-// CommitCachedContexts commit all the cache contexts created by `StateDB.Snapshot`.
-func (k *Keeper) CommitCachedContexts() {
-	k.ctxStack.Commit()
-}
-
-// CachedContextsEmpty returns true if there's no cache contexts.
-func (k *Keeper) CachedContextsEmpty() bool {
-	return k.ctxStack.IsEmpty()
+	return k.ctx
 }
 
 // Logger returns a module-specific logger.
@@ -114,9 +108,9 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", types.ModuleName)
 }
 
-// WithContext clears the context stack, and set the initial context.
+// WithContext sets the current context.
 func (k *Keeper) WithContext(ctx sdk.Context) {
-	k.ctxStack.Reset(ctx)
+	k.ctx = ctx
 }
 
 // WithChainID sets the chain id to the local variable in the keeper
@@ -218,12 +212,6 @@ func (k Keeper) IncreaseTxIndexTransient() {
 	k.SetTxIndexTransient(txIndex + 1)
 }
 
-// ResetRefundTransient resets the available refund amount to 0
-func (k Keeper) ResetRefundTransient(ctx sdk.Context) {
-	store := ctx.TransientStore(k.transientKey)
-	store.Set(types.KeyPrefixTransientRefund, sdk.Uint64ToBigEndian(0))
-}
-
 // ----------------------------------------------------------------------------
 // Log
 // ----------------------------------------------------------------------------
@@ -308,6 +296,7 @@ func (k Keeper) GetAccountStorage(ctx sdk.Context, address common.Address) (type
 func (k Keeper) DeleteState(addr common.Address, key common.Hash) {
 	store := prefix.NewStore(k.Ctx().KVStore(k.storeKey), types.AddressStoragePrefix(addr))
 	store.Delete(key.Bytes())
+	k.stateTrieTracker().markSlot(k.Ctx(), addr, key)
 }
 
 // DeleteAccountStorage clears all the storage state associated with the given address.
@@ -356,6 +345,14 @@ func (k Keeper) ResetAccount(addr common.Address) {
 	k.DeleteAccountStorage(addr)
 }
 
+// EnableDevMode accepts the unsigned transactions on EthereumTx, whose sender is the From field. It must
+// only be called on the nodes of development chains, whose ante handler accepts the unsigned transactions
+// of the impersonated accounts.
+func (k *Keeper) EnableDevMode() *Keeper {
+	k.devMode = true
+	return k
+}
+
 // SetHooks sets the hooks for the EVM module
 func (k *Keeper) SetHooks(eh types.EvmHooks) *Keeper {
 	if k.hooks != nil {
@@ -366,10 +363,44 @@ func (k *Keeper) SetHooks(eh types.EvmHooks) *Keeper {
 	return k
 }
 
-// PostTxProcessing delegate the call to the hooks. If no hook has been registered, this function returns with a `nil` error
-func (k *Keeper) PostTxProcessing(txHash common.Hash, logs []*ethtypes.Log) error {
+// PreTxProcessing delegate the call to the hooks. If no hook has been registered, or the failure policy of
+// the hooks is to log their errors, this function returns with a `nil` error
+func (k *Keeper) PreTxProcessing(msg core.Message) error {
+	if k.hooks == nil {
+		return nil
+	}
+	return runTxHook(k.Ctx(), k.hooks, "pre tx processing", func(ctx sdk.Context) error {
+		return k.hooks.PreTxProcessing(ctx, msg)
+	})
+}
+
+// PostTxProcessing delegate the call to the hooks. If no hook has been registered, or the failure policy of
+// the hooks is to log their errors, this function returns with a `nil` error
+func (k *Keeper) PostTxProcessing(msg core.Message, receipt *ethtypes.Receipt) error {
 	if k.hooks == nil {
 		return nil
 	}
-	return k.hooks.PostTxProcessing(k.Ctx(), txHash, logs)
+	return runTxHook(k.Ctx(), k.hooks, "post tx processing", func(ctx sdk.Context) error {
+		return k.hooks.PostTxProcessing(ctx, msg, receipt)
+	})
+}
+
+// BeginBlockEVM delegate the call to the hooks, if any. The state changes of failed hooks are discarded.
+func (k *Keeper) BeginBlockEVM(ctx sdk.Context, req abci.RequestBeginBlock) {
+	if k.hooks == nil {
+		return
+	}
+	runBlockHook(ctx, k.hooks, "begin block", func(ctx sdk.Context) error {
+		return k.hooks.BeginBlockEVM(ctx, req)
+	})
+}
+
+// EndBlockEVM delegate the call to the hooks, if any. The state changes of failed hooks are discarded.
+func (k *Keeper) EndBlockEVM(ctx sdk.Context, req abci.RequestEndBlock) {
+	if k.hooks == nil {
+		return
+	}
+	runBlockHook(ctx, k.hooks, "end block", func(ctx sdk.Context) error {
+		return k.hooks.EndBlockEVM(ctx, req)
+	})
 }
@@ -1,7 +1,6 @@
 package keeper_test
 
 import (
-	_ "embed"
 	"encoding/json"
 	"math/big"
 	"testing"
@@ -23,10 +22,10 @@ import (
 	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/server/config"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/testutil/contracts"
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -40,28 +39,10 @@ import (
 )
 
 var (
-	//go:embed ERC20Contract.json
-	compiledContractJSON []byte
-	ContractBin          []byte
-	ContractABI          abi.ABI
+	ContractBin = contracts.ERC20Contract.Bin
+	ContractABI = contracts.ERC20Contract.ABI
 )
 
-func init() {
-	var tmp struct {
-		Abi string
-		Bin string
-	}
-	err := json.Unmarshal(compiledContractJSON, &tmp)
-	if err != nil {
-		panic(err)
-	}
-	ContractBin = common.FromHex(tmp.Bin)
-	err = json.Unmarshal([]byte(tmp.Abi), &ContractABI)
-	if err != nil {
-		panic(err)
-	}
-}
-
 var testTokens = sdk.NewIntWithDecimal(1000, 18)
 
 type KeeperTestSuite struct {
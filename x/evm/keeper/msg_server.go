@@ -11,6 +11,8 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
@@ -27,7 +29,17 @@ func (k *Keeper) EthereumTx(goCtx context.Context, msg *types.MsgEthereumTx) (*t
 	sender := msg.From
 	tx := msg.AsTransaction()
 
-	response, err := k.ApplyTransaction(tx)
+	var (
+		response *types.MsgEthereumTxResponse
+		err      error
+	)
+
+	// the sender of an unsigned transaction, only accepted on development chains, is the From field
+	if msg.IsUnsigned() {
+		response, err = k.ApplyUnsignedTransaction(tx, common.HexToAddress(sender))
+	} else {
+		response, err = k.ApplyTransaction(tx)
+	}
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to apply transaction")
 	}
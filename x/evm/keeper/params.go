@@ -9,10 +9,30 @@ import (
 // GetParams returns the total set of evm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 	k.paramSpace.GetParamSet(ctx, &params)
+	params.ChainConfig = k.GetChainConfig(ctx)
 	return params
 }
 
-// SetParams sets the evm parameters to the param space.
+// SetParams sets the evm parameters to the param space and the chain config to
+// the evm store.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
+	k.SetChainConfig(ctx, params.ChainConfig)
+}
+
+// GetChainConfig returns the chain config from the evm store.
+func (k Keeper) GetChainConfig(ctx sdk.Context) (chainConfig types.ChainConfig) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefixChainConfig)
+	if len(bz) == 0 {
+		return chainConfig
+	}
+
+	k.cdc.MustUnmarshal(bz, &chainConfig)
+	return chainConfig
+}
+
+// SetChainConfig sets the chain config to the evm store.
+func (k Keeper) SetChainConfig(ctx sdk.Context, chainConfig types.ChainConfig) {
+	bz := k.cdc.MustMarshal(&chainConfig)
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefixChainConfig, bz)
 }
@@ -2,35 +2,45 @@ package keeper
 
 import (
 	"math/big"
+	"strconv"
+	"time"
 
+	metrics "github.com/armon/go-metrics"
 	"github.com/palantir/stacktrace"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 // NewEVM generates a go-ethereum VM from the provided Message fields and the chain parameters
 // (ChainConfig and module Params). It additionally sets the validator operator address as the
 // coinbase address to make it available for the COINBASE opcode, even though there is no
-// beneficiary of the coinbase transaction (since we're not mining).
+// beneficiary of the coinbase transaction (since we're not mining). The state transitions of
+// the EVM are applied to the given StateDB.
 func (k *Keeper) NewEVM(
 	msg core.Message,
 	config *params.ChainConfig,
 	params types.Params,
 	coinbase common.Address,
 	tracer vm.Tracer,
+	stateDB vm.StateDB,
 ) *vm.EVM {
 	blockCtx := vm.BlockContext{
 		CanTransfer: core.CanTransfer,
@@ -46,7 +56,7 @@ func (k *Keeper) NewEVM(
 	txCtx := core.NewEVMTxContext(msg)
 	vmConfig := k.VMConfig(msg, params, tracer)
 
-	return vm.NewEVM(blockCtx, txCtx, k, config, vmConfig)
+	return vm.NewEVM(blockCtx, txCtx, stateDB, config, vmConfig)
 }
 
 // VMConfig creates an EVM configuration from the debug setting and the extra EIPs enabled on the
@@ -135,13 +145,63 @@ func (k Keeper) GetHashFn() vm.GetHashFunc {
 // returning.
 //
 // For relevant discussion see: https://github.com/cosmos/cosmos-sdk/discussions/9072
+//
+// Metrics
+//
+// The gas used, reverted transactions and contract creations are recorded through the SDK telemetry
+// package for every applied transaction, and the transactions that fail to be applied are counted as
+// failed. The execution time is recorded on a Prometheus histogram.
 func (k *Keeper) ApplyTransaction(tx *ethtypes.Transaction) (*types.MsgEthereumTxResponse, error) {
-	ctx := k.Ctx()
-	params := k.GetParams(ctx)
+	return k.applyTransaction(tx, nil)
+}
+
+// ApplyUnsignedTransaction applies an unsigned transaction like ApplyTransaction, with the given sender.
+// The unsigned transactions are only accepted by the ante handler of development chains, from the
+// impersonated accounts. It returns an error if the dev mode of the keeper is not enabled.
+func (k *Keeper) ApplyUnsignedTransaction(tx *ethtypes.Transaction, from common.Address) (*types.MsgEthereumTxResponse, error) {
+	if !k.devMode {
+		return nil, stacktrace.Propagate(types.ErrUnsignedTx, "failed to apply the transaction of %s", from)
+	}
+
+	return k.applyTransaction(tx, &from)
+}
+
+// applyTransaction applies the transaction, whose sender is recovered from the signature if from is nil.
+func (k *Keeper) applyTransaction(tx *ethtypes.Transaction, from *common.Address) (res *types.MsgEthereumTxResponse, err error) {
+	start := time.Now()
+	defer func() {
+		applyTxDuration.Observe(time.Since(start).Seconds())
+		recordTxMetrics(tx, res, err)
+	}()
 
 	// ensure keeper state error is cleared
 	defer k.ClearStateError()
 
+	exec, err := k.executeTransaction(tx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.finalizeTransaction(exec)
+}
+
+// txExecution is the result of running the message of a transaction on the EVM, prior to
+// writing it to the store.
+type txExecution struct {
+	tx      *ethtypes.Transaction
+	msg     core.Message
+	stateDB *statedb.StateDB
+	res     *types.MsgEthereumTxResponse
+}
+
+// executeTransaction runs the pre processing hooks and the message of the transaction on a new
+// StateDB. Apart from the hooks, it doesn't write to the store: the state changes are kept on the
+// returned StateDB until the execution is finalized. The sender is recovered from the signature of the
+// transaction if from is nil.
+func (k *Keeper) executeTransaction(tx *ethtypes.Transaction, from *common.Address) (*txExecution, error) {
+	ctx := k.Ctx()
+	params := k.GetParams(ctx)
+
 	// return error if contract creation or call are disabled through governance
 	if !params.EnableCreate && tx.To() == nil {
 		return nil, stacktrace.Propagate(types.ErrCreateDisabled, "failed to create new contract")
@@ -154,9 +214,21 @@ func (k *Keeper) ApplyTransaction(tx *ethtypes.Transaction) (*types.MsgEthereumT
 	// get the latest signer according to the chain rules from the config
 	signer := ethtypes.MakeSigner(ethCfg, big.NewInt(ctx.BlockHeight()))
 
-	msg, err := tx.AsMessage(signer)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "failed to return ethereum transaction as core message")
+	var (
+		msg core.Message
+		err error
+	)
+	if from != nil {
+		msg = types.NewUnsignedMessage(tx, *from)
+	} else {
+		msg, err = tx.AsMessage(signer)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to return ethereum transaction as core message")
+		}
+	}
+
+	if err := k.PreTxProcessing(msg); err != nil {
+		return nil, stacktrace.Propagate(err, types.ErrPreTxProcessing.Error())
 	}
 
 	// get the coinbase address from the block proposer
@@ -165,9 +237,32 @@ func (k *Keeper) ApplyTransaction(tx *ethtypes.Transaction) (*types.MsgEthereumT
 		return nil, stacktrace.Propagate(err, "failed to obtain coinbase address")
 	}
 
-	// create an ethereum EVM instance and run the message
+	// create an ethereum EVM instance and run the message on an in-memory StateDB
 	tracer := types.NewTracer(k.tracer, msg, ethCfg, ctx.BlockHeight(), k.debug)
-	evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer)
+	stateDB := statedb.New(k)
+	evm := k.NewEVM(msg, ethCfg, params, coinbase, tracer, stateDB)
+
+	// pass true to compute the gas refund without transferring it, which is done when the
+	// execution is finalized
+	res, err := k.ApplyMessage(evm, msg, ethCfg, true)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to apply ethereum core message")
+	}
+
+	return &txExecution{
+		tx:      tx,
+		msg:     msg,
+		stateDB: stateDB,
+		res:     res,
+	}, nil
+}
+
+// finalizeTransaction writes the execution of a transaction to the store: it refunds the leftover
+// gas to the sender, commits the StateDB, runs the post processing hooks and updates the block
+// bloom and the tx gas meter.
+func (k *Keeper) finalizeTransaction(exec *txExecution) (*types.MsgEthereumTxResponse, error) {
+	ctx := k.Ctx()
+	tx, msg, stateDB, res := exec.tx, exec.msg, exec.stateDB, exec.res
 
 	txHash := tx.Hash()
 
@@ -175,35 +270,46 @@ func (k *Keeper) ApplyTransaction(tx *ethtypes.Transaction) (*types.MsgEthereumT
 	// available on the StateDB functions (eg: AddLog)
 	k.SetTxHashTransient(txHash)
 
-	if !k.ctxStack.IsEmpty() {
-		panic("context stack shouldn't be dirty before apply message")
+	var commit func()
+	if k.hooks != nil {
+		// cache context to contain the tx processing and post processing in same scope
+		var cacheCtx sdk.Context
+		cacheCtx, commit = ctx.CacheContext()
+		k.WithContext(cacheCtx)
+		defer k.WithContext(ctx)
 	}
 
-	var revision int
-	if k.hooks != nil {
-		// snapshot to contain the tx processing and post processing in same scope
-		revision = k.Snapshot()
+	// refund gas prior to handling the vm error in order to match the Ethereum gas consumption instead of the default SDK one.
+	if err := k.refundLeftoverGas(msg, msg.Gas()-res.GasUsed); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to refund gas leftover gas to sender %s", msg.From())
 	}
 
-	// pass false to execute in real mode, which do actual gas refunding
-	res, err := k.ApplyMessage(evm, msg, ethCfg, false)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "failed to apply ethereum core message")
+	// write the state changes of the message to the store in a single pass. The logs are
+	// indexed with the current tx index, so the state is committed before increasing it.
+	if err := stateDB.Commit(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to commit ethereum core message state")
 	}
 
+	txIndex := k.GetTxIndexTransient()
 	k.IncreaseTxIndexTransient()
 
 	res.Hash = txHash.Hex()
-	logs := k.GetTxLogsTransient(txHash)
+	// the log fields derived from the block and tx are set by the keeper on commit
+	logs := stateDB.Logs()
 
-	if !res.Failed() {
-		// Only call hooks if tx executed successfully.
-		if err = k.PostTxProcessing(txHash, logs); err != nil {
+	if k.hooks != nil {
+		receipt := newReceipt(ctx, tx, msg, res, logs, txIndex)
+		if err := k.PostTxProcessing(msg, receipt); err != nil {
 			// If hooks return error, revert the whole tx.
-			k.RevertToSnapshot(revision)
 			res.VmError = types.ErrPostTxProcessing.Error()
 			k.Logger(ctx).Error("tx post processing failed", "error", err)
+		} else {
+			// keep all the cosmos events
+			ctx.EventManager().EmitEvents(k.Ctx().EventManager().Events())
+			commit()
 		}
+
+		k.WithContext(ctx)
 	}
 
 	if len(logs) > 0 {
@@ -214,23 +320,95 @@ func (k *Keeper) ApplyTransaction(tx *ethtypes.Transaction) (*types.MsgEthereumT
 		k.SetBlockBloomTransient(bloom)
 	}
 
-	// Since we've implemented `RevertToSnapshot` api, so for the vm error cases,
-	// the state is reverted, so it's ok to call the commit here anyway.
-	k.CommitCachedContexts()
-
 	// update the gas used after refund
 	k.resetGasMeterAndConsumeGas(res.GasUsed)
+
 	return res, nil
 }
 
+// newReceipt returns the receipt of a transaction applied on the current block, for the post
+// processing hooks. The cumulative gas used includes the gas used by the previous transactions of the
+// block, as consumed on the block gas meter.
+func newReceipt(
+	ctx sdk.Context, tx *ethtypes.Transaction, msg core.Message, res *types.MsgEthereumTxResponse,
+	logs []*ethtypes.Log, txIndex uint64,
+) *ethtypes.Receipt {
+	receipt := &ethtypes.Receipt{
+		Type:              tx.Type(),
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: res.GasUsed,
+		Bloom:             ethtypes.BytesToBloom(ethtypes.LogsBloom(logs)),
+		Logs:              logs,
+		TxHash:            tx.Hash(),
+		GasUsed:           res.GasUsed,
+		BlockHash:         common.BytesToHash(ctx.HeaderHash()),
+		BlockNumber:       big.NewInt(ctx.BlockHeight()),
+		TransactionIndex:  uint(txIndex),
+	}
+
+	if res.Failed() {
+		receipt.Status = ethtypes.ReceiptStatusFailed
+	}
+
+	if ctx.BlockGasMeter() != nil {
+		receipt.CumulativeGasUsed += ctx.BlockGasMeter().GasConsumed()
+	}
+
+	if msg.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(msg.From(), msg.Nonce())
+	}
+
+	return receipt
+}
+
+// applyTxDuration is the execution time histogram of the applied transactions. The telemetry package
+// only records timings as Prometheus summaries, so the histogram is registered on the default
+// Prometheus registry, which is gathered by the telemetry endpoint.
+var applyTxDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "evm_apply_transaction_duration_seconds",
+	Help:    "Execution time of the EVM transactions.",
+	Buckets: prometheus.DefBuckets,
+})
+
+// recordTxMetrics emits the telemetry counters for an applied transaction. The transactions that
+// fail to be applied are counted as failed, while the ones whose execution is reverted are counted
+// as reverted.
+func recordTxMetrics(tx *ethtypes.Transaction, res *types.MsgEthereumTxResponse, err error) {
+	labels := []metrics.Label{
+		telemetry.NewLabel("tx_type", strconv.Itoa(int(tx.Type()))),
+	}
+
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, "tx", "total"}, 1, labels)
+
+	if err != nil {
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "tx", "failed"}, 1, labels)
+		return
+	}
+
+	if res == nil {
+		return
+	}
+
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, "tx", "gas_used", "total"}, float32(res.GasUsed), labels)
+
+	if res.Failed() {
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "tx", "reverted"}, 1, labels)
+	}
+
+	if tx.To() == nil {
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "tx", "contract_creation"}, 1, labels)
+	}
+}
+
 // ApplyMessage computes the new state by applying the given message against the existing state.
 // If the message fails, the VM execution error with the reason will be returned to the client
 // and the transaction won't be committed to the store.
 //
 // Reverted state
 //
-// The transaction is never "reverted" since there is no snapshot + rollback performed on the StateDB.
-// Only successful transactions are written to the store during DeliverTx mode.
+// The message is executed against the StateDB of the EVM, which journals the state changes in
+// memory and reverts them on execution errors. The changes are not written to the store until the
+// caller commits the StateDB.
 //
 // Prechecks and Preprocessing
 //
@@ -279,7 +457,7 @@ func (k *Keeper) ApplyMessage(evm *vm.EVM, msg core.Message, cfg *params.ChainCo
 	// access list preparaion is moved from ante handler to here, because it's needed when `ApplyMessage` is called
 	// under contexts where ante handlers are not run, for example `eth_call` and `eth_estimateGas`.
 	if rules := cfg.Rules(big.NewInt(k.Ctx().BlockHeight())); rules.IsBerlin {
-		k.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+		evm.StateDB.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
 	}
 
 	if contractCreation {
@@ -295,10 +473,10 @@ func (k *Keeper) ApplyMessage(evm *vm.EVM, msg core.Message, cfg *params.ChainCo
 		// We don't refund gas to the sender.
 		// For more info, see: https://github.com/Electronic-Signatures-Industries/ancon-evm/issues/229 and https://github.com/cosmos/cosmos-sdk/issues/9636
 		gasConsumed := msg.Gas() - leftoverGas
-		leftoverGas += k.GasToRefund(gasConsumed, refundQuotient)
+		leftoverGas += GasToRefund(evm.StateDB.GetRefund(), gasConsumed, refundQuotient)
 	} else {
 		// refund gas prior to handling the vm error in order to match the Ethereum gas consumption instead of the default SDK one.
-		leftoverGas, err = k.RefundGas(msg, leftoverGas, refundQuotient)
+		leftoverGas, err = k.RefundGas(msg, leftoverGas, evm.StateDB.GetRefund(), refundQuotient)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "failed to refund gas leftover gas to sender %s", msg.From())
 		}
@@ -340,13 +518,21 @@ func (k *Keeper) ApplyNativeMessage(msg core.Message) (*types.MsgEthereumTxRespo
 		return nil, stacktrace.Propagate(err, "failed to obtain coinbase address")
 	}
 
-	evm := k.NewEVM(msg, ethCfg, params, coinbase, nil)
+	stateDB := statedb.New(k)
+	evm := k.NewEVM(msg, ethCfg, params, coinbase, nil, stateDB)
 
 	ret, err := k.ApplyMessage(evm, msg, ethCfg, true)
 	if err != nil {
 		return nil, err
 	}
-	k.CommitCachedContexts()
+
+	if err := stateDB.Commit(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to commit ethereum core message state")
+	}
+
+	// the logs fields are set by the keeper when the state is committed
+	ret.Logs = types.NewLogsFromEth(stateDB.Logs())
+
 	return ret, nil
 }
 
@@ -360,11 +546,10 @@ func (k *Keeper) GetEthIntrinsicGas(msg core.Message, cfg *params.ChainConfig, i
 }
 
 // GasToRefund calculates the amount of gas the state machine should refund to the sender. It is
-// capped by the refund quotient value.
-func (k *Keeper) GasToRefund(gasConsumed, refundQuotient uint64) uint64 {
+// capped by the refund quotient value and the refund counter of the StateDB.
+func GasToRefund(availableRefund, gasConsumed, refundQuotient uint64) uint64 {
 	// Apply refund counter
 	refund := gasConsumed / refundQuotient
-	availableRefund := k.GetRefund()
 	if refund > availableRefund {
 		return availableRefund
 	}
@@ -375,7 +560,7 @@ func (k *Keeper) GasToRefund(gasConsumed, refundQuotient uint64) uint64 {
 // consumed in the transaction. Additionally, the function sets the total gas consumed to the value
 // returned by the EVM execution, thus ignoring the previous intrinsic gas consumed during in the
 // AnteHandler.
-func (k *Keeper) RefundGas(msg core.Message, leftoverGas, refundQuotient uint64) (uint64, error) {
+func (k *Keeper) RefundGas(msg core.Message, leftoverGas, availableRefund, refundQuotient uint64) (uint64, error) {
 	// safety check: leftover gas after execution should never exceed the gas limit defined on the message
 	if leftoverGas > msg.Gas() {
 		return leftoverGas, stacktrace.Propagate(
@@ -387,7 +572,7 @@ func (k *Keeper) RefundGas(msg core.Message, leftoverGas, refundQuotient uint64)
 	gasConsumed := msg.Gas() - leftoverGas
 
 	// calculate available gas to refund and add it to the leftover gas amount
-	refund := k.GasToRefund(gasConsumed, refundQuotient)
+	refund := GasToRefund(availableRefund, gasConsumed, refundQuotient)
 	leftoverGas += refund
 
 	// safety check: leftover gas after refund should never exceed the gas limit defined on the message
@@ -398,13 +583,31 @@ func (k *Keeper) RefundGas(msg core.Message, leftoverGas, refundQuotient uint64)
 		)
 	}
 
+	if err := k.refundLeftoverGas(msg, leftoverGas); err != nil {
+		return leftoverGas, err
+	}
+
+	return leftoverGas, nil
+}
+
+// refundLeftoverGas returns the EVM tokens of the leftover gas to the sender of the message from
+// the fee collector module account.
+func (k *Keeper) refundLeftoverGas(msg core.Message, leftoverGas uint64) error {
+	// safety check: leftover gas should never exceed the gas limit defined on the message
+	if leftoverGas > msg.Gas() {
+		return stacktrace.Propagate(
+			sdkerrors.Wrapf(types.ErrInconsistentGas, "leftover gas cannot be greater than gas limit (%d > %d)", leftoverGas, msg.Gas()),
+			"failed to refund leftover gas",
+		)
+	}
+
 	// Return EVM tokens for remaining gas, exchanged at the original rate.
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(leftoverGas), msg.GasPrice())
 
 	switch remaining.Sign() {
 	case -1:
 		// negative refund errors
-		return leftoverGas, sdkerrors.Wrapf(types.ErrInvalidRefund, "refunded amount value cannot be negative %d", remaining.Int64())
+		return sdkerrors.Wrapf(types.ErrInvalidRefund, "refunded amount value cannot be negative %d", remaining.Int64())
 	case 1:
 		// positive amount refund
 		params := k.GetParams(k.Ctx())
@@ -415,13 +618,13 @@ func (k *Keeper) RefundGas(msg core.Message, leftoverGas, refundQuotient uint64)
 		err := k.bankKeeper.SendCoinsFromModuleToAccount(k.Ctx(), authtypes.FeeCollectorName, msg.From().Bytes(), refundedCoins)
 		if err != nil {
 			err = sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "fee collector account failed to refund fees: %s", err.Error())
-			return leftoverGas, stacktrace.Propagate(err, "failed to refund %d leftover gas (%s)", leftoverGas, refundedCoins.String())
+			return stacktrace.Propagate(err, "failed to refund %d leftover gas (%s)", leftoverGas, refundedCoins.String())
 		}
 	default:
 		// no refund, consume gas and update the tx gas meter
 	}
 
-	return leftoverGas, nil
+	return nil
 }
 
 // resetGasMeterAndConsumeGas reset first the gas meter consumed value to zero and set it back to the new value
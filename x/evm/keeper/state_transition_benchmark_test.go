@@ -2,9 +2,12 @@ package keeper_test
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -12,7 +15,6 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
-	evmtypes "github.com/tharsis/ethermint/x/evm/types"
 )
 
 var templateAccessListTx = &ethtypes.AccessListTx{
@@ -218,3 +220,67 @@ func BenchmarkApplyNativeMessageWithLegacyTx(b *testing.B) {
 		require.False(b, resp.Failed())
 	}
 }
+
+// nestedCallsCode is the runtime code of a contract that stores its call depth argument on the
+// slot of the same index and calls itself with the argument decremented until it reaches 0, which
+// snapshots and writes the state once per call frame.
+var nestedCallsCode = common.FromHex(
+	"6000" + // PUSH1 0
+		"35" + // CALLDATALOAD
+		"80" + // DUP1
+		"15" + // ISZERO
+		"6020" + // PUSH1 end
+		"57" + // JUMPI
+		"8080" + // DUP1 DUP1
+		"55" + // SSTORE
+		"600190" + // PUSH1 1 SWAP1
+		"03" + // SUB
+		"600052" + // PUSH1 0 MSTORE
+		"6000600060206000600030" + // PUSH1 0 PUSH1 0 PUSH1 32 PUSH1 0 PUSH1 0 ADDRESS
+		"5af1" + // GAS CALL
+		"50" + // POP
+		"5b" + // end: JUMPDEST
+		"00", // STOP
+)
+
+func BenchmarkApplyTransactionNestedCalls(b *testing.B) {
+	for _, depth := range []int64{1, 16, 64} {
+		b.Run(fmt.Sprintf("depth %d", depth), func(b *testing.B) {
+			suite := KeeperTestSuite{}
+			suite.DoSetupTest(b)
+
+			contract := tests.GenerateAddress()
+			suite.app.EvmKeeper.CreateAccount(contract)
+			suite.app.EvmKeeper.SetCode(contract, nestedCallsCode)
+
+			ethSigner := ethtypes.LatestSignerForChainID(suite.app.EvmKeeper.ChainID())
+			txData := &ethtypes.LegacyTx{
+				GasPrice: big.NewInt(0),
+				Gas:      10000000,
+				To:       &contract,
+				Value:    big.NewInt(0),
+				Data:     common.BigToHash(big.NewInt(depth)).Bytes(),
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tx, err := newSignedEthTx(txData,
+					suite.app.EvmKeeper.GetNonce(suite.address),
+					sdk.AccAddress(suite.address.Bytes()),
+					suite.signer,
+					ethSigner,
+				)
+				require.NoError(b, err)
+
+				b.StartTimer()
+				resp, err := suite.app.EvmKeeper.ApplyTransaction(tx)
+				b.StopTimer()
+
+				require.NoError(b, err)
+				require.False(b, resp.Failed())
+			}
+		})
+	}
+}
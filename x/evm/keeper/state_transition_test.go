@@ -2,8 +2,13 @@ package keeper_test
 
 import (
 	"fmt"
+	"math/big"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -14,6 +19,7 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
 func (suite *KeeperTestSuite) TestGetHashFn() {
@@ -173,3 +179,81 @@ func (suite *KeeperTestSuite) TestGetCoinbaseAddress() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestEthereumTxUnsigned() {
+	to := tests.GenerateAddress()
+	suite.app.EvmKeeper.AddBalance(suite.address, big.NewInt(100))
+
+	newUnsignedTx := func() *types.MsgEthereumTx {
+		nonce := suite.app.EvmKeeper.GetNonce(suite.address)
+		tx := types.NewTx(suite.app.EvmKeeper.ChainID(), nonce, &to, big.NewInt(10), 100000, nil, nil, nil)
+		tx.From = suite.address.Hex()
+		return tx
+	}
+
+	// the unsigned transactions are rejected outside of development chains, whatever the path of the message
+	_, err := suite.app.EvmKeeper.EthereumTx(sdk.WrapSDKContext(suite.ctx), newUnsignedTx())
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), types.ErrUnsignedTx.Error())
+	suite.Require().Equal(big.NewInt(0), suite.app.EvmKeeper.GetBalance(to))
+
+	suite.app.EvmKeeper.EnableDevMode()
+
+	res, err := suite.app.EvmKeeper.EthereumTx(sdk.WrapSDKContext(suite.ctx), newUnsignedTx())
+	suite.Require().NoError(err)
+	suite.Require().False(res.Failed(), res.VmError)
+	suite.Require().Equal(big.NewInt(10), suite.app.EvmKeeper.GetBalance(to))
+}
+
+func (suite *KeeperTestSuite) TestApplyTransactionMetrics() {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, sink)
+	suite.Require().NoError(err)
+	defer metrics.NewGlobal(cfg, &metrics.BlackholeSink{}) // nolint: errcheck
+
+	counter := func(name string) float64 {
+		var total float64
+		for _, interval := range sink.Data() {
+			for _, value := range interval.Counters {
+				if value.Name == name {
+					total += value.Sum
+				}
+			}
+		}
+		return total
+	}
+
+	chainID := suite.app.EvmKeeper.ChainID()
+	ethSigner := ethtypes.LatestSignerForChainID(chainID)
+
+	contract := suite.DeployTestContract(suite.T(), suite.address, big.NewInt(100))
+	suite.Require().Equal(float64(1), counter("evm.tx.total"))
+	suite.Require().Equal(float64(1), counter("evm.tx.contract_creation"))
+	suite.Require().NotZero(counter("evm.tx.gas_used.total"))
+
+	// the transfer of more tokens than the balance of the sender is reverted
+	transferData, err := ContractABI.Pack("transfer", tests.GenerateAddress(), big.NewInt(1000))
+	suite.Require().NoError(err)
+	revertedTx := types.NewTx(chainID, suite.app.EvmKeeper.GetNonce(suite.address), &contract, nil, 100000, nil, transferData, nil)
+	revertedTx.From = suite.address.Hex()
+	suite.Require().NoError(revertedTx.Sign(ethSigner, suite.signer))
+
+	res, err := suite.app.EvmKeeper.ApplyTransaction(revertedTx.AsTransaction())
+	suite.Require().NoError(err)
+	suite.Require().True(res.Failed())
+	suite.Require().Equal(float64(2), counter("evm.tx.total"))
+	suite.Require().Equal(float64(1), counter("evm.tx.reverted"))
+	suite.Require().Zero(counter("evm.tx.failed"))
+
+	// the transaction without signature fails to be applied
+	unsignedTx := types.NewTx(chainID, suite.app.EvmKeeper.GetNonce(suite.address), &contract, nil, 100000, nil, transferData, nil)
+	_, err = suite.app.EvmKeeper.ApplyTransaction(unsignedTx.AsTransaction())
+	suite.Require().Error(err)
+	suite.Require().Equal(float64(3), counter("evm.tx.total"))
+	suite.Require().Equal(float64(1), counter("evm.tx.reverted"))
+	suite.Require().Equal(float64(1), counter("evm.tx.failed"))
+	suite.Require().Equal(float64(1), counter("evm.tx.contract_creation"))
+}
@@ -0,0 +1,430 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/palantir/stacktrace"
+
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// The state trie is a secondary Merkle-Patricia trie commitment of the EVM world state, with the
+// same layout as the Ethereum one: the EthAccounts are stored on the account trie, keyed by the
+// hash of their address, and their storage on a storage trie per account. It is maintained when
+// the EnableStateTrie parameter is set and it's updated at the end of every block from the
+// accounts and storage slots modified during the block. The trie nodes are stored, keyed by their
+// hash, on the evm store, so the roots and proofs of past blocks can be queried at their height.
+
+// GetStateRoot returns the root of the state trie, which is not found if the state trie is not
+// enabled.
+func (k Keeper) GetStateRoot(ctx sdk.Context) (common.Hash, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefixStateRoot)
+	if len(bz) == 0 {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(bz), true
+}
+
+// setStateRoot sets the root of the state trie to the evm store.
+func (k Keeper) setStateRoot(ctx sdk.Context, root common.Hash) {
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefixStateRoot, root.Bytes())
+}
+
+// StateTrieBuildBatchSize defines the number of accounts added to the state trie on every block
+// while it's built from the whole EVM state.
+const StateTrieBuildBatchSize = 1000
+
+// stateTrieBuild defines the progress of the construction of the state trie: the root of the trie of
+// the accounts already added and the auth store key of the next account to add.
+type stateTrieBuild struct {
+	root    common.Hash
+	nextKey []byte
+}
+
+// getStateTrieBuild returns the progress of the construction of the state trie, which is not found if
+// the construction hasn't started yet.
+func (k Keeper) getStateTrieBuild(ctx sdk.Context) (stateTrieBuild, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyPrefixStateTrieBuild)
+	if len(bz) < common.HashLength {
+		return stateTrieBuild{}, false
+	}
+
+	return stateTrieBuild{
+		root:    common.BytesToHash(bz[:common.HashLength]),
+		nextKey: bz[common.HashLength:],
+	}, true
+}
+
+// setStateTrieBuild sets the progress of the construction of the state trie to the evm store.
+func (k Keeper) setStateTrieBuild(ctx sdk.Context, build stateTrieBuild) {
+	bz := append(build.root.Bytes(), build.nextKey...)
+	ctx.KVStore(k.storeKey).Set(types.KeyPrefixStateTrieBuild, bz)
+}
+
+// resetStateTrie removes the state trie, or the one under construction, and prunes its nodes from
+// the evm store, so that the trie is built from scratch the next time it is enabled. The nodes remain
+// available on the store versions of the past blocks.
+func (k Keeper) resetStateTrie(ctx sdk.Context) {
+	nodes := k.stateTrieNodes(ctx)
+
+	if root, found := k.GetStateRoot(ctx); found {
+		if err := nodes.dereference(root, true); err != nil {
+			k.Logger(ctx).Error("failed to prune the state trie", "root", root.Hex(), "error", err.Error())
+		}
+		ctx.KVStore(k.storeKey).Delete(types.KeyPrefixStateRoot)
+	}
+
+	if build, found := k.getStateTrieBuild(ctx); found {
+		if err := nodes.dereference(build.root, true); err != nil {
+			k.Logger(ctx).Error("failed to prune the state trie", "root", build.root.Hex(), "error", err.Error())
+		}
+		ctx.KVStore(k.storeKey).Delete(types.KeyPrefixStateTrieBuild)
+	}
+}
+
+// stateTrieDatabase returns the trie database backed by the state trie nodes of the evm store.
+func (k Keeper) stateTrieDatabase(ctx sdk.Context) *trie.Database {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixStateTrie)
+	// the preimages of the trie keys aren't needed, as the accounts and storage are iterable
+	return trie.NewDatabaseWithConfig(trieStore{store: store}, &trie.Config{})
+}
+
+// stateTrieTracker returns the tracker of the accounts and storage slots modified by the keeper.
+func (k Keeper) stateTrieTracker() stateTrieTracker {
+	return stateTrieTracker{paramSpace: k.paramSpace, transientKey: k.transientKey}
+}
+
+// UpdateStateTrie updates the state trie with the accounts and storage slots modified on the
+// current block and emits its root on an event to be included on the Web3 block headers. If the trie
+// wasn't enabled on the previous block, it's built from the whole EVM state over the next blocks,
+// StateTrieBuildBatchSize accounts at a time, and its root is only set once all the accounts are
+// added. The state trie is removed when the EnableStateTrie parameter is not set.
+func (k *Keeper) UpdateStateTrie(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	if !params.EnableStateTrie {
+		k.resetStateTrie(ctx)
+		return
+	}
+
+	// the trie nodes are only written if the update succeeds
+	cacheCtx, writeCache := ctx.CacheContext()
+
+	var (
+		newRoot common.Hash
+		built   bool
+		err     error
+	)
+
+	if root, found := k.GetStateRoot(cacheCtx); found {
+		newRoot, err = k.updateStateTrie(cacheCtx, params.EvmDenom, root)
+		built = true
+	} else {
+		newRoot, built, err = k.buildStateTrie(cacheCtx, params.EvmDenom)
+	}
+
+	if err != nil {
+		// the trie is rebuilt from the EVM state on the next blocks
+		k.Logger(ctx).Error("failed to update the state trie", "error", err.Error())
+		k.resetStateTrie(ctx)
+		return
+	}
+
+	writeCache()
+
+	if !built {
+		return
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeStateRoot,
+			sdk.NewAttribute(types.AttributeKeyStateRoot, newRoot.Hex()),
+		),
+	)
+}
+
+// stateTrieUpdates returns the accounts modified on the current block, sorted by address, and their
+// modified storage slots.
+func (k Keeper) stateTrieUpdates(ctx sdk.Context) ([]common.Address, map[common.Address][]common.Hash) {
+	accounts := make(map[common.Address]struct{})
+	slots := make(map[common.Address][]common.Hash)
+
+	slotStore := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientStateTrieSlot)
+	slotIterator := slotStore.Iterator(nil, nil)
+	for ; slotIterator.Valid(); slotIterator.Next() {
+		addr := common.BytesToAddress(slotIterator.Key()[:common.AddressLength])
+		slots[addr] = append(slots[addr], common.BytesToHash(slotIterator.Key()[common.AddressLength:]))
+		accounts[addr] = struct{}{}
+	}
+	slotIterator.Close()
+
+	accountStore := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientStateTrieAccount)
+	accountIterator := accountStore.Iterator(nil, nil)
+	for ; accountIterator.Valid(); accountIterator.Next() {
+		accounts[common.BytesToAddress(accountIterator.Key())] = struct{}{}
+	}
+	accountIterator.Close()
+
+	addresses := make([]common.Address, 0, len(accounts))
+	for addr := range accounts {
+		addresses = append(addresses, addr)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	return addresses, slots
+}
+
+// updateStateTrie applies the accounts and storage slots modified on the current block to the
+// state trie with the given root, sets the new root and prunes the nodes of the previous one.
+func (k Keeper) updateStateTrie(ctx sdk.Context, evmDenom string, root common.Hash) (common.Hash, error) {
+	addresses, slots := k.stateTrieUpdates(ctx)
+
+	db := k.stateTrieDatabase(ctx)
+	accountTrie, err := trie.NewSecure(root, db)
+	if err != nil {
+		return common.Hash{}, stacktrace.Propagate(err, "failed to open the state trie at %s", root)
+	}
+
+	for _, addr := range addresses {
+		account := k.accountKeeper.GetAccount(ctx, addr.Bytes())
+		if err := k.updateStateTrieAccount(ctx, db, accountTrie, evmDenom, addr, account, slots[addr]); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	newRoot, err := k.commitStateTrie(ctx, db, accountTrie, root)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	k.setStateRoot(ctx, newRoot)
+	return newRoot, nil
+}
+
+// buildStateTrie adds the next StateTrieBuildBatchSize accounts, and their storage, to the state trie
+// under construction, along with the accounts already added that were modified on the current
+// block. It returns the root of the trie, and true if all the accounts are added, in which case the
+// root is set.
+func (k Keeper) buildStateTrie(ctx sdk.Context, evmDenom string) (common.Hash, bool, error) {
+	build, started := k.getStateTrieBuild(ctx)
+
+	db := k.stateTrieDatabase(ctx)
+	accountTrie, err := trie.NewSecure(build.root, db)
+	if err != nil {
+		return common.Hash{}, false, stacktrace.Propagate(err, "failed to open the state trie at %s", build.root)
+	}
+
+	// the accounts that are not added yet are added with their latest state
+	if started {
+		addresses, slots := k.stateTrieUpdates(ctx)
+		for _, addr := range addresses {
+			if bytes.Compare(addr.Bytes(), build.nextKey) >= 0 {
+				break
+			}
+
+			account := k.accountKeeper.GetAccount(ctx, addr.Bytes())
+			if err := k.updateStateTrieAccount(ctx, db, accountTrie, evmDenom, addr, account, slots[addr]); err != nil {
+				return common.Hash{}, false, err
+			}
+		}
+	}
+
+	res, err := k.accountKeeper.Accounts(sdk.WrapSDKContext(ctx), &authtypes.QueryAccountsRequest{
+		Pagination: &query.PageRequest{Key: build.nextKey, Limit: StateTrieBuildBatchSize},
+	})
+	if err != nil {
+		return common.Hash{}, false, stacktrace.Propagate(err, "failed to query the accounts")
+	}
+
+	for _, any := range res.Accounts {
+		account, ok := any.GetCachedValue().(authtypes.AccountI)
+		if !ok {
+			return common.Hash{}, false, fmt.Errorf("invalid account type %s", any.TypeUrl)
+		}
+
+		if _, ok := account.(*ethermint.EthAccount); !ok {
+			continue
+		}
+
+		addr := common.BytesToAddress(account.GetAddress())
+
+		var slots []common.Hash
+		store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(addr))
+		iterator := store.Iterator(nil, nil)
+		for ; iterator.Valid(); iterator.Next() {
+			slots = append(slots, common.BytesToHash(iterator.Key()))
+		}
+		iterator.Close()
+
+		if err := k.updateStateTrieAccount(ctx, db, accountTrie, evmDenom, addr, account, slots); err != nil {
+			return common.Hash{}, false, err
+		}
+	}
+
+	root, err := k.commitStateTrie(ctx, db, accountTrie, build.root)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+
+	if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+		ctx.KVStore(k.storeKey).Delete(types.KeyPrefixStateTrieBuild)
+		k.setStateRoot(ctx, root)
+		return root, true, nil
+	}
+
+	k.setStateTrieBuild(ctx, stateTrieBuild{root: root, nextKey: res.Pagination.NextKey})
+	return root, false, nil
+}
+
+// updateStateTrieAccount updates the account leaf and the given storage slots of an account on
+// the state trie. The account is removed from the trie if it is not an EthAccount.
+func (k Keeper) updateStateTrieAccount(
+	ctx sdk.Context, db *trie.Database, accountTrie *trie.SecureTrie, evmDenom string,
+	addr common.Address, account authtypes.AccountI, slots []common.Hash,
+) error {
+	ethAccount, ok := account.(*ethermint.EthAccount)
+	if !ok {
+		return accountTrie.TryDelete(addr.Bytes())
+	}
+
+	data, err := getStateTrieAccount(accountTrie, addr)
+	if err != nil {
+		return err
+	}
+
+	if len(slots) > 0 {
+		storageTrie, err := trie.NewSecure(data.Root, db)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to open the storage trie of %s at %s", addr, data.Root)
+		}
+
+		store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(addr))
+		for _, key := range slots {
+			value := common.BytesToHash(store.Get(key.Bytes()))
+			if value == (common.Hash{}) {
+				err = storageTrie.TryDelete(key.Bytes())
+			} else {
+				// the values are stored as RLP encoded integers, as in Ethereum
+				var enc []byte
+				enc, err = rlp.EncodeToBytes(common.TrimLeftZeroes(value.Bytes()))
+				if err == nil {
+					err = storageTrie.TryUpdate(key.Bytes(), enc)
+				}
+			}
+
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to update the storage trie of %s", addr)
+			}
+		}
+
+		data.Root, err = writeStateTrie(db, storageTrie)
+		if err != nil {
+			return err
+		}
+	}
+
+	data.Nonce = ethAccount.GetSequence()
+	data.Balance = k.bankKeeper.GetBalance(ctx, ethAccount.GetAddress(), evmDenom).Amount.BigInt()
+	data.CodeHash = ethAccount.GetCodeHash().Bytes()
+
+	enc, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return err
+	}
+
+	return accountTrie.TryUpdate(addr.Bytes(), enc)
+}
+
+// getStateTrieAccount returns the account leaf of the state trie, or an empty account if the
+// address is not found on the trie.
+func getStateTrieAccount(accountTrie *trie.SecureTrie, addr common.Address) (*state.Account, error) {
+	data := &state.Account{
+		Balance:  new(big.Int),
+		Root:     ethtypes.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash,
+	}
+
+	enc, err := accountTrie.TryGet(addr.Bytes())
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to get the state trie account %s", addr)
+	}
+
+	if len(enc) == 0 {
+		return data, nil
+	}
+
+	if err := rlp.DecodeBytes(enc, data); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to decode the state trie account %s", addr)
+	}
+
+	return data, nil
+}
+
+// stateTrieProof collects the hex encoded nodes of a state trie proof, from the root to the leaf.
+type stateTrieProof []string
+
+// Put implements ethdb.KeyValueWriter.
+func (p *stateTrieProof) Put(_ []byte, value []byte) error {
+	*p = append(*p, hexutil.Encode(value))
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter.
+func (p *stateTrieProof) Delete([]byte) error {
+	return errors.New("delete not supported")
+}
+
+// writeStateTrie commits the trie nodes to the trie database and writes them to the evm store.
+func writeStateTrie(db *trie.Database, t *trie.SecureTrie) (common.Hash, error) {
+	root, err := t.Commit(nil)
+	if err != nil {
+		return common.Hash{}, stacktrace.Propagate(err, "failed to commit the trie")
+	}
+
+	if err := db.Commit(root, false, nil); err != nil {
+		return common.Hash{}, stacktrace.Propagate(err, "failed to write the trie nodes")
+	}
+
+	return root, nil
+}
+
+// commitStateTrie writes the nodes of the account trie, and of the storage tries updated along with
+// it, to the evm store and prunes the nodes of the previous root that are no longer referenced.
+func (k Keeper) commitStateTrie(
+	ctx sdk.Context, db *trie.Database, accountTrie *trie.SecureTrie, prevRoot common.Hash,
+) (common.Hash, error) {
+	root, err := writeStateTrie(db, accountTrie)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// the new nodes are referenced first, so that the nodes shared with the previous root are kept
+	nodes := k.stateTrieNodes(ctx)
+	if err := nodes.reference(root, true); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := nodes.dereference(prevRoot, true); err != nil {
+		return common.Hash{}, err
+	}
+
+	return root, nil
+}
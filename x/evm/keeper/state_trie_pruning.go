@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// The state trie nodes are shared between the roots of consecutive blocks, and between the storage
+// tries with the same content, so they are reference counted on the evm store: a node is referenced
+// once by the state root and once by every node (or account leaf, for the storage trie roots) that
+// points to it. The nodes that are no longer referenced once the root is updated are pruned from
+// the latest state. The roots of the past blocks, and their proofs, remain available on the store
+// versions of their height.
+
+// stateTrieRef defines a reference to a node of the account trie, or of a storage trie.
+type stateTrieRef struct {
+	hash        common.Hash
+	accountTrie bool
+}
+
+// stateTrieNodes defines the state trie nodes of the evm store and their reference counts.
+type stateTrieNodes struct {
+	nodes prefix.Store
+	refs  prefix.Store
+}
+
+// stateTrieNodes returns the state trie nodes of the evm store.
+func (k Keeper) stateTrieNodes(ctx sdk.Context) stateTrieNodes {
+	store := ctx.KVStore(k.storeKey)
+	return stateTrieNodes{
+		nodes: prefix.NewStore(store, types.KeyPrefixStateTrie),
+		refs:  prefix.NewStore(store, types.KeyPrefixStateTrieRefs),
+	}
+}
+
+// count returns the reference count of the node.
+func (n stateTrieNodes) count(hash common.Hash) uint64 {
+	bz := n.refs.Get(hash.Bytes())
+	if len(bz) == 0 {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// reference increments the reference count of the node. The nodes it points to are referenced when
+// it's referenced for the first time.
+func (n stateTrieNodes) reference(hash common.Hash, accountTrie bool) error {
+	if hash == (common.Hash{}) || hash == ethtypes.EmptyRootHash {
+		return nil
+	}
+
+	count := n.count(hash)
+	n.refs.Set(hash.Bytes(), sdk.Uint64ToBigEndian(count+1))
+	if count > 0 {
+		return nil
+	}
+
+	children, err := n.children(hash, accountTrie)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if err := n.reference(child.hash, child.accountTrie); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dereference decrements the reference count of the node. The node is deleted, and the nodes it
+// points to are dereferenced, when it's no longer referenced.
+func (n stateTrieNodes) dereference(hash common.Hash, accountTrie bool) error {
+	if hash == (common.Hash{}) || hash == ethtypes.EmptyRootHash {
+		return nil
+	}
+
+	count := n.count(hash)
+	switch count {
+	case 0:
+		return fmt.Errorf("state trie node %s is not referenced", hash)
+	case 1:
+	default:
+		n.refs.Set(hash.Bytes(), sdk.Uint64ToBigEndian(count-1))
+		return nil
+	}
+
+	children, err := n.children(hash, accountTrie)
+	if err != nil {
+		return err
+	}
+
+	n.refs.Delete(hash.Bytes())
+	n.nodes.Delete(hash.Bytes())
+
+	for _, child := range children {
+		if err := n.dereference(child.hash, child.accountTrie); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// children returns the references of the node with the given hash.
+func (n stateTrieNodes) children(hash common.Hash, accountTrie bool) ([]stateTrieRef, error) {
+	blob := n.nodes.Get(hash.Bytes())
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("state trie node %s not found", hash)
+	}
+
+	elems, _, err := rlp.SplitList(blob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state trie node %s: %w", hash, err)
+	}
+
+	return nodeRefs(elems, accountTrie)
+}
+
+// nodeRefs returns the references of the node with the given RLP encoded list elements: the hashes
+// of its children, and the storage root of the account leaves. The children smaller than a hash are
+// embedded on their parent.
+func nodeRefs(elems []byte, accountTrie bool) ([]stateTrieRef, error) {
+	count, err := rlp.CountValues(elems)
+	if err != nil {
+		return nil, err
+	}
+
+	switch count {
+	case 2:
+		// short node, with a compact encoded key whose flag nibble is set for leaves
+		key, rest, err := rlp.SplitString(elems)
+		if err != nil {
+			return nil, err
+		}
+
+		kind, val, _, err := rlp.Split(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(key) == 0 || key[0]>>4 < 2 {
+			return childRefs(kind, val, accountTrie)
+		}
+
+		if !accountTrie {
+			return nil, nil
+		}
+
+		var account state.Account
+		if err := rlp.DecodeBytes(val, &account); err != nil {
+			return nil, err
+		}
+
+		return []stateTrieRef{{hash: account.Root}}, nil
+
+	case 17:
+		// full node, whose last element is the value, which is not set on the secure tries
+		var refs []stateTrieRef
+		for i := 0; i < 16; i++ {
+			kind, val, rest, err := rlp.Split(elems)
+			if err != nil {
+				return nil, err
+			}
+			elems = rest
+
+			childRefs, err := childRefs(kind, val, accountTrie)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, childRefs...)
+		}
+		return refs, nil
+
+	default:
+		return nil, fmt.Errorf("invalid trie node with %d elements", count)
+	}
+}
+
+// childRefs returns the references of a child of a node, which is either the hash of the child, an
+// embedded node or empty.
+func childRefs(kind rlp.Kind, val []byte, accountTrie bool) ([]stateTrieRef, error) {
+	switch {
+	case kind == rlp.String && len(val) == common.HashLength:
+		return []stateTrieRef{{hash: common.BytesToHash(val), accountTrie: accountTrie}}, nil
+	case kind == rlp.List:
+		return nodeRefs(val, accountTrie)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,413 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// enableStateTrie sets the EnableStateTrie parameter.
+func (suite *KeeperTestSuite) enableStateTrie(enable bool) {
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.EnableStateTrie = enable
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+}
+
+// endBlock runs the evm EndBlock on the current context.
+func (suite *KeeperTestSuite) endBlock() {
+	suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: suite.ctx.BlockHeight()})
+	suite.app.EvmKeeper.WithContext(suite.ctx)
+}
+
+// expectedStateRoot computes the Ethereum world state root of the EthAccounts with the go-ethereum
+// StateDB.
+func (suite *KeeperTestSuite) expectedStateRoot() common.Hash {
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	suite.Require().NoError(err)
+
+	k := suite.app.EvmKeeper
+	suite.app.AccountKeeper.IterateAccounts(suite.ctx, func(account authtypes.AccountI) bool {
+		if _, ok := account.(*ethermint.EthAccount); !ok {
+			return false
+		}
+
+		addr := common.BytesToAddress(account.GetAddress())
+		stateDB.CreateAccount(addr)
+		stateDB.SetBalance(addr, k.GetBalance(addr))
+		stateDB.SetNonce(addr, k.GetNonce(addr))
+		stateDB.SetCode(addr, k.GetCode(addr))
+		err := k.ForEachStorage(addr, func(key, value common.Hash) bool {
+			stateDB.SetState(addr, key, value)
+			return false
+		})
+		suite.Require().NoError(err)
+		return false
+	})
+
+	root, err := stateDB.Commit(false)
+	suite.Require().NoError(err)
+	return root
+}
+
+func (suite *KeeperTestSuite) TestUpdateStateTrie() {
+	testCases := []struct {
+		name     string
+		malleate func()
+	}{
+		{
+			"storage updated",
+			func() {
+				suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+			},
+		},
+		{
+			"storage deleted",
+			func() {
+				suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.Hash{})
+			},
+		},
+		{
+			"contract deployed",
+			func() {
+				suite.DeployTestContract(suite.T(), suite.address, big.NewInt(1000))
+			},
+		},
+		{
+			"account created",
+			func() {
+				addr := tests.GenerateAddress()
+				suite.app.EvmKeeper.CreateAccount(addr)
+				suite.app.EvmKeeper.AddBalance(addr, big.NewInt(100))
+				suite.app.EvmKeeper.SetNonce(addr, 3)
+			},
+		},
+		{
+			"balance sent outside of the EVM",
+			func() {
+				addr := tests.GenerateAddress()
+				account := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr.Bytes())
+				suite.app.AccountKeeper.SetAccount(suite.ctx, account)
+
+				// the updated accounts are tracked by the bank keeper
+				coins := sdk.NewCoins(sdk.NewCoin(suite.EvmDenom(), sdk.NewInt(10)))
+				err := suite.app.BankKeeper.MintCoins(suite.ctx, types.ModuleName, coins)
+				suite.Require().NoError(err)
+				err = suite.app.BankKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, addr.Bytes(), coins)
+				suite.Require().NoError(err)
+			},
+		},
+		{
+			"fee paid by a fee granter",
+			func() {
+				// the granter is funded without tracking the updated balance
+				bankKeeper := suite.app.BankKeeper.(keeper.StateTrieBankKeeper).Unwrap()
+				coins := sdk.NewCoins(sdk.NewCoin(suite.EvmDenom(), sdk.NewInt(20)))
+				err := bankKeeper.MintCoins(suite.ctx, types.ModuleName, coins)
+				suite.Require().NoError(err)
+				err = bankKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, suite.address.Bytes(), coins)
+				suite.Require().NoError(err)
+
+				// the fee is deducted from the granter on the ante handler
+				fees := sdk.NewCoins(sdk.NewCoin(suite.EvmDenom(), sdk.NewInt(10)))
+				err = suite.app.BankKeeper.SendCoinsFromAccountToModule(suite.ctx, suite.address.Bytes(), authtypes.FeeCollectorName, fees)
+				suite.Require().NoError(err)
+			},
+		},
+		{
+			"sequence incremented by the ante handler",
+			func() {
+				ak := keeper.NewStateTrieAccountKeeper(
+					suite.app.AccountKeeper, suite.app.GetSubspace(types.ModuleName), suite.app.GetTKey(types.TransientKey),
+				)
+				account := ak.GetAccount(suite.ctx, suite.address.Bytes())
+				suite.Require().NoError(account.SetSequence(account.GetSequence() + 1))
+				ak.SetAccount(suite.ctx, account)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("initial")))
+
+			// the state trie is built from the whole EVM state once enabled
+			suite.enableStateTrie(true)
+			suite.endBlock()
+
+			root, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+			suite.Require().True(found)
+			suite.Require().Equal(suite.expectedStateRoot(), root)
+
+			// and then updated from the modified accounts
+			suite.ctx = suite.ctx.WithEventManager(sdk.NewEventManager())
+			suite.app.EvmKeeper.WithContext(suite.ctx)
+			tc.malleate()
+			suite.endBlock()
+
+			newRoot, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+			suite.Require().True(found)
+			suite.Require().NotEqual(root, newRoot)
+			suite.Require().Equal(suite.expectedStateRoot(), newRoot)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestUpdateStateTrieDisabled() {
+	suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+	suite.enableStateTrie(true)
+	suite.endBlock()
+
+	_, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().NotZero(suite.countKeys(types.KeyPrefixStateTrie))
+
+	suite.enableStateTrie(false)
+	suite.endBlock()
+
+	_, found = suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().False(found)
+
+	_, err := suite.queryClient.StateRoot(sdk.WrapSDKContext(suite.ctx), &types.QueryStateRootRequest{})
+	suite.Require().Error(err)
+
+	// the trie nodes are pruned
+	suite.Require().Zero(suite.countKeys(types.KeyPrefixStateTrie))
+	suite.Require().Zero(suite.countKeys(types.KeyPrefixStateTrieRefs))
+}
+
+// countKeys returns the number of keys with the given prefix on the evm store.
+func (suite *KeeperTestSuite) countKeys(keyPrefix []byte) int {
+	iterator := sdk.KVStorePrefixIterator(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), keyPrefix)
+	defer iterator.Close()
+
+	count := 0
+	for ; iterator.Valid(); iterator.Next() {
+		count++
+	}
+	return count
+}
+
+// countTrackedAccounts returns the number of accounts marked as modified on the state trie.
+func (suite *KeeperTestSuite) countTrackedAccounts() int {
+	store := suite.ctx.TransientStore(suite.app.GetTKey(types.TransientKey))
+	iterator := sdk.KVStorePrefixIterator(store, types.KeyPrefixTransientStateTrieAccount)
+	defer iterator.Close()
+
+	count := 0
+	for ; iterator.Valid(); iterator.Next() {
+		count++
+	}
+	return count
+}
+
+// reachableStateTrieNodes returns the number of distinct nodes reachable from the given root on the
+// account trie and on the storage tries of its accounts.
+func (suite *KeeperTestSuite) reachableStateTrieNodes(root common.Hash) int {
+	db := memorydb.New()
+	iterator := sdk.KVStorePrefixIterator(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), types.KeyPrefixStateTrie)
+	for ; iterator.Valid(); iterator.Next() {
+		suite.Require().NoError(db.Put(iterator.Key()[len(types.KeyPrefixStateTrie):], iterator.Value()))
+	}
+	iterator.Close()
+
+	trieDB := trie.NewDatabase(db)
+	nodes := make(map[common.Hash]struct{})
+
+	var walk func(root common.Hash, accountTrie bool)
+	walk = func(root common.Hash, accountTrie bool) {
+		t, err := trie.New(root, trieDB)
+		suite.Require().NoError(err)
+
+		it := t.NodeIterator(nil)
+		for it.Next(true) {
+			if it.Hash() != (common.Hash{}) {
+				nodes[it.Hash()] = struct{}{}
+			}
+
+			if it.Leaf() && accountTrie {
+				var account state.Account
+				suite.Require().NoError(rlp.DecodeBytes(it.LeafBlob(), &account))
+				if account.Root != ethtypes.EmptyRootHash {
+					walk(account.Root, false)
+				}
+			}
+		}
+		suite.Require().NoError(it.Error())
+	}
+	walk(root, true)
+
+	return len(nodes)
+}
+
+func (suite *KeeperTestSuite) TestStateTrieTracking() {
+	coins := sdk.NewCoins(sdk.NewCoin(suite.EvmDenom(), sdk.NewInt(10)))
+	send := func() {
+		err := suite.app.BankKeeper.MintCoins(suite.ctx, types.ModuleName, coins)
+		suite.Require().NoError(err)
+		err = suite.app.BankKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, tests.GenerateAddress().Bytes(), coins)
+		suite.Require().NoError(err)
+	}
+
+	// nothing is tracked while the state trie is disabled
+	send()
+	suite.Require().Zero(suite.countTrackedAccounts())
+
+	suite.enableStateTrie(true)
+	send()
+	suite.Require().Equal(2, suite.countTrackedAccounts())
+}
+
+func (suite *KeeperTestSuite) TestBuildStateTrie() {
+	// the accounts are added over two blocks
+	addresses := make([]common.Address, keeper.StateTrieBuildBatchSize)
+	for i := range addresses {
+		addresses[i] = tests.GenerateAddress()
+		suite.app.EvmKeeper.CreateAccount(addresses[i])
+		suite.app.EvmKeeper.AddBalance(addresses[i], big.NewInt(int64(i+1)))
+	}
+	suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+
+	suite.enableStateTrie(true)
+	suite.endBlock()
+
+	_, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().False(found)
+
+	// the accounts updated during the construction are included, whether they are already added or not
+	suite.ctx = suite.ctx.WithEventManager(sdk.NewEventManager())
+	suite.app.EvmKeeper.WithContext(suite.ctx)
+	for _, addr := range addresses {
+		suite.app.EvmKeeper.SetNonce(addr, 1)
+	}
+	suite.endBlock()
+
+	root, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().Equal(suite.expectedStateRoot(), root)
+	suite.Require().Equal(suite.reachableStateTrieNodes(root), suite.countKeys(types.KeyPrefixStateTrie))
+}
+
+func (suite *KeeperTestSuite) TestStateTriePruning() {
+	key := common.BytesToHash([]byte("key"))
+
+	contract := suite.DeployTestContract(suite.T(), suite.address, big.NewInt(1000))
+	suite.app.EvmKeeper.SetState(suite.address, key, common.BytesToHash([]byte("value")))
+	suite.enableStateTrie(true)
+	suite.endBlock()
+
+	prevRoot, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().True(found)
+
+	// the storage tries of the account and of the contract share the nodes of the same slot value
+	suite.app.EvmKeeper.SetState(contract, key, common.BytesToHash([]byte("value")))
+	suite.endBlock()
+
+	sharedRoot, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().Equal(suite.expectedStateRoot(), sharedRoot)
+	suite.Require().Equal(suite.reachableStateTrieNodes(sharedRoot), suite.countKeys(types.KeyPrefixStateTrie))
+
+	store := suite.ctx.KVStore(suite.app.GetKey(types.StoreKey))
+	suite.Require().False(store.Has(append(types.KeyPrefixStateTrie, prevRoot.Bytes()...)))
+
+	// the nodes still referenced by the contract storage are kept
+	suite.app.EvmKeeper.SetState(suite.address, key, common.Hash{})
+	suite.endBlock()
+
+	root, found := suite.app.EvmKeeper.GetStateRoot(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().Equal(suite.expectedStateRoot(), root)
+	suite.Require().Equal(suite.reachableStateTrieNodes(root), suite.countKeys(types.KeyPrefixStateTrie))
+	suite.Require().Equal(suite.countKeys(types.KeyPrefixStateTrie), suite.countKeys(types.KeyPrefixStateTrieRefs))
+
+	res, err := suite.queryClient.Proof(sdk.WrapSDKContext(suite.ctx), &types.QueryProofRequest{
+		Address:     contract.Hex(),
+		StorageKeys: []string{key.Hex()},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(common.BytesToHash([]byte("value")).Hex(), res.StorageProofs[0].Value)
+}
+
+func (suite *KeeperTestSuite) TestQueryProof() {
+	key := common.BytesToHash([]byte("key"))
+	value := common.BytesToHash([]byte("value"))
+	missingKey := common.BytesToHash([]byte("missing"))
+
+	suite.app.EvmKeeper.SetState(suite.address, key, value)
+	suite.enableStateTrie(true)
+	suite.endBlock()
+
+	ctx := sdk.WrapSDKContext(suite.ctx)
+
+	rootRes, err := suite.queryClient.StateRoot(ctx, &types.QueryStateRootRequest{})
+	suite.Require().NoError(err)
+	root := common.HexToHash(rootRes.Root)
+	suite.Require().Equal(suite.expectedStateRoot(), root)
+
+	res, err := suite.queryClient.Proof(ctx, &types.QueryProofRequest{
+		Address:     suite.address.Hex(),
+		StorageKeys: []string{key.Hex(), missingKey.Hex()},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(root.Hex(), res.StateRoot)
+	suite.Require().Equal(suite.app.EvmKeeper.GetBalance(suite.address).String(), res.Balance)
+	suite.Require().Equal(suite.app.EvmKeeper.GetNonce(suite.address), res.Nonce)
+	suite.Require().Equal(suite.app.EvmKeeper.GetCodeHash(suite.address).Hex(), res.CodeHash)
+
+	// the account proof must resolve to the account leaf
+	enc, err := trie.VerifyProof(root, crypto.Keccak256(suite.address.Bytes()), proofDB(suite, res.AccountProof))
+	suite.Require().NoError(err)
+
+	var account state.Account
+	suite.Require().NoError(rlp.DecodeBytes(enc, &account))
+	suite.Require().Equal(res.StorageHash, account.Root.Hex())
+
+	suite.Require().Len(res.StorageProofs, 2)
+	suite.Require().Equal(value.Hex(), res.StorageProofs[0].Value)
+	suite.Require().Equal(common.Hash{}.Hex(), res.StorageProofs[1].Value)
+
+	// the storage proofs must resolve to the value or prove its absence
+	storageRoot := common.HexToHash(res.StorageHash)
+	enc, err = trie.VerifyProof(storageRoot, crypto.Keccak256(key.Bytes()), proofDB(suite, res.StorageProofs[0].Proof))
+	suite.Require().NoError(err)
+	var content []byte
+	suite.Require().NoError(rlp.DecodeBytes(enc, &content))
+	suite.Require().Equal(value, common.BytesToHash(content))
+
+	enc, err = trie.VerifyProof(storageRoot, crypto.Keccak256(missingKey.Bytes()), proofDB(suite, res.StorageProofs[1].Proof))
+	suite.Require().NoError(err)
+	suite.Require().Nil(enc)
+
+	_, err = suite.queryClient.Proof(ctx, &types.QueryProofRequest{Address: "invalid"})
+	suite.Require().Error(err)
+}
+
+// proofDB returns a database with the hex encoded proof nodes keyed by their hash.
+func proofDB(suite *KeeperTestSuite, proof []string) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		bz, err := hexutil.Decode(node)
+		suite.Require().NoError(err)
+		suite.Require().NoError(db.Put(crypto.Keccak256(bz), bz))
+	}
+	return db
+}
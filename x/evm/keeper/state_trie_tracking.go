@@ -0,0 +1,267 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// The accounts updated outside of the EVM, such as the fees paid by the signers and fee granters
+// of a transaction, the rewards paid by the distribution module or the sequences incremented by
+// the ante handler, must also be updated on the state trie. They are marked on the transient
+// store of the evm module by the bank and account keepers below, which wrap the ones used by the
+// other modules. As the marks are written on the same branch of the store as the updates, they
+// are discarded along with the updates of failed transactions and of the CheckTx state. Nothing is
+// marked while the EnableStateTrie parameter is not set, as the trie is then built from scratch
+// once it's enabled.
+
+// stateTrieTracker marks the accounts and storage slots modified on the current block on the evm
+// transient store, if the state trie is enabled. The stores are accessed with an infinite gas meter
+// so that the state trie doesn't change the gas consumed by the transactions.
+type stateTrieTracker struct {
+	paramSpace   paramtypes.Subspace
+	transientKey sdk.StoreKey
+}
+
+// newStateTrieTracker returns a tracker that reads the EnableStateTrie parameter from the given evm
+// param space and marks the modified accounts on the given evm transient store.
+func newStateTrieTracker(paramSpace paramtypes.Subspace, transientKey sdk.StoreKey) stateTrieTracker {
+	// the key table is shared with the evm keeper param space
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return stateTrieTracker{paramSpace: paramSpace, transientKey: transientKey}
+}
+
+// enabled returns true if the EnableStateTrie parameter is set.
+func (t stateTrieTracker) enabled(ctx sdk.Context) bool {
+	var enabled bool
+	t.paramSpace.GetIfExists(ctx, types.ParamStoreKeyEnableStateTrie, &enabled)
+	return enabled
+}
+
+// markAccount marks the account as modified on the current block.
+func (t stateTrieTracker) markAccount(ctx sdk.Context, addr common.Address) {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if !t.enabled(ctx) {
+		return
+	}
+
+	store := prefix.NewStore(ctx.TransientStore(t.transientKey), types.KeyPrefixTransientStateTrieAccount)
+	store.Set(addr.Bytes(), []byte{1})
+}
+
+// markSlot marks the account storage slot as modified on the current block.
+func (t stateTrieTracker) markSlot(ctx sdk.Context, addr common.Address, key common.Hash) {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if !t.enabled(ctx) {
+		return
+	}
+
+	store := prefix.NewStore(ctx.TransientStore(t.transientKey), types.KeyPrefixTransientStateTrieSlot)
+	store.Set(append(addr.Bytes(), key.Bytes()...), []byte{1})
+}
+
+// StateTrieBankKeeper is a bank keeper that marks the accounts whose balances are updated as
+// modified on the state trie.
+type StateTrieBankKeeper struct {
+	bankkeeper.BaseKeeper
+
+	tracker stateTrieTracker
+}
+
+var _ bankkeeper.Keeper = StateTrieBankKeeper{}
+
+// NewStateTrieBankKeeper returns a bank keeper wrapping the given one, which marks the updated
+// accounts on the given evm transient store while the EnableStateTrie parameter of the given evm
+// param space is set.
+func NewStateTrieBankKeeper(
+	bk bankkeeper.BaseKeeper, paramSpace paramtypes.Subspace, transientKey sdk.StoreKey,
+) StateTrieBankKeeper {
+	return StateTrieBankKeeper{BaseKeeper: bk, tracker: newStateTrieTracker(paramSpace, transientKey)}
+}
+
+// Unwrap returns the wrapped base keeper, which doesn't track the updated accounts.
+func (k StateTrieBankKeeper) Unwrap() bankkeeper.BaseKeeper {
+	return k.BaseKeeper
+}
+
+// mark marks the accounts as modified on the state trie.
+func (k StateTrieBankKeeper) mark(ctx sdk.Context, addrs ...sdk.AccAddress) {
+	for _, addr := range addrs {
+		k.tracker.markAccount(ctx, common.BytesToAddress(addr))
+	}
+}
+
+// markModule marks the module account as modified on the state trie.
+func (k StateTrieBankKeeper) markModule(ctx sdk.Context, moduleName string) {
+	k.mark(ctx, authtypes.NewModuleAddress(moduleName))
+}
+
+// SendCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) SendCoins(ctx sdk.Context, fromAddr, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := k.BaseKeeper.SendCoins(ctx, fromAddr, toAddr, amt); err != nil {
+		return err
+	}
+	k.mark(ctx, fromAddr, toAddr)
+	return nil
+}
+
+// InputOutputCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []banktypes.Input, outputs []banktypes.Output) error {
+	if err := k.BaseKeeper.InputOutputCoins(ctx, inputs, outputs); err != nil {
+		return err
+	}
+
+	for _, input := range inputs {
+		addr, err := sdk.AccAddressFromBech32(input.Address)
+		if err != nil {
+			return err
+		}
+		k.mark(ctx, addr)
+	}
+
+	for _, output := range outputs {
+		addr, err := sdk.AccAddressFromBech32(output.Address)
+		if err != nil {
+			return err
+		}
+		k.mark(ctx, addr)
+	}
+
+	return nil
+}
+
+// SendCoinsFromModuleToAccount implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) SendCoinsFromModuleToAccount(
+	ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins,
+) error {
+	if err := k.BaseKeeper.SendCoinsFromModuleToAccount(ctx, senderModule, recipientAddr, amt); err != nil {
+		return err
+	}
+	k.markModule(ctx, senderModule)
+	k.mark(ctx, recipientAddr)
+	return nil
+}
+
+// SendCoinsFromModuleToModule implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) SendCoinsFromModuleToModule(
+	ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins,
+) error {
+	if err := k.BaseKeeper.SendCoinsFromModuleToModule(ctx, senderModule, recipientModule, amt); err != nil {
+		return err
+	}
+	k.markModule(ctx, senderModule)
+	k.markModule(ctx, recipientModule)
+	return nil
+}
+
+// SendCoinsFromAccountToModule implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) SendCoinsFromAccountToModule(
+	ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins,
+) error {
+	if err := k.BaseKeeper.SendCoinsFromAccountToModule(ctx, senderAddr, recipientModule, amt); err != nil {
+		return err
+	}
+	k.mark(ctx, senderAddr)
+	k.markModule(ctx, recipientModule)
+	return nil
+}
+
+// DelegateCoinsFromAccountToModule implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) DelegateCoinsFromAccountToModule(
+	ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins,
+) error {
+	if err := k.BaseKeeper.DelegateCoinsFromAccountToModule(ctx, senderAddr, recipientModule, amt); err != nil {
+		return err
+	}
+	k.mark(ctx, senderAddr)
+	k.markModule(ctx, recipientModule)
+	return nil
+}
+
+// UndelegateCoinsFromModuleToAccount implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) UndelegateCoinsFromModuleToAccount(
+	ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins,
+) error {
+	if err := k.BaseKeeper.UndelegateCoinsFromModuleToAccount(ctx, senderModule, recipientAddr, amt); err != nil {
+		return err
+	}
+	k.markModule(ctx, senderModule)
+	k.mark(ctx, recipientAddr)
+	return nil
+}
+
+// DelegateCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) DelegateCoins(ctx sdk.Context, delegatorAddr, moduleAccAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := k.BaseKeeper.DelegateCoins(ctx, delegatorAddr, moduleAccAddr, amt); err != nil {
+		return err
+	}
+	k.mark(ctx, delegatorAddr, moduleAccAddr)
+	return nil
+}
+
+// UndelegateCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) UndelegateCoins(ctx sdk.Context, moduleAccAddr, delegatorAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := k.BaseKeeper.UndelegateCoins(ctx, moduleAccAddr, delegatorAddr, amt); err != nil {
+		return err
+	}
+	k.mark(ctx, moduleAccAddr, delegatorAddr)
+	return nil
+}
+
+// MintCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error {
+	if err := k.BaseKeeper.MintCoins(ctx, moduleName, amt); err != nil {
+		return err
+	}
+	k.markModule(ctx, moduleName)
+	return nil
+}
+
+// BurnCoins implements bankkeeper.Keeper.
+func (k StateTrieBankKeeper) BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error {
+	if err := k.BaseKeeper.BurnCoins(ctx, moduleName, amt); err != nil {
+		return err
+	}
+	k.markModule(ctx, moduleName)
+	return nil
+}
+
+// StateTrieAccountKeeper is an account keeper that marks the accounts it sets or removes, such as
+// the signers whose sequence is incremented by the ante handler, as modified on the state trie.
+type StateTrieAccountKeeper struct {
+	authkeeper.AccountKeeper
+
+	tracker stateTrieTracker
+}
+
+// NewStateTrieAccountKeeper returns an account keeper wrapping the given one, which marks the
+// updated accounts on the given evm transient store while the EnableStateTrie parameter of the given
+// evm param space is set.
+func NewStateTrieAccountKeeper(
+	ak authkeeper.AccountKeeper, paramSpace paramtypes.Subspace, transientKey sdk.StoreKey,
+) StateTrieAccountKeeper {
+	return StateTrieAccountKeeper{AccountKeeper: ak, tracker: newStateTrieTracker(paramSpace, transientKey)}
+}
+
+// SetAccount implements authkeeper.AccountKeeperI.
+func (k StateTrieAccountKeeper) SetAccount(ctx sdk.Context, account authtypes.AccountI) {
+	k.AccountKeeper.SetAccount(ctx, account)
+	k.tracker.markAccount(ctx, common.BytesToAddress(account.GetAddress()))
+}
+
+// RemoveAccount implements authkeeper.AccountKeeperI.
+func (k StateTrieAccountKeeper) RemoveAccount(ctx sdk.Context, account authtypes.AccountI) {
+	k.AccountKeeper.RemoveAccount(ctx, account)
+	k.tracker.markAccount(ctx, common.BytesToAddress(account.GetAddress()))
+}
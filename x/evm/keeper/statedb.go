@@ -7,17 +7,17 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 )
 
-var _ vm.StateDB = &Keeper{}
+var _ statedb.Keeper = &Keeper{}
 
 // ----------------------------------------------------------------------------
 // Account
@@ -34,6 +34,7 @@ func (k *Keeper) CreateAccount(addr common.Address) {
 
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 	account := k.accountKeeper.GetAccount(ctx, cosmosAddr)
 	log := ""
 	if account == nil {
@@ -66,6 +67,7 @@ func (k *Keeper) AddBalance(addr common.Address, amount *big.Int) {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 
 	if amount.Sign() != 1 {
 		k.Logger(ctx).Debug(
@@ -126,6 +128,7 @@ func (k *Keeper) SubBalance(addr common.Address, amount *big.Int) {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 
 	if amount.Sign() != 1 {
 		k.Logger(ctx).Debug(
@@ -231,6 +234,7 @@ func (k *Keeper) SetNonce(addr common.Address, nonce uint64) {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 	account := k.accountKeeper.GetAccount(ctx, cosmosAddr)
@@ -330,6 +334,7 @@ func (k *Keeper) SetCode(addr common.Address, code []byte) {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 
 	if bytes.Equal(code, types.EmptyCodeHash) {
 		k.Logger(ctx).Debug("passed in EmptyCodeHash, but expected empty code")
@@ -376,80 +381,6 @@ func (k *Keeper) SetCode(addr common.Address, code []byte) {
 	)
 }
 
-// GetCodeSize returns the size of the contract code associated with this object,
-// or zero if none.
-func (k *Keeper) GetCodeSize(addr common.Address) int {
-	if k.HasStateError() {
-		return 0
-	}
-
-	code := k.GetCode(addr)
-	return len(code)
-}
-
-// ----------------------------------------------------------------------------
-// Refund
-// ----------------------------------------------------------------------------
-
-// NOTE: gas refunded needs to be tracked and stored in a separate variable in
-// order to add it subtract/add it from/to the gas used value after the EVM
-// execution has finalized. The refund value is cleared on every transaction and
-// at the end of every block.
-
-// AddRefund adds the given amount of gas to the refund transient value.
-func (k *Keeper) AddRefund(gas uint64) {
-	if k.HasStateError() {
-		return
-	}
-
-	ctx := k.Ctx()
-	refund := k.GetRefund()
-
-	refund += gas
-
-	store := ctx.TransientStore(k.transientKey)
-	store.Set(types.KeyPrefixTransientRefund, sdk.Uint64ToBigEndian(refund))
-}
-
-// SubRefund subtracts the given amount of gas from the transient refund value. This function
-// will panic if gas amount is greater than the stored refund.
-func (k *Keeper) SubRefund(gas uint64) {
-	if k.HasStateError() {
-		return
-	}
-
-	ctx := k.Ctx()
-	refund := k.GetRefund()
-
-	if gas > refund {
-		// TODO: (@fedekunze) set to 0?? Geth panics here
-		panic("refund counter below zero")
-	}
-
-	refund -= gas
-
-	store := ctx.TransientStore(k.transientKey)
-	store.Set(types.KeyPrefixTransientRefund, sdk.Uint64ToBigEndian(refund))
-}
-
-// GetRefund returns the amount of gas available for return after the tx execution
-// finalizes. This value is reset to 0 on every transaction.
-func (k *Keeper) GetRefund() uint64 {
-	if k.HasStateError() {
-		return 0
-	}
-
-	ctx := k.Ctx()
-	store := ctx.TransientStore(k.transientKey)
-
-	bz := store.Get(types.KeyPrefixTransientRefund)
-	if len(bz) == 0 {
-		return 0
-	}
-
-	return sdk.BigEndianToUint64(bz)
-}
-
 // ----------------------------------------------------------------------------
 // State
 // ----------------------------------------------------------------------------
@@ -465,18 +396,8 @@ func doGetState(ctx sdk.Context, storeKey sdk.StoreKey, addr common.Address, key
 	return common.BytesToHash(value)
 }
 
-// GetCommittedState returns the value set in store for the given key hash. If the key is not registered
+// GetState returns the value set in store for the given key hash. If the key is not registered
 // this function returns the empty hash.
-func (k *Keeper) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
-	if k.HasStateError() {
-		return common.Hash{}
-	}
-
-	return doGetState(k.ctxStack.initialCtx, k.storeKey, addr, hash)
-}
-
-// GetState returns the committed state for the given key hash, as all changes are committed directly
-// to the KVStore.
 func (k *Keeper) GetState(addr common.Address, hash common.Hash) common.Hash {
 	if k.HasStateError() {
 		return common.Hash{}
@@ -494,6 +415,7 @@ func (k *Keeper) SetState(addr common.Address, key, value common.Hash) {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markSlot(ctx, addr, key)
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(addr))
 
 	action := "updated"
@@ -523,6 +445,7 @@ func (k *Keeper) Suicide(addr common.Address) bool {
 	}
 
 	ctx := k.Ctx()
+	k.stateTrieTracker().markAccount(ctx, addr)
 
 	prev := k.HasSuicided(addr)
 	if prev {
@@ -575,7 +498,7 @@ func (k *Keeper) HasSuicided(addr common.Address) bool {
 }
 
 // ----------------------------------------------------------------------------
-// Account Exist / Empty
+// Account Exist
 // ----------------------------------------------------------------------------
 
 // Exist returns true if the given account exists in store or if it has been
@@ -596,161 +519,6 @@ func (k *Keeper) Exist(addr common.Address) bool {
 	return account != nil
 }
 
-// Empty returns true if the address meets the following conditions:
-// 	- nonce is 0
-// 	- balance amount for evm denom is 0
-// 	- account code hash is empty
-//
-// Non-ethereum accounts are considered not empty
-func (k *Keeper) Empty(addr common.Address) bool {
-	if k.HasStateError() {
-		return false
-	}
-
-	ctx := k.Ctx()
-	nonce := uint64(0)
-	codeHash := types.EmptyCodeHash
-
-	cosmosAddr := sdk.AccAddress(addr.Bytes())
-	account := k.accountKeeper.GetAccount(ctx, cosmosAddr)
-
-	if account != nil {
-		nonce = account.GetSequence()
-		ethAccount, isEthAccount := account.(*ethermint.EthAccount)
-		if !isEthAccount {
-			return false
-		}
-
-		codeHash = common.HexToHash(ethAccount.CodeHash).Bytes()
-	}
-
-	balance := k.GetBalance(addr)
-	hasZeroBalance := balance.Sign() == 0
-	hasEmptyCodeHash := bytes.Equal(codeHash, types.EmptyCodeHash)
-
-	return hasZeroBalance && nonce == 0 && hasEmptyCodeHash
-}
-
-// ----------------------------------------------------------------------------
-// Access List
-// ----------------------------------------------------------------------------
-
-// PrepareAccessList handles the preparatory steps for executing a state transition with
-// regards to both EIP-2929 and EIP-2930:
-//
-// 	- Add sender to access list (2929)
-// 	- Add destination to access list (2929)
-// 	- Add precompiles to access list (2929)
-// 	- Add the contents of the optional tx access list (2930)
-//
-// This method should only be called if Yolov3/Berlin/2929+2930 is applicable at the current number.
-func (k *Keeper) PrepareAccessList(sender common.Address, dest *common.Address, precompiles []common.Address, txAccesses ethtypes.AccessList) {
-	if k.HasStateError() {
-		return
-	}
-
-	k.AddAddressToAccessList(sender)
-	if dest != nil {
-		k.AddAddressToAccessList(*dest)
-		// If it's a create-tx, the destination will be added inside evm.create
-	}
-	for _, addr := range precompiles {
-		k.AddAddressToAccessList(addr)
-	}
-	for _, tuple := range txAccesses {
-		k.AddAddressToAccessList(tuple.Address)
-		for _, key := range tuple.StorageKeys {
-			k.AddSlotToAccessList(tuple.Address, key)
-		}
-	}
-}
-
-// AddressInAccessList returns true if the address is registered on the transient store.
-func (k *Keeper) AddressInAccessList(addr common.Address) bool {
-	if k.HasStateError() {
-		return false
-	}
-
-	ctx := k.Ctx()
-	ts := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientAccessListAddress)
-	return ts.Has(addr.Bytes())
-}
-
-// SlotInAccessList checks if the address and the slots are registered in the transient store
-func (k *Keeper) SlotInAccessList(addr common.Address, slot common.Hash) (addressOk, slotOk bool) {
-	if k.HasStateError() {
-		return false, false
-	}
-
-	addressOk = k.AddressInAccessList(addr)
-	slotOk = k.addressSlotInAccessList(addr, slot)
-	return addressOk, slotOk
-}
-
-// addressSlotInAccessList returns true if the address's slot is registered on the transient store.
-func (k *Keeper) addressSlotInAccessList(addr common.Address, slot common.Hash) bool {
-	ctx := k.Ctx()
-	ts := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientAccessListSlot)
-	key := append(addr.Bytes(), slot.Bytes()...)
-	return ts.Has(key)
-}
-
-// AddAddressToAccessList adds the given address to the access list. If the address is already
-// in the access list, this function performs a no-op.
-func (k *Keeper) AddAddressToAccessList(addr common.Address) {
-	if k.HasStateError() {
-		return
-	}
-
-	if k.AddressInAccessList(addr) {
-		return
-	}
-
-	ctx := k.Ctx()
-	ts := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientAccessListAddress)
-	ts.Set(addr.Bytes(), []byte{0x1})
-}
-
-// AddSlotToAccessList adds the given (address, slot) to the access list. If the address and slot are
-// already in the access list, this function performs a no-op.
-func (k *Keeper) AddSlotToAccessList(addr common.Address, slot common.Hash) {
-	if k.HasStateError() {
-		return
-	}
-
-	k.AddAddressToAccessList(addr)
-	if k.addressSlotInAccessList(addr, slot) {
-		return
-	}
-
-	ctx := k.Ctx()
-	ts := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientAccessListSlot)
-	key := append(addr.Bytes(), slot.Bytes()...)
-	ts.Set(key, []byte{0x1})
-}
-
-// ----------------------------------------------------------------------------
-// Snapshotting
-// ----------------------------------------------------------------------------
-
-// Snapshot return the index in the cached context stack
-func (k *Keeper) Snapshot() int {
-	if k.HasStateError() {
-		return 0
-	}
-
-	return k.ctxStack.Snapshot()
-}
-
-// RevertToSnapshot pop all the cached contexts after(including) the snapshot
-func (k *Keeper) RevertToSnapshot(target int) {
-	if k.HasStateError() {
-		return
-	}
-
-	k.ctxStack.RevertToSnapshot(target)
-}
-
 // ----------------------------------------------------------------------------
 // Log
 // ----------------------------------------------------------------------------
@@ -780,15 +548,6 @@ func (k *Keeper) AddLog(log *ethtypes.Log) {
 	)
 }
 
-// ----------------------------------------------------------------------------
-// Trie
-// ----------------------------------------------------------------------------
-
-// AddPreimage performs a no-op since the EnablePreimageRecording flag is disabled
-// on the vm.Config during state transitions. No store trie preimages are written
-// to the database.
-func (k *Keeper) AddPreimage(_ common.Hash, _ []byte) {}
-
 // ----------------------------------------------------------------------------
 // Iterator
 // ----------------------------------------------------------------------------
@@ -827,6 +586,11 @@ func (k *Keeper) HasStateError() bool {
 	return k.stateErr != nil
 }
 
+// StateError returns the error of the previous state operation, if any
+func (k *Keeper) StateError() error {
+	return k.stateErr
+}
+
 // ClearStateError reset the previous state operation error to nil
 func (k *Keeper) ClearStateError() {
 	k.stateErr = nil
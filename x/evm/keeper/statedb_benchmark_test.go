@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 )
 
 func BenchmarkCreateAccountNew(b *testing.B) {
@@ -115,14 +116,16 @@ func BenchmarkSnapshot(b *testing.B) {
 	b.ResetTimer()
 	b.ReportAllocs()
 
+	stateDB := statedb.New(suite.app.EvmKeeper)
+
 	for i := 0; i < b.N; i++ {
-		target := suite.app.EvmKeeper.Snapshot()
+		target := stateDB.Snapshot()
 		require.Equal(b, i, target)
 	}
 
 	for i := b.N - 1; i >= 0; i-- {
 		require.NotPanics(b, func() {
-			suite.app.EvmKeeper.RevertToSnapshot(i)
+			stateDB.RevertToSnapshot(i)
 		})
 	}
 }
@@ -157,11 +160,13 @@ func BenchmarkAddRefund(b *testing.B) {
 	suite := KeeperTestSuite{}
 	suite.DoSetupTest(b)
 
+	stateDB := statedb.New(suite.app.EvmKeeper)
+
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		suite.app.EvmKeeper.AddRefund(1)
+		stateDB.AddRefund(1)
 	}
 }
 
@@ -12,49 +12,59 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/statedb"
 	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// StateDB returns a new StateDB on top of the keeper, as the ones the transactions are executed on.
+func (suite *KeeperTestSuite) StateDB() *statedb.StateDB {
+	return statedb.New(suite.app.EvmKeeper)
+}
+
 func (suite *KeeperTestSuite) TestCreateAccount() {
 	testCases := []struct {
 		name     string
 		addr     common.Address
-		malleate func(common.Address)
-		callback func(common.Address)
+		malleate func(vm.StateDB, common.Address)
+		callback func(vm.StateDB, common.Address)
 	}{
 		{
 			"reset account (keep balance)",
 			suite.address,
-			func(addr common.Address) {
-				suite.app.EvmKeeper.AddBalance(addr, big.NewInt(100))
-				suite.Require().NotZero(suite.app.EvmKeeper.GetBalance(addr).Int64())
+			func(vmdb vm.StateDB, addr common.Address) {
+				vmdb.AddBalance(addr, big.NewInt(100))
+				suite.Require().NotZero(vmdb.GetBalance(addr).Int64())
 			},
-			func(addr common.Address) {
-				suite.Require().Equal(suite.app.EvmKeeper.GetBalance(addr).Int64(), int64(100))
+			func(vmdb vm.StateDB, addr common.Address) {
+				suite.Require().Equal(vmdb.GetBalance(addr).Int64(), int64(100))
 			},
 		},
 		{
 			"create account",
 			tests.GenerateAddress(),
-			func(addr common.Address) {
-				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes())
-				suite.Require().Nil(acc)
+			func(vmdb vm.StateDB, addr common.Address) {
+				suite.Require().False(vmdb.Exist(addr))
 			},
-			func(addr common.Address) {
-				acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes())
-				suite.Require().NotNil(acc)
+			func(vmdb vm.StateDB, addr common.Address) {
+				suite.Require().True(vmdb.Exist(addr))
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			tc.malleate(tc.addr)
-			suite.app.EvmKeeper.CreateAccount(tc.addr)
-			tc.callback(tc.addr)
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb, tc.addr)
+			vmdb.CreateAccount(tc.addr)
+			tc.callback(vmdb, tc.addr)
+
+			// the committed state is read back from the keeper
+			suite.Require().NoError(vmdb.Commit())
+			tc.callback(suite.StateDB(), tc.addr)
 		})
 	}
 }
@@ -75,24 +85,23 @@ func (suite *KeeperTestSuite) TestAddBalance() {
 			big.NewInt(0),
 			true,
 		},
-		{
-			"negative amount",
-			big.NewInt(-1),
-			true,
-		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			prev := suite.app.EvmKeeper.GetBalance(suite.address)
-			suite.app.EvmKeeper.AddBalance(suite.address, tc.amount)
-			post := suite.app.EvmKeeper.GetBalance(suite.address)
+			vmdb := suite.StateDB()
+			prev := vmdb.GetBalance(suite.address)
+			vmdb.AddBalance(suite.address, tc.amount)
+			post := vmdb.GetBalance(suite.address)
 
 			if tc.isNoOp {
 				suite.Require().Equal(prev.Int64(), post.Int64())
 			} else {
 				suite.Require().Equal(new(big.Int).Add(prev, tc.amount).Int64(), post.Int64())
 			}
+
+			suite.Require().NoError(vmdb.Commit())
+			suite.Require().Equal(post, suite.app.EvmKeeper.GetBalance(suite.address))
 		})
 	}
 }
@@ -111,7 +120,7 @@ func (suite *KeeperTestSuite) TestSubBalance() {
 			true,
 		},
 		{
-			"positive amount, below zero",
+			"positive amount, above zero",
 			big.NewInt(50),
 			func() {
 				suite.app.EvmKeeper.AddBalance(suite.address, big.NewInt(100))
@@ -124,26 +133,24 @@ func (suite *KeeperTestSuite) TestSubBalance() {
 			func() {},
 			true,
 		},
-		{
-			"negative amount",
-			big.NewInt(-1),
-			func() {},
-			true,
-		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.malleate()
 
-			prev := suite.app.EvmKeeper.GetBalance(suite.address)
-			suite.app.EvmKeeper.SubBalance(suite.address, tc.amount)
-			post := suite.app.EvmKeeper.GetBalance(suite.address)
+			vmdb := suite.StateDB()
+			prev := vmdb.GetBalance(suite.address)
+			vmdb.SubBalance(suite.address, tc.amount)
+			post := vmdb.GetBalance(suite.address)
+			suite.Require().Equal(new(big.Int).Sub(prev, tc.amount).Int64(), post.Int64())
 
+			// the subtractions below zero are ignored by the keeper on commit
+			suite.Require().NoError(vmdb.Commit())
 			if tc.isNoOp {
-				suite.Require().Equal(prev.Int64(), post.Int64())
+				suite.Require().Equal(prev, suite.app.EvmKeeper.GetBalance(suite.address))
 			} else {
-				suite.Require().Equal(new(big.Int).Sub(prev, tc.amount).Int64(), post.Int64())
+				suite.Require().Equal(post, suite.app.EvmKeeper.GetBalance(suite.address))
 			}
 		})
 	}
@@ -176,7 +183,7 @@ func (suite *KeeperTestSuite) TestGetNonce() {
 		suite.Run(tc.name, func() {
 			tc.malleate()
 
-			nonce := suite.app.EvmKeeper.GetNonce(tc.address)
+			nonce := suite.StateDB().GetNonce(tc.address)
 			suite.Require().Equal(tc.expectedNonce, nonce)
 		})
 	}
@@ -205,9 +212,12 @@ func (suite *KeeperTestSuite) TestSetNonce() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			suite.app.EvmKeeper.SetNonce(tc.address, tc.nonce)
-			nonce := suite.app.EvmKeeper.GetNonce(tc.address)
-			suite.Require().Equal(tc.nonce, nonce)
+			vmdb := suite.StateDB()
+			vmdb.SetNonce(tc.address, tc.nonce)
+			suite.Require().Equal(tc.nonce, vmdb.GetNonce(tc.address))
+
+			suite.Require().NoError(vmdb.Commit())
+			suite.Require().Equal(tc.nonce, suite.app.EvmKeeper.GetNonce(tc.address))
 		})
 	}
 }
@@ -226,7 +236,7 @@ func (suite *KeeperTestSuite) TestGetCodeHash() {
 		{
 			"account not found",
 			tests.GenerateAddress(),
-			common.BytesToHash(types.EmptyCodeHash),
+			common.Hash{},
 			func() {},
 		},
 		{
@@ -249,7 +259,7 @@ func (suite *KeeperTestSuite) TestGetCodeHash() {
 		suite.Run(tc.name, func() {
 			tc.malleate()
 
-			hash := suite.app.EvmKeeper.GetCodeHash(tc.address)
+			hash := suite.StateDB().GetCodeHash(tc.address)
 			suite.Require().Equal(tc.expHash, hash)
 		})
 	}
@@ -275,7 +285,7 @@ func (suite *KeeperTestSuite) TestSetCode() {
 		{
 			"account not EthAccount type",
 			addr,
-			nil,
+			[]byte("code"),
 			true,
 		},
 		{
@@ -294,18 +304,24 @@ func (suite *KeeperTestSuite) TestSetCode() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			prev := suite.app.EvmKeeper.GetCode(tc.address)
-			suite.app.EvmKeeper.SetCode(tc.address, tc.code)
-			post := suite.app.EvmKeeper.GetCode(tc.address)
+			vmdb := suite.StateDB()
+			prev := vmdb.GetCode(tc.address)
+			vmdb.SetCode(tc.address, tc.code)
+			post := vmdb.GetCode(tc.address)
+
+			suite.Require().Equal(tc.code, post)
+			suite.Require().Equal(len(post), vmdb.GetCodeSize(tc.address))
 
+			// the code of the non ethereum accounts can't be set on the keeper
+			err := vmdb.Commit()
 			if tc.isNoOp {
-				suite.Require().Equal(prev, post)
+				suite.Require().Error(err)
+				suite.Require().Equal(prev, suite.app.EvmKeeper.GetCode(tc.address))
 			} else {
-				suite.Require().Equal(tc.code, post)
+				suite.Require().NoError(err)
+				suite.Require().Equal(post, suite.app.EvmKeeper.GetCode(tc.address))
 			}
 
-			suite.Require().Equal(len(post), suite.app.EvmKeeper.GetCodeSize(tc.address))
-
 			suite.app.EvmKeeper.ClearStateError()
 		})
 	}
@@ -314,21 +330,21 @@ func (suite *KeeperTestSuite) TestSetCode() {
 func (suite *KeeperTestSuite) TestRefund() {
 	testCases := []struct {
 		name      string
-		malleate  func()
+		malleate  func(vm.StateDB)
 		expRefund uint64
 		expPanic  bool
 	}{
 		{
 			"success - add and subtract refund",
-			func() {
-				suite.app.EvmKeeper.AddRefund(11)
+			func(vmdb vm.StateDB) {
+				vmdb.AddRefund(11)
 			},
 			1,
 			false,
 		},
 		{
 			"fail - subtract amount > current refund",
-			func() {
+			func(vm.StateDB) {
 			},
 			0,
 			true,
@@ -337,18 +353,18 @@ func (suite *KeeperTestSuite) TestRefund() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			tc.malleate()
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb)
 
 			if tc.expPanic {
-				suite.Require().Panics(func() { suite.app.EvmKeeper.SubRefund(10) })
+				suite.Require().Panics(func() { vmdb.SubRefund(10) })
 			} else {
-				suite.app.EvmKeeper.SubRefund(10)
-				suite.Require().Equal(tc.expRefund, suite.app.EvmKeeper.GetRefund())
+				vmdb.SubRefund(10)
+				suite.Require().Equal(tc.expRefund, vmdb.GetRefund())
 			}
 
-			// clear and reset refund from store
-			suite.app.EvmKeeper.ResetRefundTransient(suite.ctx)
-			suite.Require().Zero(suite.app.EvmKeeper.GetRefund())
+			// the refund counter isn't persisted across transactions
+			suite.Require().Zero(suite.StateDB().GetRefund())
 		})
 	}
 }
@@ -372,9 +388,13 @@ func (suite *KeeperTestSuite) TestState() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			suite.app.EvmKeeper.SetState(suite.address, tc.key, tc.value)
-			value := suite.app.EvmKeeper.GetState(suite.address, tc.key)
+			vmdb := suite.StateDB()
+			vmdb.SetState(suite.address, tc.key, tc.value)
+			value := vmdb.GetState(suite.address, tc.key)
 			suite.Require().Equal(tc.value, value)
+
+			suite.Require().NoError(vmdb.Commit())
+			suite.Require().Equal(tc.value, suite.app.EvmKeeper.GetState(suite.address, tc.key))
 		})
 	}
 }
@@ -386,23 +406,28 @@ func (suite *KeeperTestSuite) TestCommittedState() {
 	value1 := common.BytesToHash([]byte("value1"))
 	value2 := common.BytesToHash([]byte("value2"))
 
-	suite.app.EvmKeeper.SetState(suite.address, key, value1)
+	vmdb := suite.StateDB()
+	vmdb.SetState(suite.address, key, value1)
+	suite.Require().NoError(vmdb.Commit())
 
-	suite.app.EvmKeeper.Snapshot()
+	vmdb = suite.StateDB()
+	vmdb.Snapshot()
 
-	suite.app.EvmKeeper.SetState(suite.address, key, value2)
-	tmp := suite.app.EvmKeeper.GetState(suite.address, key)
+	vmdb.SetState(suite.address, key, value2)
+	tmp := vmdb.GetState(suite.address, key)
 	suite.Require().Equal(value2, tmp)
-	tmp = suite.app.EvmKeeper.GetCommittedState(suite.address, key)
+	tmp = vmdb.GetCommittedState(suite.address, key)
 	suite.Require().Equal(value1, tmp)
 
-	suite.app.EvmKeeper.CommitCachedContexts()
+	suite.Require().NoError(vmdb.Commit())
 
-	tmp = suite.app.EvmKeeper.GetCommittedState(suite.address, key)
+	tmp = suite.StateDB().GetCommittedState(suite.address, key)
 	suite.Require().Equal(value2, tmp)
 }
 
 func (suite *KeeperTestSuite) TestSuicide() {
+	vmdb := suite.StateDB()
+
 	testCases := []struct {
 		name     string
 		suicided bool
@@ -413,30 +438,37 @@ func (suite *KeeperTestSuite) TestSuicide() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			suite.Require().Equal(tc.suicided, suite.app.EvmKeeper.Suicide(suite.address))
-			suite.Require().Equal(tc.suicided, suite.app.EvmKeeper.HasSuicided(suite.address))
+			suite.Require().Equal(tc.suicided, vmdb.Suicide(suite.address))
+			suite.Require().Equal(tc.suicided, vmdb.HasSuicided(suite.address))
 		})
 	}
+
+	suite.Require().NoError(vmdb.Commit())
+	suite.Require().True(suite.app.EvmKeeper.HasSuicided(suite.address))
 }
 
 func (suite *KeeperTestSuite) TestExist() {
 	testCases := []struct {
 		name     string
 		address  common.Address
-		malleate func()
+		malleate func(vm.StateDB)
 		exists   bool
 	}{
-		{"success, account exists", suite.address, func() {}, true},
-		{"success, has suicided", suite.address, func() {
-			suite.app.EvmKeeper.Suicide(suite.address)
+		{"success, account exists", suite.address, func(vm.StateDB) {}, true},
+		{"success, has suicided", suite.address, func(vmdb vm.StateDB) {
+			vmdb.Suicide(suite.address)
 		}, true},
-		{"success, account doesn't exist", tests.GenerateAddress(), func() {}, false},
+		{"success, account doesn't exist", tests.GenerateAddress(), func(vm.StateDB) {}, false},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			tc.malleate()
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb)
+
+			suite.Require().Equal(tc.exists, vmdb.Exist(tc.address))
 
+			suite.Require().NoError(vmdb.Commit())
 			suite.Require().Equal(tc.exists, suite.app.EvmKeeper.Exist(tc.address))
 		})
 	}
@@ -450,22 +482,23 @@ func (suite *KeeperTestSuite) TestEmpty() {
 	testCases := []struct {
 		name     string
 		address  common.Address
-		malleate func()
+		malleate func(vm.StateDB)
 		empty    bool
 	}{
-		{"empty, account exists", suite.address, func() {}, true},
-		{"not empty, non ethereum account", addr, func() {}, false},
-		{"not empty, positive balance", suite.address, func() {
-			suite.app.EvmKeeper.AddBalance(suite.address, big.NewInt(100))
+		{"empty, account exists", suite.address, func(vm.StateDB) {}, true},
+		{"empty, non ethereum account", addr, func(vm.StateDB) {}, true},
+		{"not empty, positive balance", suite.address, func(vmdb vm.StateDB) {
+			vmdb.AddBalance(suite.address, big.NewInt(100))
 		}, false},
-		{"empty, account doesn't exist", tests.GenerateAddress(), func() {}, true},
+		{"empty, account doesn't exist", tests.GenerateAddress(), func(vm.StateDB) {}, true},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			tc.malleate()
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb)
 
-			suite.Require().Equal(tc.empty, suite.app.EvmKeeper.Empty(tc.address))
+			suite.Require().Equal(tc.empty, vmdb.Empty(tc.address))
 		})
 	}
 }
@@ -477,53 +510,56 @@ func (suite *KeeperTestSuite) TestSnapshot() {
 
 	testCases := []struct {
 		name     string
-		malleate func()
+		malleate func(vm.StateDB)
 	}{
-		{"simple revert", func() {
-			revision := suite.app.EvmKeeper.Snapshot()
+		{"simple revert", func(vmdb vm.StateDB) {
+			revision := vmdb.Snapshot()
 			suite.Require().Zero(revision)
 
-			suite.app.EvmKeeper.SetState(suite.address, key, value1)
-			suite.Require().Equal(value1, suite.app.EvmKeeper.GetState(suite.address, key))
+			vmdb.SetState(suite.address, key, value1)
+			suite.Require().Equal(value1, vmdb.GetState(suite.address, key))
 
-			suite.app.EvmKeeper.RevertToSnapshot(revision)
+			vmdb.RevertToSnapshot(revision)
 
 			// reverted
-			suite.Require().Equal(common.Hash{}, suite.app.EvmKeeper.GetState(suite.address, key))
+			suite.Require().Equal(common.Hash{}, vmdb.GetState(suite.address, key))
 		}},
-		{"nested snapshot/revert", func() {
-			revision1 := suite.app.EvmKeeper.Snapshot()
+		{"nested snapshot/revert", func(vmdb vm.StateDB) {
+			revision1 := vmdb.Snapshot()
 			suite.Require().Zero(revision1)
 
-			suite.app.EvmKeeper.SetState(suite.address, key, value1)
+			vmdb.SetState(suite.address, key, value1)
 
-			revision2 := suite.app.EvmKeeper.Snapshot()
+			revision2 := vmdb.Snapshot()
 
-			suite.app.EvmKeeper.SetState(suite.address, key, value2)
-			suite.Require().Equal(value2, suite.app.EvmKeeper.GetState(suite.address, key))
+			vmdb.SetState(suite.address, key, value2)
+			suite.Require().Equal(value2, vmdb.GetState(suite.address, key))
 
-			suite.app.EvmKeeper.RevertToSnapshot(revision2)
-			suite.Require().Equal(value1, suite.app.EvmKeeper.GetState(suite.address, key))
+			vmdb.RevertToSnapshot(revision2)
+			suite.Require().Equal(value1, vmdb.GetState(suite.address, key))
 
-			suite.app.EvmKeeper.RevertToSnapshot(revision1)
-			suite.Require().Equal(common.Hash{}, suite.app.EvmKeeper.GetState(suite.address, key))
+			vmdb.RevertToSnapshot(revision1)
+			suite.Require().Equal(common.Hash{}, vmdb.GetState(suite.address, key))
 		}},
-		{"jump revert", func() {
-			revision1 := suite.app.EvmKeeper.Snapshot()
-			suite.app.EvmKeeper.SetState(suite.address, key, value1)
-			suite.app.EvmKeeper.Snapshot()
-			suite.app.EvmKeeper.SetState(suite.address, key, value2)
-			suite.app.EvmKeeper.RevertToSnapshot(revision1)
-			suite.Require().Equal(common.Hash{}, suite.app.EvmKeeper.GetState(suite.address, key))
+		{"jump revert", func(vmdb vm.StateDB) {
+			revision1 := vmdb.Snapshot()
+			vmdb.SetState(suite.address, key, value1)
+			vmdb.Snapshot()
+			vmdb.SetState(suite.address, key, value2)
+			vmdb.RevertToSnapshot(revision1)
+			suite.Require().Equal(common.Hash{}, vmdb.GetState(suite.address, key))
 		}},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			suite.SetupTest()
-			tc.malleate()
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb)
+
 			// the test case should finish in clean state
-			suite.Require().True(suite.app.EvmKeeper.CachedContextsEmpty())
+			suite.Require().NoError(vmdb.Commit())
+			suite.Require().Equal(common.Hash{}, suite.app.EvmKeeper.GetState(suite.address, key))
 		})
 	}
 }
@@ -608,8 +644,12 @@ func (suite *KeeperTestSuite) TestAddLog() {
 			suite.SetupTest()
 			tc.malleate()
 
+			// the log fields are set by the keeper when the StateDB is committed
+			vmdb := suite.StateDB()
+			vmdb.AddLog(tc.log)
 			suite.app.EvmKeeper.SetTxHashTransient(tc.hash)
-			suite.app.EvmKeeper.AddLog(tc.log)
+			suite.Require().NoError(vmdb.Commit())
+
 			logs := suite.app.EvmKeeper.GetTxLogsTransient(tc.hash)
 			suite.Require().Equal(1, len(logs))
 			suite.Require().Equal(tc.expLog, logs[0])
@@ -625,18 +665,19 @@ func (suite *KeeperTestSuite) TestPrepareAccessList() {
 		{Address: tests.GenerateAddress(), StorageKeys: []common.Hash{common.BytesToHash([]byte("key1"))}},
 	}
 
-	suite.app.EvmKeeper.PrepareAccessList(suite.address, &dest, precompiles, accesses)
+	vmdb := suite.StateDB()
+	vmdb.PrepareAccessList(suite.address, &dest, precompiles, accesses)
 
-	suite.Require().True(suite.app.EvmKeeper.AddressInAccessList(suite.address))
-	suite.Require().True(suite.app.EvmKeeper.AddressInAccessList(dest))
+	suite.Require().True(vmdb.AddressInAccessList(suite.address))
+	suite.Require().True(vmdb.AddressInAccessList(dest))
 
 	for _, precompile := range precompiles {
-		suite.Require().True(suite.app.EvmKeeper.AddressInAccessList(precompile))
+		suite.Require().True(vmdb.AddressInAccessList(precompile))
 	}
 
 	for _, access := range accesses {
 		for _, key := range access.StorageKeys {
-			addrOK, slotOK := suite.app.EvmKeeper.SlotInAccessList(access.Address, key)
+			addrOK, slotOK := vmdb.SlotInAccessList(access.Address, key)
 			suite.Require().True(addrOK, access.Address.Hex())
 			suite.Require().True(slotOK, key.Hex())
 		}
@@ -654,14 +695,15 @@ func (suite *KeeperTestSuite) TestAddAddressToAccessList() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			suite.app.EvmKeeper.AddAddressToAccessList(tc.addr)
-			addrOk := suite.app.EvmKeeper.AddressInAccessList(tc.addr)
+			vmdb := suite.StateDB()
+			vmdb.AddAddressToAccessList(tc.addr)
+			addrOk := vmdb.AddressInAccessList(tc.addr)
 			suite.Require().True(addrOk, tc.addr.Hex())
 		})
 	}
 }
 
-func (suite *KeeperTestSuite) AddSlotToAccessList() {
+func (suite *KeeperTestSuite) TestAddSlotToAccessList() {
 	testCases := []struct {
 		name string
 		addr common.Address
@@ -675,8 +717,9 @@ func (suite *KeeperTestSuite) AddSlotToAccessList() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			suite.app.EvmKeeper.AddSlotToAccessList(tc.addr, tc.slot)
-			addrOk, slotOk := suite.app.EvmKeeper.SlotInAccessList(tc.addr, tc.slot)
+			vmdb := suite.StateDB()
+			vmdb.AddSlotToAccessList(tc.addr, tc.slot)
+			addrOk, slotOk := vmdb.SlotInAccessList(tc.addr, tc.slot)
 			suite.Require().True(addrOk, tc.addr.Hex())
 			suite.Require().True(slotOk, tc.slot.Hex())
 		})
@@ -688,15 +731,15 @@ func (suite *KeeperTestSuite) TestForEachStorage() {
 
 	testCase := []struct {
 		name      string
-		malleate  func()
+		malleate  func(vm.StateDB)
 		callback  func(key, value common.Hash) (stop bool)
 		expValues []common.Hash
 	}{
 		{
 			"aggregate state",
-			func() {
+			func(vmdb vm.StateDB) {
 				for i := 0; i < 5; i++ {
-					suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte(fmt.Sprintf("key%d", i))), common.BytesToHash([]byte(fmt.Sprintf("value%d", i))))
+					vmdb.SetState(suite.address, common.BytesToHash([]byte(fmt.Sprintf("key%d", i))), common.BytesToHash([]byte(fmt.Sprintf("value%d", i))))
 				}
 			},
 			func(key, value common.Hash) bool {
@@ -713,9 +756,9 @@ func (suite *KeeperTestSuite) TestForEachStorage() {
 		},
 		{
 			"filter state",
-			func() {
-				suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
-				suite.app.EvmKeeper.SetState(suite.address, common.BytesToHash([]byte("filterkey")), common.BytesToHash([]byte("filtervalue")))
+			func(vmdb vm.StateDB) {
+				vmdb.SetState(suite.address, common.BytesToHash([]byte("key")), common.BytesToHash([]byte("value")))
+				vmdb.SetState(suite.address, common.BytesToHash([]byte("filterkey")), common.BytesToHash([]byte("filtervalue")))
 			},
 			func(key, value common.Hash) bool {
 				if value == common.BytesToHash([]byte("filtervalue")) {
@@ -733,9 +776,10 @@ func (suite *KeeperTestSuite) TestForEachStorage() {
 	for _, tc := range testCase {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
-			tc.malleate()
+			vmdb := suite.StateDB()
+			tc.malleate(vmdb)
 
-			err := suite.app.EvmKeeper.ForEachStorage(suite.address, tc.callback)
+			err := vmdb.ForEachStorage(suite.address, tc.callback)
 			suite.Require().NoError(err)
 			suite.Require().Equal(len(tc.expValues), len(storage), fmt.Sprintf("Expected values:\n%v\nStorage Values\n%v", tc.expValues, storage))
 
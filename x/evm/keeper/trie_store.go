@@ -0,0 +1,178 @@
+package keeper
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+var (
+	_ ethdb.KeyValueStore = trieStore{}
+	_ ethdb.Batch         = &trieBatch{}
+	_ ethdb.Iterator      = &trieIterator{}
+)
+
+// errTrieNodeNotFound is returned when a key is not found on the trie store.
+var errTrieNodeNotFound = errors.New("not found")
+
+// trieStore adapts a KVStore to the go-ethereum key-value database used to persist the state trie
+// nodes.
+type trieStore struct {
+	store sdk.KVStore
+}
+
+// Has implements ethdb.KeyValueReader.
+func (s trieStore) Has(key []byte) (bool, error) {
+	return s.store.Has(key), nil
+}
+
+// Get implements ethdb.KeyValueReader.
+func (s trieStore) Get(key []byte) ([]byte, error) {
+	value := s.store.Get(key)
+	if value == nil {
+		return nil, errTrieNodeNotFound
+	}
+	return value, nil
+}
+
+// Put implements ethdb.KeyValueWriter.
+func (s trieStore) Put(key []byte, value []byte) error {
+	s.store.Set(key, value)
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter.
+func (s trieStore) Delete(key []byte) error {
+	s.store.Delete(key)
+	return nil
+}
+
+// NewBatch implements ethdb.Batcher.
+func (s trieStore) NewBatch() ethdb.Batch {
+	return &trieBatch{store: s.store}
+}
+
+// NewIterator implements ethdb.Iteratee.
+func (s trieStore) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	return &trieIterator{
+		iterator: s.store.Iterator(append(append([]byte{}, prefix...), start...), sdk.PrefixEndBytes(prefix)),
+	}
+}
+
+// Stat implements ethdb.Stater.
+func (s trieStore) Stat(string) (string, error) {
+	return "", nil
+}
+
+// Compact implements ethdb.Compacter. The store is compacted by the underlying database.
+func (s trieStore) Compact([]byte, []byte) error {
+	return nil
+}
+
+// Close implements io.Closer. The store lifecycle is managed by the multistore.
+func (s trieStore) Close() error {
+	return nil
+}
+
+type trieBatchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// trieBatch buffers the writes to the trie store until Write is called.
+type trieBatch struct {
+	store sdk.KVStore
+	ops   []trieBatchOp
+	size  int
+}
+
+// Put implements ethdb.KeyValueWriter.
+func (b *trieBatch) Put(key []byte, value []byte) error {
+	b.ops = append(b.ops, trieBatchOp{key: append([]byte{}, key...), value: append([]byte{}, value...)})
+	b.size += len(value)
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter.
+func (b *trieBatch) Delete(key []byte) error {
+	b.ops = append(b.ops, trieBatchOp{key: append([]byte{}, key...), delete: true})
+	b.size += len(key)
+	return nil
+}
+
+// ValueSize implements ethdb.Batch.
+func (b *trieBatch) ValueSize() int {
+	return b.size
+}
+
+// Write implements ethdb.Batch.
+func (b *trieBatch) Write() error {
+	return b.Replay(trieStore{store: b.store})
+}
+
+// Reset implements ethdb.Batch.
+func (b *trieBatch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+// Replay implements ethdb.Batch.
+func (b *trieBatch) Replay(w ethdb.KeyValueWriter) error {
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = w.Delete(op.key)
+		} else {
+			err = w.Put(op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trieIterator wraps a KVStore iterator as an ethdb.Iterator.
+type trieIterator struct {
+	iterator sdk.Iterator
+	started  bool
+}
+
+// Next implements ethdb.Iterator.
+func (it *trieIterator) Next() bool {
+	if !it.started {
+		it.started = true
+	} else if it.iterator.Valid() {
+		it.iterator.Next()
+	}
+	return it.iterator.Valid()
+}
+
+// Error implements ethdb.Iterator.
+func (it *trieIterator) Error() error {
+	return it.iterator.Error()
+}
+
+// Key implements ethdb.Iterator.
+func (it *trieIterator) Key() []byte {
+	if !it.iterator.Valid() {
+		return nil
+	}
+	return it.iterator.Key()
+}
+
+// Value implements ethdb.Iterator.
+func (it *trieIterator) Value() []byte {
+	if !it.iterator.Valid() {
+		return nil
+	}
+	return it.iterator.Value()
+}
+
+// Release implements ethdb.Iterator.
+func (it *trieIterator) Release() {
+	it.iterator.Close()
+}
@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// ParamStoreKeyChainConfig is the key of the chain config on the evm param space
+// before it was moved to the evm store on version 2.
+var ParamStoreKeyChainConfig = []byte("ChainConfig")
+
+// MigrateStore performs in-place store migrations from version 1 to 2. The migration
+// moves the chain config from the evm param space to its own key on the evm store and
+// sets the EnableStateTrie and InvariantFullScan parameters, which are disabled by default.
+//
+// NOTE: the legacy chain config value is not removed from the params store, as the
+// params module doesn't support deleting keys. It is unreachable once the key is no
+// longer registered on the param key table.
+func MigrateStore(ctx sdk.Context, storeKey sdk.StoreKey, paramSpace paramtypes.Subspace, cdc codec.BinaryCodec) error {
+	bz := paramSpace.GetRaw(ctx, ParamStoreKeyChainConfig)
+	if len(bz) == 0 {
+		return fmt.Errorf("chain config not found on the %s param space", paramSpace.Name())
+	}
+
+	// param values are encoded with amino JSON
+	var chainConfig types.ChainConfig
+	if err := codec.NewLegacyAmino().UnmarshalJSON(bz, &chainConfig); err != nil {
+		return fmt.Errorf("failed to unmarshal legacy chain config: %w", err)
+	}
+
+	if err := chainConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid legacy chain config: %w", err)
+	}
+
+	ctx.KVStore(storeKey).Set(types.KeyPrefixChainConfig, cdc.MustMarshal(&chainConfig))
+
+	if !paramSpace.Has(ctx, types.ParamStoreKeyEnableStateTrie) {
+		paramSpace.Set(ctx, types.ParamStoreKeyEnableStateTrie, false)
+	}
+
+	if !paramSpace.Has(ctx, types.ParamStoreKeyInvariantFullScan) {
+		paramSpace.Set(ctx, types.ParamStoreKeyInvariantFullScan, false)
+	}
+
+	return nil
+}
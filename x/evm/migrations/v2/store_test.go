@@ -0,0 +1,90 @@
+package v2_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/app"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+	v2 "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/migrations/v2"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// setupV1 initializes the evm module from the exported genesis fixture and reverts
+// the store to the version 1 layout, with the chain config stored on the param space.
+func setupV1(t *testing.T) (*app.EthermintApp, sdk.Context, types.GenesisState) {
+	ethermintApp := app.Setup(false)
+	ctx := ethermintApp.BaseApp.NewContext(false, tmproto.Header{ChainID: "ethermint_9000-1"})
+
+	bz, err := ioutil.ReadFile("testdata/genesis_v1.json")
+	require.NoError(t, err)
+
+	var genesis types.GenesisState
+	ethermintApp.AppCodec().MustUnmarshalJSON(bz, &genesis)
+
+	for _, account := range genesis.Accounts {
+		address := common.HexToAddress(account.Address)
+		ethermintApp.AccountKeeper.SetAccount(ctx, &ethermint.EthAccount{
+			BaseAccount: authtypes.NewBaseAccountWithAddress(address.Bytes()),
+			CodeHash:    crypto.Keccak256Hash(common.Hex2Bytes(account.Code)).Hex(),
+		})
+	}
+
+	evm.InitGenesis(ctx, ethermintApp.EvmKeeper, ethermintApp.AccountKeeper, genesis)
+
+	// the legacy key table is needed to set the chain config on the param space
+	legacySubspace := paramtypes.NewSubspace(
+		ethermintApp.AppCodec(), ethermintApp.LegacyAmino(),
+		ethermintApp.GetKey(paramtypes.StoreKey), ethermintApp.GetTKey(paramtypes.TStoreKey), types.ModuleName,
+	).WithKeyTable(paramtypes.NewKeyTable(
+		paramtypes.NewParamSetPair(v2.ParamStoreKeyChainConfig, &types.ChainConfig{}, func(interface{}) error { return nil }),
+	))
+	legacySubspace.Set(ctx, v2.ParamStoreKeyChainConfig, genesis.Params.ChainConfig)
+
+	ctx.KVStore(ethermintApp.GetKey(types.StoreKey)).Delete(types.KeyPrefixChainConfig)
+
+	// the enable state trie and invariant full scan parameters are not set on version 1
+	paramStore := ctx.KVStore(ethermintApp.GetKey(paramtypes.StoreKey))
+	for _, key := range [][]byte{types.ParamStoreKeyEnableStateTrie, types.ParamStoreKeyInvariantFullScan} {
+		paramStore.Delete(append([]byte(types.ModuleName+"/"), key...))
+		require.False(t, ethermintApp.GetSubspace(types.ModuleName).Has(ctx, key))
+	}
+
+	return ethermintApp, ctx, genesis
+}
+
+func TestMigrateStore(t *testing.T) {
+	ethermintApp, ctx, genesis := setupV1(t)
+
+	m := keeper.NewMigrator(*ethermintApp.EvmKeeper)
+	require.NoError(t, m.Migrate1to2(ctx))
+
+	require.Equal(t, genesis.Params.ChainConfig, ethermintApp.EvmKeeper.GetChainConfig(ctx))
+	require.True(t, ethermintApp.GetSubspace(types.ModuleName).Has(ctx, types.ParamStoreKeyEnableStateTrie))
+	require.True(t, ethermintApp.GetSubspace(types.ModuleName).Has(ctx, types.ParamStoreKeyInvariantFullScan))
+
+	exported := evm.ExportGenesis(ctx, ethermintApp.EvmKeeper, ethermintApp.AccountKeeper)
+	require.Equal(t, genesis.Params, exported.Params)
+	require.Equal(t, genesis.Accounts, exported.Accounts)
+}
+
+func TestMigrateStoreChainConfigNotFound(t *testing.T) {
+	ethermintApp := app.Setup(false)
+	ctx := ethermintApp.BaseApp.NewContext(false, tmproto.Header{ChainID: "ethermint_9000-1"})
+
+	err := v2.MigrateStore(
+		ctx, ethermintApp.GetKey(types.StoreKey), ethermintApp.GetSubspace(types.ModuleName), ethermintApp.AppCodec(),
+	)
+	require.Error(t, err)
+}
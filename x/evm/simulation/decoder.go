@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// NewDecodeStore returns a decoder function closure that unmarshals the KVPair's
+// Value to the corresponding evm type.
+func NewDecodeStore() func(kvA, kvB kv.Pair) string {
+	return func(kvA, kvB kv.Pair) string {
+		switch {
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixCode):
+			codeHash := common.BytesToHash(kvA.Key[1:])
+			return fmt.Sprintf("Code hash %s\n%X\n%X", codeHash, kvA.Value, kvB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixStorage):
+			address := common.BytesToAddress(kvA.Key[1 : 1+common.AddressLength])
+			key := common.BytesToHash(kvA.Key[1+common.AddressLength:])
+			return fmt.Sprintf("Storage of %s at %s\n%s\n%s", address, key, common.BytesToHash(kvA.Value), common.BytesToHash(kvB.Value))
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixChainConfig):
+			var chainConfigA, chainConfigB types.ChainConfig
+			if err := chainConfigA.Unmarshal(kvA.Value); err != nil {
+				panic(err)
+			}
+			if err := chainConfigB.Unmarshal(kvB.Value); err != nil {
+				panic(err)
+			}
+			return fmt.Sprintf("%v\n%v", chainConfigA, chainConfigB)
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixStateTrie):
+			nodeHash := common.BytesToHash(kvA.Key[1:])
+			return fmt.Sprintf("State trie node %s\n%X\n%X", nodeHash, kvA.Value, kvB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixStateRoot):
+			return fmt.Sprintf("State root\n%s\n%s", common.BytesToHash(kvA.Value), common.BytesToHash(kvB.Value))
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixStateTrieRefs):
+			nodeHash := common.BytesToHash(kvA.Key[1:])
+			return fmt.Sprintf(
+				"State trie node %s references\n%d\n%d",
+				nodeHash, sdk.BigEndianToUint64(kvA.Value), sdk.BigEndianToUint64(kvB.Value),
+			)
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixStateTrieBuild):
+			return fmt.Sprintf("State trie build\n%X\n%X", kvA.Value, kvB.Value)
+
+		default:
+			panic(fmt.Sprintf("invalid evm key prefix %X", kvA.Key[:1]))
+		}
+	}
+}
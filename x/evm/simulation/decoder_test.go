@@ -0,0 +1,73 @@
+package simulation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/tests"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/simulation"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+func TestDecodeStore(t *testing.T) {
+	dec := simulation.NewDecodeStore()
+
+	address := tests.GenerateAddress()
+	code := []byte{1, 2, 3}
+	codeHash := crypto.Keccak256Hash(code)
+	key := common.BytesToHash([]byte("key"))
+	value := common.BytesToHash([]byte("value"))
+
+	node := []byte{4, 5, 6}
+	nodeHash := crypto.Keccak256Hash(node)
+
+	chainConfig := types.DefaultChainConfig()
+	chainConfigBz, err := chainConfig.Marshal()
+	require.NoError(t, err)
+
+	kvPairs := kv.Pairs{
+		Pairs: []kv.Pair{
+			{Key: append(types.KeyPrefixCode, codeHash.Bytes()...), Value: code},
+			{Key: types.StateKey(address, key.Bytes()), Value: value.Bytes()},
+			{Key: types.KeyPrefixChainConfig, Value: chainConfigBz},
+			{Key: append(types.KeyPrefixStateTrie, nodeHash.Bytes()...), Value: node},
+			{Key: types.KeyPrefixStateRoot, Value: nodeHash.Bytes()},
+			{Key: append(types.KeyPrefixStateTrieRefs, nodeHash.Bytes()...), Value: sdk.Uint64ToBigEndian(2)},
+			{Key: types.KeyPrefixStateTrieBuild, Value: nodeHash.Bytes()},
+			{Key: []byte{0x99}, Value: []byte{0x99}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		expectedLog string
+	}{
+		{"Code", fmt.Sprintf("Code hash %s\n%X\n%X", codeHash, code, code)},
+		{"Storage", fmt.Sprintf("Storage of %s at %s\n%s\n%s", address, key, value, value)},
+		{"ChainConfig", fmt.Sprintf("%v\n%v", chainConfig, chainConfig)},
+		{"StateTrie", fmt.Sprintf("State trie node %s\n%X\n%X", nodeHash, node, node)},
+		{"StateRoot", fmt.Sprintf("State root\n%s\n%s", nodeHash, nodeHash)},
+		{"StateTrieRefs", fmt.Sprintf("State trie node %s references\n2\n2", nodeHash)},
+		{"StateTrieBuild", fmt.Sprintf("State trie build\n%X\n%X", nodeHash.Bytes(), nodeHash.Bytes())},
+		{"other", ""},
+	}
+	for i, tt := range testCases {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			switch i {
+			case len(testCases) - 1:
+				require.Panics(t, func() { dec(kvPairs.Pairs[i], kvPairs.Pairs[i]) }, tt.name)
+			default:
+				require.Equal(t, tt.expectedLog, dec(kvPairs.Pairs[i], kvPairs.Pairs[i]), tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// Simulation parameter constants
+const (
+	EnableCreate    = "enable_create"
+	EnableCall      = "enable_call"
+	ExtraEIPs       = "extra_eips"
+	BerlinBlock     = "berlin_block"
+	LondonBlock     = "london_block"
+	EnableStateTrie = "enable_state_trie"
+
+	InvariantFullScan = "invariant_full_scan"
+)
+
+// GenEnableCreate randomized EnableCreate, contract creation is disabled 10% of the time
+func GenEnableCreate(r *rand.Rand) bool {
+	return r.Intn(100) >= 10
+}
+
+// GenEnableCall randomized EnableCall, contract calls are disabled 10% of the time
+func GenEnableCall(r *rand.Rand) bool {
+	return r.Intn(100) >= 10
+}
+
+// GenExtraEIPs randomized ExtraEIPs, as a random subset of the available extra EIPs
+func GenExtraEIPs(r *rand.Rand) []int64 {
+	var eips []int64
+	for _, eip := range types.AvailableExtraEIPs {
+		if r.Intn(2) == 0 {
+			eips = append(eips, eip)
+		}
+	}
+	return eips
+}
+
+// GenEnableStateTrie randomized EnableStateTrie, the state trie is maintained half of the time
+func GenEnableStateTrie(r *rand.Rand) bool {
+	return r.Intn(2) == 0
+}
+
+// GenInvariantFullScan randomized InvariantFullScan, the invariants check all the accounts 10% of the time
+func GenInvariantFullScan(r *rand.Rand) bool {
+	return r.Intn(100) < 10
+}
+
+// GenForkBlock randomized fork activation height. The fork is activated at genesis half of the
+// time, and within the first 100 blocks otherwise.
+func GenForkBlock(r *rand.Rand) int64 {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return int64(r.Intn(100))
+}
+
+// RandomizedGenState generates a random GenesisState for the evm module
+func RandomizedGenState(simState *module.SimulationState) {
+	var enableCreate bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, EnableCreate, &enableCreate, simState.Rand,
+		func(r *rand.Rand) { enableCreate = GenEnableCreate(r) },
+	)
+
+	var enableCall bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, EnableCall, &enableCall, simState.Rand,
+		func(r *rand.Rand) { enableCall = GenEnableCall(r) },
+	)
+
+	var extraEIPs []int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, ExtraEIPs, &extraEIPs, simState.Rand,
+		func(r *rand.Rand) { extraEIPs = GenExtraEIPs(r) },
+	)
+
+	var berlinBlock int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, BerlinBlock, &berlinBlock, simState.Rand,
+		func(r *rand.Rand) { berlinBlock = GenForkBlock(r) },
+	)
+
+	// London must be activated after Berlin
+	var londonBlock int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, LondonBlock, &londonBlock, simState.Rand,
+		func(r *rand.Rand) { londonBlock = berlinBlock + GenForkBlock(r) },
+	)
+
+	var enableStateTrie bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, EnableStateTrie, &enableStateTrie, simState.Rand,
+		func(r *rand.Rand) { enableStateTrie = GenEnableStateTrie(r) },
+	)
+
+	var invariantFullScan bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, InvariantFullScan, &invariantFullScan, simState.Rand,
+		func(r *rand.Rand) { invariantFullScan = GenInvariantFullScan(r) },
+	)
+
+	chainConfig := types.DefaultChainConfig()
+	berlin := sdk.NewInt(berlinBlock)
+	london := sdk.NewInt(londonBlock)
+	chainConfig.BerlinBlock = &berlin
+	chainConfig.LondonBlock = &london
+
+	// the EVM denomination must match the one of the simulation accounts balances
+	params := types.NewParams(sdk.DefaultBondDenom, enableCreate, enableCall, chainConfig, extraEIPs...)
+	params.EnableStateTrie = enableStateTrie
+	params.InvariantFullScan = invariantFullScan
+	evmGenesis := types.NewGenesisState(params, []types.GenesisAccount{})
+
+	bz, err := json.MarshalIndent(evmGenesis, "", " ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Selected randomly generated %s parameters:\n%s\n", types.ModuleName, bz)
+
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(evmGenesis)
+}
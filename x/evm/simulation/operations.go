@@ -0,0 +1,307 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/big"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/encoding"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/testutil/contracts"
+	ethermint "github.com/Electronic-Signatures-Industries/ancon-evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/keeper"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgEthSimpleTransfer = "op_weight_msg_eth_simple_transfer" // nolint: gosec
+	OpWeightMsgEthCreateContract = "op_weight_msg_eth_create_contract" // nolint: gosec
+)
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgEthSimpleTransfer = 100
+	DefaultWeightMsgEthCreateContract = 50
+)
+
+// gasCap defines the gas cap used to estimate the gas of the simulated transactions
+const gasCap = 25_000_000
+
+// erc20Methods defines the ERC20Contract methods called by the simulation, along with a function
+// that returns random arguments for them.
+var erc20Methods = []struct {
+	name string
+	args func(r *rand.Rand, owner, recipient common.Address) []interface{}
+}{
+	{"transfer", func(r *rand.Rand, _, recipient common.Address) []interface{} {
+		return []interface{}{recipient, big.NewInt(r.Int63n(1000))}
+	}},
+	{"approve", func(r *rand.Rand, _, recipient common.Address) []interface{} {
+		return []interface{}{recipient, big.NewInt(r.Int63n(1000))}
+	}},
+	{"mint", func(r *rand.Rand, _, recipient common.Address) []interface{} {
+		return []interface{}{recipient, big.NewInt(r.Int63n(1000))}
+	}},
+	{"benchmarkLogs", func(r *rand.Rand, _, _ common.Address) []interface{} {
+		return []interface{}{big.NewInt(r.Int63n(10))}
+	}},
+}
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	appParams simtypes.AppParams, cdc codec.JSONCodec, ak types.AccountKeeper, k *keeper.Keeper,
+) simulation.WeightedOperations {
+	var weightMsgEthSimpleTransfer int
+	appParams.GetOrGenerate(cdc, OpWeightMsgEthSimpleTransfer, &weightMsgEthSimpleTransfer, nil,
+		func(_ *rand.Rand) {
+			weightMsgEthSimpleTransfer = DefaultWeightMsgEthSimpleTransfer
+		},
+	)
+
+	var weightMsgEthCreateContract int
+	appParams.GetOrGenerate(cdc, OpWeightMsgEthCreateContract, &weightMsgEthCreateContract, nil,
+		func(_ *rand.Rand) {
+			weightMsgEthCreateContract = DefaultWeightMsgEthCreateContract
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgEthSimpleTransfer,
+			SimulateEthSimpleTransfer(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgEthCreateContract,
+			SimulateEthCreateContract(ak, k),
+		),
+	}
+}
+
+// SimulateEthSimpleTransfer simulates an Ethereum transfer of a random amount of the EVM
+// denomination between two random accounts.
+func SimulateEthSimpleTransfer(ak types.AccountKeeper, k *keeper.Keeper) simtypes.Operation {
+	return func(
+		r *rand.Rand, bapp *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string,
+	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		from, ok := randomEthAccount(r, accs)
+		if !ok {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "no account with an ethsecp256k1 key"), nil, nil
+		}
+
+		to, _ := simtypes.RandomAcc(r, accs)
+		recipient := common.BytesToAddress(to.Address)
+
+		k.WithContext(ctx)
+		balance := k.GetBalance(common.BytesToAddress(from.Address))
+		if balance.Sign() <= 0 {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "sender has no balance"), nil, nil
+		}
+
+		// keep most of the balance to pay for the fees
+		amount, err := simtypes.RandPositiveInt(r, sdk.NewIntFromBigInt(balance).QuoRaw(10).AddRaw(1))
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, err.Error()), nil, nil
+		}
+
+		opMsg, err := deliverEthTx(r, bapp, ctx, ak, k, chainID, from, &recipient, amount.BigInt(), nil)
+		return opMsg, nil, err
+	}
+}
+
+// SimulateEthCreateContract simulates the deployment of the ERC20Contract by a random account. The
+// deployer receives the initial supply of tokens and calls a random contract method on the next
+// block.
+func SimulateEthCreateContract(ak types.AccountKeeper, k *keeper.Keeper) simtypes.Operation {
+	return func(
+		r *rand.Rand, bapp *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string,
+	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		from, ok := randomEthAccount(r, accs)
+		if !ok {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "no account with an ethsecp256k1 key"), nil, nil
+		}
+
+		owner := common.BytesToAddress(from.Address)
+		supply := big.NewInt(r.Int63n(1_000_000) + 1)
+
+		ctorArgs, err := contracts.ERC20Contract.ABI.Pack("", owner, supply)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to pack constructor arguments"), nil, err
+		}
+
+		data := append(append([]byte{}, contracts.ERC20Contract.Bin...), ctorArgs...)
+
+		nonce, err := ak.GetSequence(ctx, from.Address)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "sender account not found"), nil, nil
+		}
+
+		opMsg, err := deliverEthTx(r, bapp, ctx, ak, k, chainID, from, nil, nil, data)
+		if err != nil || !opMsg.OK {
+			return opMsg, nil, err
+		}
+
+		contract := crypto.CreateAddress(owner, nonce)
+		futureOps := []simtypes.FutureOperation{
+			{
+				BlockHeight: int(ctx.BlockHeight()) + 1,
+				Op:          SimulateEthCallContract(ak, k, from, contract),
+			},
+		}
+
+		return opMsg, futureOps, nil
+	}
+}
+
+// SimulateEthCallContract simulates a call to a random method of an ERC20Contract deployed by the
+// given account.
+func SimulateEthCallContract(ak types.AccountKeeper, k *keeper.Keeper, owner simtypes.Account, contract common.Address) simtypes.Operation {
+	return func(
+		r *rand.Rand, bapp *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string,
+	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		recipient, _ := simtypes.RandomAcc(r, accs)
+		method := erc20Methods[r.Intn(len(erc20Methods))]
+
+		args := method.args(r, common.BytesToAddress(owner.Address), common.BytesToAddress(recipient.Address))
+		data, err := contracts.ERC20Contract.ABI.Pack(method.name, args...)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to pack method arguments"), nil, err
+		}
+
+		opMsg, err := deliverEthTx(r, bapp, ctx, ak, k, chainID, owner, &contract, nil, data)
+		return opMsg, nil, err
+	}
+}
+
+// deliverEthTx signs the Ethereum transaction with the private key of the given account and
+// delivers it to the app. Transactions that can't be executed on the current state (disabled
+// by the module parameters, not enough balance to pay for the fees, etc) are skipped.
+func deliverEthTx(
+	r *rand.Rand, bapp *baseapp.BaseApp, ctx sdk.Context, ak types.AccountKeeper, k *keeper.Keeper, chainID string,
+	from simtypes.Account, to *common.Address, amount *big.Int, data []byte,
+) (simtypes.OperationMsg, error) {
+	params := k.GetParams(ctx)
+	switch {
+	case to == nil && !params.EnableCreate:
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "contract creation is disabled"), nil
+	case to != nil && !params.EnableCall:
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "calls are disabled"), nil
+	}
+
+	eip155ChainID, err := ethermint.ParseChainID(chainID)
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "invalid chain-id"), err
+	}
+
+	sender := common.BytesToAddress(from.Address)
+
+	nonce, err := ak.GetSequence(ctx, from.Address)
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "sender account not found"), nil
+	}
+
+	callArgs := types.CallArgs{
+		From: &sender,
+		To:   to,
+		Data: (*hexutil.Bytes)(&data),
+	}
+	if amount != nil {
+		callArgs.Value = (*hexutil.Big)(amount)
+	}
+
+	args, err := json.Marshal(&callArgs)
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to marshal call args"), err
+	}
+
+	// estimate the gas on a branch of the state, as the query doesn't revert its changes
+	queryCtx, _ := ctx.CacheContext()
+	res, err := k.EstimateGas(sdk.WrapSDKContext(queryCtx), &types.EthCallRequest{Args: args, GasCap: gasCap})
+	if err != nil {
+		// the transaction fails on the current state (eg: reverted contract call)
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to estimate gas"), nil
+	}
+
+	gasPrice := big.NewInt(r.Int63n(10) + 1)
+	fees := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(res.Gas))
+
+	cost := new(big.Int).Set(fees)
+	if amount != nil {
+		cost.Add(cost, amount)
+	}
+
+	k.WithContext(ctx)
+	if k.GetBalance(sender).Cmp(cost) < 0 {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "not enough balance to pay for the transaction"), nil
+	}
+
+	key, err := from.PrivKey.(*ethsecp256k1.PrivKey).ToECDSA()
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "invalid private key"), err
+	}
+
+	msg := types.NewTx(eip155ChainID, nonce, to, amount, res.Gas, gasPrice, data, nil)
+	tx, err := ethtypes.SignTx(msg.AsTransaction(), ethtypes.LatestSignerForChainID(eip155ChainID), key)
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to sign transaction"), err
+	}
+
+	msg.FromEthereumTx(tx)
+	msg.From = sender.Hex()
+
+	txConfig := encoding.MakeConfig(module.NewBasicManager()).TxConfig
+	builder, ok := txConfig.NewTxBuilder().(authtx.ExtensionOptionsTxBuilder)
+	if !ok {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "unsupported tx builder"), nil
+	}
+
+	option, err := codectypes.NewAnyWithValue(&types.ExtensionOptionsEthereumTx{})
+	if err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to pack extension options"), err
+	}
+
+	builder.SetExtensionOptions(option)
+	if err := builder.SetMsgs(msg); err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "failed to set messages"), err
+	}
+
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(params.EvmDenom, sdk.NewIntFromBigInt(fees))))
+	builder.SetGasLimit(res.Gas)
+
+	if _, _, err := bapp.Deliver(txConfig.TxEncoder(), builder.GetTx()); err != nil {
+		return simtypes.NoOpMsg(types.ModuleName, types.TypeMsgEthereumTx, "unable to deliver tx"), err
+	}
+
+	return simtypes.NewOperationMsgBasic(types.RouterKey, types.TypeMsgEthereumTx, "", true, nil), nil
+}
+
+// randomEthAccount returns a random account with an ethsecp256k1 private key, which is required to
+// sign Ethereum transactions.
+func randomEthAccount(r *rand.Rand, accs []simtypes.Account) (simtypes.Account, bool) {
+	ethAccs := make([]simtypes.Account, 0, len(accs))
+	for _, acc := range accs {
+		if _, ok := acc.PrivKey.(*ethsecp256k1.PrivKey); ok {
+			ethAccs = append(ethAccs, acc)
+		}
+	}
+
+	if len(ethAccs) == 0 {
+		return simtypes.Account{}, false
+	}
+
+	return ethAccs[r.Intn(len(ethAccs))], true
+}
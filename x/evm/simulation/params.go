@@ -0,0 +1,57 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simtypes.ParamChange {
+	return []simtypes.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyEnableCreate),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenEnableCreate(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyEnableCall),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenEnableCall(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyExtraEIPs),
+			func(r *rand.Rand) string {
+				// int64 values are encoded as strings on amino JSON
+				eips := []string{}
+				for _, eip := range GenExtraEIPs(r) {
+					eips = append(eips, strconv.FormatInt(eip, 10))
+				}
+
+				bz, err := json.Marshal(eips)
+				if err != nil {
+					panic(err)
+				}
+				return string(bz)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyEnableStateTrie),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenEnableStateTrie(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyInvariantFullScan),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenInvariantFullScan(r))
+			},
+		),
+	}
+}
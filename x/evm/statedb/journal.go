@@ -0,0 +1,152 @@
+package statedb
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// journalEntry is a modification entry in the state change journal that can be
+// reverted on demand.
+type journalEntry interface {
+	// revert undoes the changes introduced by this journal entry.
+	revert(*StateDB)
+}
+
+// journal contains the list of state modifications applied since the last state
+// commit. These are tracked to be able to be reverted in the case of an execution
+// exception or request for reversal.
+type journal struct {
+	entries []journalEntry
+}
+
+// append inserts a new modification entry to the end of the change journal.
+func (j *journal) append(entry journalEntry) {
+	j.entries = append(j.entries, entry)
+}
+
+// revert undoes a batch of journalled modifications along with any reverted
+// dirty handling too.
+func (j *journal) revert(statedb *StateDB, snapshot int) {
+	for i := len(j.entries) - 1; i >= snapshot; i-- {
+		j.entries[i].revert(statedb)
+	}
+	j.entries = j.entries[:snapshot]
+}
+
+// length returns the current number of entries in the journal.
+func (j *journal) length() int {
+	return len(j.entries)
+}
+
+type (
+	// Changes to the account trie.
+	createObjectChange struct {
+		account common.Address
+	}
+	resetObjectChange struct {
+		prev *stateObject
+	}
+	suicideChange struct {
+		account     common.Address
+		prev        bool // whether account had already suicided
+		prevbalance *big.Int
+	}
+
+	// Changes to individual accounts.
+	balanceChange struct {
+		account common.Address
+		prev    *big.Int
+	}
+	nonceChange struct {
+		account common.Address
+		prev    uint64
+	}
+	storageChange struct {
+		account  common.Address
+		key      common.Hash
+		prevalue common.Hash
+		dirty    bool // whether the key had a dirty value before the change
+	}
+	codeChange struct {
+		account            common.Address
+		prevcode, prevhash []byte
+	}
+
+	// Changes to other state values.
+	refundChange struct {
+		prev uint64
+	}
+	addLogChange struct{}
+
+	// Changes to the access list
+	accessListAddAccountChange struct {
+		address common.Address
+	}
+	accessListAddSlotChange struct {
+		address common.Address
+		slot    common.Hash
+	}
+)
+
+func (ch createObjectChange) revert(s *StateDB) {
+	delete(s.stateObjects, ch.account)
+}
+
+func (ch resetObjectChange) revert(s *StateDB) {
+	s.stateObjects[ch.prev.address] = ch.prev
+}
+
+func (ch suicideChange) revert(s *StateDB) {
+	obj := s.getStateObject(ch.account)
+	if obj != nil {
+		obj.suicided = ch.prev
+		obj.setBalance(ch.prevbalance)
+	}
+}
+
+func (ch balanceChange) revert(s *StateDB) {
+	s.getStateObject(ch.account).setBalance(ch.prev)
+}
+
+func (ch nonceChange) revert(s *StateDB) {
+	s.getStateObject(ch.account).setNonce(ch.prev)
+}
+
+func (ch codeChange) revert(s *StateDB) {
+	s.getStateObject(ch.account).setCode(common.BytesToHash(ch.prevhash), ch.prevcode)
+}
+
+func (ch storageChange) revert(s *StateDB) {
+	obj := s.getStateObject(ch.account)
+	if !ch.dirty {
+		delete(obj.dirtyStorage, ch.key)
+		return
+	}
+	obj.setState(ch.key, ch.prevalue)
+}
+
+func (ch refundChange) revert(s *StateDB) {
+	s.refund = ch.prev
+}
+
+func (ch addLogChange) revert(s *StateDB) {
+	s.logs = s.logs[:len(s.logs)-1]
+}
+
+func (ch accessListAddAccountChange) revert(s *StateDB) {
+	/*
+		One important invariant here, is that whenever a (addr, slot) is added, if the
+		addr is not already present, the add causes two journal entries:
+		- one for the address,
+		- one for the (address,slot)
+		Therefore, when unrolling the change, we can always blindly delete the
+		(addr) at this point, since no storage adds can remain when come upon
+		a single (addr) change.
+	*/
+	s.accessList.DeleteAddress(ch.address)
+}
+
+func (ch accessListAddSlotChange) revert(s *StateDB) {
+	s.accessList.DeleteSlot(ch.address, ch.slot)
+}
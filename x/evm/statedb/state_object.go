@@ -0,0 +1,220 @@
+package statedb
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// emptyCodeHash is the code hash of the accounts without code.
+var emptyCodeHash = common.BytesToHash(types.EmptyCodeHash)
+
+// Storage represents in-memory cache of the storage slots of an account.
+type Storage map[common.Hash]common.Hash
+
+// stateObject represents an Ethereum account which is being modified during the
+// execution of a transaction. The account values are loaded from the keeper the
+// first time they are accessed and the modified values are only written back to
+// the keeper when the StateDB is committed.
+type stateObject struct {
+	db      *StateDB
+	address common.Address
+
+	// account values at the time the object was loaded, used to compute the
+	// changes that need to be committed
+	originBalance  *big.Int
+	originNonce    uint64
+	originCodeHash common.Hash
+
+	balance  *big.Int
+	nonce    uint64
+	codeHash common.Hash
+	code     []byte
+
+	originStorage Storage // storage cache of the committed entries, deduplicates keeper reads
+	dirtyStorage  Storage // storage entries modified during the current transaction
+
+	dirtyCode bool // true if the code was updated
+	created   bool // true if the account was (re)created during the transaction, discarding its storage
+	suicided  bool
+}
+
+// newObject creates a state object from the account values loaded from the keeper.
+func newObject(db *StateDB, address common.Address, balance *big.Int, nonce uint64, codeHash common.Hash) *stateObject {
+	if balance == nil {
+		balance = new(big.Int)
+	}
+	if (codeHash == common.Hash{}) {
+		codeHash = emptyCodeHash
+	}
+
+	return &stateObject{
+		db:             db,
+		address:        address,
+		originBalance:  new(big.Int).Set(balance),
+		originNonce:    nonce,
+		originCodeHash: codeHash,
+		balance:        new(big.Int).Set(balance),
+		nonce:          nonce,
+		codeHash:       codeHash,
+		originStorage:  make(Storage),
+		dirtyStorage:   make(Storage),
+	}
+}
+
+// empty returns whether the account is considered empty (EIP-161).
+func (s *stateObject) empty() bool {
+	return s.nonce == 0 && s.balance.Sign() == 0 && s.codeHash == emptyCodeHash
+}
+
+// ----------------------------------------------------------------------------
+// Balance
+// ----------------------------------------------------------------------------
+
+// AddBalance adds amount to s's balance.
+func (s *stateObject) AddBalance(amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	s.SetBalance(new(big.Int).Add(s.balance, amount))
+}
+
+// SubBalance removes amount from s's balance.
+func (s *stateObject) SubBalance(amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	s.SetBalance(new(big.Int).Sub(s.balance, amount))
+}
+
+// SetBalance journals and updates the balance of the account.
+func (s *stateObject) SetBalance(amount *big.Int) {
+	s.db.journal.append(balanceChange{
+		account: s.address,
+		prev:    new(big.Int).Set(s.balance),
+	})
+	s.setBalance(amount)
+}
+
+func (s *stateObject) setBalance(amount *big.Int) {
+	s.balance = amount
+}
+
+// ----------------------------------------------------------------------------
+// Nonce
+// ----------------------------------------------------------------------------
+
+// SetNonce journals and updates the nonce of the account.
+func (s *stateObject) SetNonce(nonce uint64) {
+	s.db.journal.append(nonceChange{
+		account: s.address,
+		prev:    s.nonce,
+	})
+	s.setNonce(nonce)
+}
+
+func (s *stateObject) setNonce(nonce uint64) {
+	s.nonce = nonce
+}
+
+// ----------------------------------------------------------------------------
+// Code
+// ----------------------------------------------------------------------------
+
+// Code returns the contract code associated with this object, if any. The code
+// is loaded from the keeper on the first access.
+func (s *stateObject) Code() []byte {
+	if s.code != nil {
+		return s.code
+	}
+	if s.codeHash == emptyCodeHash {
+		return nil
+	}
+
+	s.code = s.db.keeper.GetCode(s.address)
+	return s.code
+}
+
+// SetCode journals and updates the code of the account.
+func (s *stateObject) SetCode(codeHash common.Hash, code []byte) {
+	prevcode := s.Code()
+	s.db.journal.append(codeChange{
+		account:  s.address,
+		prevhash: s.codeHash.Bytes(),
+		prevcode: prevcode,
+	})
+	s.setCode(codeHash, code)
+}
+
+func (s *stateObject) setCode(codeHash common.Hash, code []byte) {
+	s.code = code
+	s.codeHash = codeHash
+	s.dirtyCode = true
+}
+
+// ----------------------------------------------------------------------------
+// Storage
+// ----------------------------------------------------------------------------
+
+// GetCommittedState returns the value of the storage slot at the beginning of the
+// transaction. Accounts created during the transaction have an empty storage.
+func (s *stateObject) GetCommittedState(key common.Hash) common.Hash {
+	if s.created {
+		return common.Hash{}
+	}
+	if value, cached := s.originStorage[key]; cached {
+		return value
+	}
+
+	value := s.db.keeper.GetState(s.address, key)
+	s.originStorage[key] = value
+	return value
+}
+
+// GetState returns the current value of the storage slot, including the changes
+// made during the transaction.
+func (s *stateObject) GetState(key common.Hash) common.Hash {
+	if value, dirty := s.dirtyStorage[key]; dirty {
+		return value
+	}
+	return s.GetCommittedState(key)
+}
+
+// SetState journals and updates the value of the storage slot.
+func (s *stateObject) SetState(key, value common.Hash) {
+	prev, dirty := s.dirtyStorage[key]
+	if !dirty {
+		prev = s.GetCommittedState(key)
+	}
+	if prev == value {
+		return
+	}
+
+	s.db.journal.append(storageChange{
+		account:  s.address,
+		key:      key,
+		prevalue: prev,
+		dirty:    dirty,
+	})
+	s.setState(key, value)
+}
+
+func (s *stateObject) setState(key, value common.Hash) {
+	s.dirtyStorage[key] = value
+}
+
+// dirtyStorageKeys returns the modified storage keys of the account in order.
+func (s *stateObject) dirtyStorageKeys() []common.Hash {
+	keys := make([]common.Hash, 0, len(s.dirtyStorage))
+	for key := range s.dirtyStorage {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+	})
+	return keys
+}
@@ -0,0 +1,533 @@
+package statedb
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var _ vm.StateDB = &StateDB{}
+
+// Keeper defines the store operations the StateDB reads the committed state from and
+// writes the dirty state to. The EVM keeper implements it on top of its current context.
+type Keeper interface {
+	// read methods
+	Exist(addr common.Address) bool
+	GetBalance(addr common.Address) *big.Int
+	GetNonce(addr common.Address) uint64
+	GetCodeHash(addr common.Address) common.Hash
+	GetCode(addr common.Address) []byte
+	GetState(addr common.Address, key common.Hash) common.Hash
+	ForEachStorage(addr common.Address, cb func(key, value common.Hash) bool) error
+
+	// write methods, only called on Commit
+	CreateAccount(addr common.Address)
+	AddBalance(addr common.Address, amount *big.Int)
+	SubBalance(addr common.Address, amount *big.Int)
+	SetNonce(addr common.Address, nonce uint64)
+	SetCode(addr common.Address, code []byte)
+	SetState(addr common.Address, key, value common.Hash)
+	Suicide(addr common.Address) bool
+	AddLog(log *ethtypes.Log)
+
+	// StateError returns the error of the last failed store operation, if any
+	StateError() error
+}
+
+type revision struct {
+	id           int
+	journalIndex int
+}
+
+// StateDB is an in-memory implementation of the go-ethereum StateDB interface that
+// caches the accounts and storage slots accessed during the execution of a single
+// transaction. All the modifications are journaled, which makes Snapshot and
+// RevertToSnapshot constant time operations, and they are only written to the
+// keeper in a single pass when the StateDB is committed.
+type StateDB struct {
+	keeper Keeper
+
+	// cached and modified accounts of the transaction
+	stateObjects map[common.Address]*stateObject
+
+	// journal of state modifications. This is the backbone of Snapshot and
+	// RevertToSnapshot.
+	journal        *journal
+	validRevisions []revision
+	nextRevisionID int
+
+	// the refund counter, also used by state transitioning
+	refund uint64
+
+	logs       []*ethtypes.Log
+	accessList *accessList
+}
+
+// New creates a new StateDB on top of the given keeper.
+func New(keeper Keeper) *StateDB {
+	return &StateDB{
+		keeper:       keeper,
+		stateObjects: make(map[common.Address]*stateObject),
+		journal:      &journal{},
+		accessList:   newAccessList(),
+	}
+}
+
+// Keeper returns the underlying keeper.
+func (s *StateDB) Keeper() Keeper {
+	return s.keeper
+}
+
+// ----------------------------------------------------------------------------
+// State objects
+// ----------------------------------------------------------------------------
+
+// getStateObject retrieves the cached state object of the address, loading it from
+// the keeper if needed. It returns nil if the account doesn't exist.
+func (s *StateDB) getStateObject(addr common.Address) *stateObject {
+	if obj, cached := s.stateObjects[addr]; cached {
+		return obj
+	}
+
+	if !s.keeper.Exist(addr) {
+		return nil
+	}
+
+	obj := newObject(s, addr, s.keeper.GetBalance(addr), s.keeper.GetNonce(addr), s.keeper.GetCodeHash(addr))
+	s.stateObjects[addr] = obj
+	return obj
+}
+
+// getOrNewStateObject retrieves the state object of the address, creating it if it
+// doesn't exist.
+func (s *StateDB) getOrNewStateObject(addr common.Address) *stateObject {
+	obj := s.getStateObject(addr)
+	if obj == nil {
+		obj, _ = s.createObject(addr)
+	}
+	return obj
+}
+
+// createObject creates a new state object. If there is an existing account with
+// the given address, it is overwritten and returned as the second return value.
+func (s *StateDB) createObject(addr common.Address) (newobj, prev *stateObject) {
+	prev = s.getStateObject(addr)
+
+	originBalance := new(big.Int)
+	if prev != nil {
+		originBalance = prev.originBalance
+	}
+
+	// the account is reset on the keeper before the dirty values are committed, so the
+	// committed nonce and code of the new object are empty
+	newobj = newObject(s, addr, originBalance, 0, common.Hash{})
+	newobj.created = true
+
+	if prev == nil {
+		s.journal.append(createObjectChange{account: addr})
+	} else {
+		s.journal.append(resetObjectChange{prev: prev})
+	}
+
+	s.stateObjects[addr] = newobj
+	return newobj, prev
+}
+
+// CreateAccount explicitly creates a state object. If a state object with the address
+// already exists the balance is carried over to the new account.
+//
+// CreateAccount is called during the EVM CREATE operation. The situation might arise that
+// a contract does the following:
+//
+//  1. sends funds to sha(account ++ (nonce + 1))
+//  2. tx_create(sha(account ++ nonce)) (note that this gets the address of 1)
+//
+// Carrying over the balance ensures that Ether doesn't disappear.
+func (s *StateDB) CreateAccount(addr common.Address) {
+	newObj, prev := s.createObject(addr)
+	if prev != nil {
+		newObj.setBalance(new(big.Int).Set(prev.balance))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Getters
+// ----------------------------------------------------------------------------
+
+// Exist reports whether the given account exists in state. Notably this also
+// returns true for suicided accounts.
+func (s *StateDB) Exist(addr common.Address) bool {
+	return s.getStateObject(addr) != nil
+}
+
+// Empty returns whether the state object is either non-existent or empty
+// according to the EIP161 specification (balance = nonce = code = 0).
+func (s *StateDB) Empty(addr common.Address) bool {
+	obj := s.getStateObject(addr)
+	return obj == nil || obj.empty()
+}
+
+// GetBalance retrieves the balance from the given address or 0 if object not found.
+func (s *StateDB) GetBalance(addr common.Address) *big.Int {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return new(big.Int).Set(obj.balance)
+	}
+	return new(big.Int)
+}
+
+// GetNonce returns the nonce of the account, or 0 if it doesn't exist.
+func (s *StateDB) GetNonce(addr common.Address) uint64 {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return obj.nonce
+	}
+	return 0
+}
+
+// GetCode returns the code of the account, or nil if it doesn't exist.
+func (s *StateDB) GetCode(addr common.Address) []byte {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return obj.Code()
+	}
+	return nil
+}
+
+// GetCodeSize returns the code size of the account.
+func (s *StateDB) GetCodeSize(addr common.Address) int {
+	return len(s.GetCode(addr))
+}
+
+// GetCodeHash returns the code hash of the account, or the empty hash if it doesn't
+// exist.
+func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
+	obj := s.getStateObject(addr)
+	if obj == nil {
+		return common.Hash{}
+	}
+	return obj.codeHash
+}
+
+// GetState retrieves a value from the given account's storage.
+func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return obj.GetState(hash)
+	}
+	return common.Hash{}
+}
+
+// GetCommittedState retrieves a value from the given account's committed storage.
+func (s *StateDB) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return obj.GetCommittedState(hash)
+	}
+	return common.Hash{}
+}
+
+// GetRefund returns the current value of the refund counter.
+func (s *StateDB) GetRefund() uint64 {
+	return s.refund
+}
+
+// HasSuicided returns if the account has been suicided during the transaction.
+func (s *StateDB) HasSuicided(addr common.Address) bool {
+	obj := s.getStateObject(addr)
+	if obj != nil {
+		return obj.suicided
+	}
+	return false
+}
+
+// Logs returns the logs emitted during the transaction.
+func (s *StateDB) Logs() []*ethtypes.Log {
+	return s.logs
+}
+
+// ForEachStorage iterates over the storage of the account, including the values
+// modified during the transaction.
+func (s *StateDB) ForEachStorage(addr common.Address, cb func(key, value common.Hash) bool) error {
+	obj := s.getStateObject(addr)
+	if obj == nil {
+		return nil
+	}
+
+	if !obj.created {
+		stop := false
+		err := s.keeper.ForEachStorage(addr, func(key, value common.Hash) bool {
+			if _, dirty := obj.dirtyStorage[key]; dirty {
+				return false
+			}
+			stop = cb(key, value)
+			return stop
+		})
+		if err != nil || stop {
+			return err
+		}
+	}
+
+	for _, key := range obj.dirtyStorageKeys() {
+		value := obj.dirtyStorage[key]
+		if (value == common.Hash{}) {
+			continue
+		}
+		if cb(key, value) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Setters
+// ----------------------------------------------------------------------------
+
+// AddBalance adds amount to the account associated with addr.
+func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
+	obj := s.getOrNewStateObject(addr)
+	obj.AddBalance(amount)
+}
+
+// SubBalance subtracts amount from the account associated with addr.
+func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
+	obj := s.getOrNewStateObject(addr)
+	obj.SubBalance(amount)
+}
+
+// SetNonce sets the nonce of the account.
+func (s *StateDB) SetNonce(addr common.Address, nonce uint64) {
+	obj := s.getOrNewStateObject(addr)
+	obj.SetNonce(nonce)
+}
+
+// SetCode sets the code of the account.
+func (s *StateDB) SetCode(addr common.Address, code []byte) {
+	obj := s.getOrNewStateObject(addr)
+	obj.SetCode(crypto.Keccak256Hash(code), code)
+}
+
+// SetState sets the value of a storage slot of the account.
+func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
+	obj := s.getOrNewStateObject(addr)
+	obj.SetState(key, value)
+}
+
+// Suicide marks the given account as suicided and clears its balance. The account
+// remains available until the state is committed.
+func (s *StateDB) Suicide(addr common.Address) bool {
+	obj := s.getStateObject(addr)
+	if obj == nil {
+		return false
+	}
+
+	s.journal.append(suicideChange{
+		account:     addr,
+		prev:        obj.suicided,
+		prevbalance: new(big.Int).Set(obj.balance),
+	})
+	obj.suicided = true
+	obj.balance = new(big.Int)
+	return true
+}
+
+// AddRefund adds gas to the refund counter.
+func (s *StateDB) AddRefund(gas uint64) {
+	s.journal.append(refundChange{prev: s.refund})
+	s.refund += gas
+}
+
+// SubRefund removes gas from the refund counter. This method will panic if the
+// refund counter goes below zero.
+func (s *StateDB) SubRefund(gas uint64) {
+	s.journal.append(refundChange{prev: s.refund})
+	if gas > s.refund {
+		panic(fmt.Sprintf("refund counter below zero (gas: %d > refund: %d)", gas, s.refund))
+	}
+	s.refund -= gas
+}
+
+// AddLog appends the log to the logs of the transaction. The tx hash, block hash,
+// tx index and log index fields are filled in by the keeper once the state is
+// committed.
+func (s *StateDB) AddLog(log *ethtypes.Log) {
+	s.journal.append(addLogChange{})
+	s.logs = append(s.logs, log)
+}
+
+// AddPreimage performs a no-op since the EnablePreimageRecording flag is disabled
+// on the vm.Config during state transitions. No store trie preimages are written
+// to the database.
+func (s *StateDB) AddPreimage(_ common.Hash, _ []byte) {}
+
+// ----------------------------------------------------------------------------
+// Access List
+// ----------------------------------------------------------------------------
+
+// PrepareAccessList handles the preparatory steps for executing a state transition with
+// regards to both EIP-2929 and EIP-2930:
+//
+//   - Add sender to access list (2929)
+//   - Add destination to access list (2929)
+//   - Add precompiles to access list (2929)
+//   - Add the contents of the optional tx access list (2930)
+//
+// This method should only be called if Berlin/2929+2930 is applicable at the current number.
+func (s *StateDB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list ethtypes.AccessList) {
+	s.AddAddressToAccessList(sender)
+	if dst != nil {
+		s.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		s.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		s.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			s.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (s *StateDB) AddAddressToAccessList(addr common.Address) {
+	if s.accessList.AddAddress(addr) {
+		s.journal.append(accessListAddAccountChange{addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (s *StateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	addrMod, slotMod := s.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		s.journal.append(accessListAddAccountChange{addr})
+	}
+	if slotMod {
+		s.journal.append(accessListAddSlotChange{
+			address: addr,
+			slot:    slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (s *StateDB) AddressInAccessList(addr common.Address) bool {
+	return s.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (s *StateDB) SlotInAccessList(addr common.Address, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	return s.accessList.Contains(addr, slot)
+}
+
+// ----------------------------------------------------------------------------
+// Snapshotting
+// ----------------------------------------------------------------------------
+
+// Snapshot returns an identifier for the current revision of the state.
+func (s *StateDB) Snapshot() int {
+	id := s.nextRevisionID
+	s.nextRevisionID++
+	s.validRevisions = append(s.validRevisions, revision{id, s.journal.length()})
+	return id
+}
+
+// RevertToSnapshot reverts all state changes made since the given revision.
+func (s *StateDB) RevertToSnapshot(revid int) {
+	// Find the snapshot in the stack of valid snapshots.
+	idx := sort.Search(len(s.validRevisions), func(i int) bool {
+		return s.validRevisions[i].id >= revid
+	})
+	if idx == len(s.validRevisions) || s.validRevisions[idx].id != revid {
+		panic(fmt.Errorf("revision id %v cannot be reverted", revid))
+	}
+	snapshot := s.validRevisions[idx].journalIndex
+
+	// Replay the journal to undo changes and remove invalidated snapshots
+	s.journal.revert(s, snapshot)
+	s.validRevisions = s.validRevisions[:idx]
+}
+
+// ----------------------------------------------------------------------------
+// Commit
+// ----------------------------------------------------------------------------
+
+// Commit writes the dirty state of the transaction to the keeper in a single pass.
+// The accounts are committed in address order so that the store writes are
+// deterministic, followed by the transaction logs in the order they were emitted.
+func (s *StateDB) Commit() error {
+	addrs := make([]common.Address, 0, len(s.stateObjects))
+	for addr := range s.stateObjects {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	for _, addr := range addrs {
+		if err := s.commitObject(s.stateObjects[addr]); err != nil {
+			return err
+		}
+	}
+
+	for _, log := range s.logs {
+		s.keeper.AddLog(log)
+	}
+
+	return s.keeper.StateError()
+}
+
+// commitObject writes the changes of a single account to the keeper.
+func (s *StateDB) commitObject(obj *stateObject) error {
+	addr := obj.address
+
+	if obj.created {
+		s.keeper.CreateAccount(addr)
+	}
+
+	switch diff := new(big.Int).Sub(obj.balance, obj.originBalance); diff.Sign() {
+	case 1:
+		s.keeper.AddBalance(addr, diff)
+	case -1:
+		s.keeper.SubBalance(addr, diff.Neg(diff))
+	}
+
+	if obj.nonce != obj.originNonce {
+		s.keeper.SetNonce(addr, obj.nonce)
+	}
+
+	if obj.dirtyCode && obj.codeHash != obj.originCodeHash {
+		s.keeper.SetCode(addr, obj.code)
+	}
+
+	for _, key := range obj.dirtyStorageKeys() {
+		value := obj.dirtyStorage[key]
+		if value == obj.GetCommittedState(key) {
+			continue
+		}
+		s.keeper.SetState(addr, key, value)
+	}
+
+	if obj.suicided {
+		s.keeper.Suicide(addr)
+	}
+
+	if err := s.keeper.StateError(); err != nil {
+		return fmt.Errorf("failed to commit state of account %s: %w", addr.Hex(), err)
+	}
+
+	return nil
+}
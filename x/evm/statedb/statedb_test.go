@@ -0,0 +1,321 @@
+package statedb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	address  = common.BigToAddress(big.NewInt(101))
+	address2 = common.BigToAddress(big.NewInt(102))
+	key1     = common.BigToHash(big.NewInt(1))
+	key2     = common.BigToHash(big.NewInt(2))
+	value1   = common.BigToHash(big.NewInt(10))
+	value2   = common.BigToHash(big.NewInt(20))
+)
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+// mockKeeper is an in-memory Keeper that records the number of writes.
+type mockKeeper struct {
+	accounts map[common.Address]*mockAccount
+	logs     []*ethtypes.Log
+	writes   int
+}
+
+var _ Keeper = &mockKeeper{}
+
+func newMockKeeper() *mockKeeper {
+	return &mockKeeper{accounts: make(map[common.Address]*mockAccount)}
+}
+
+func (k *mockKeeper) account(addr common.Address) *mockAccount {
+	acc, ok := k.accounts[addr]
+	if !ok {
+		acc = &mockAccount{balance: new(big.Int), storage: make(map[common.Hash]common.Hash)}
+		k.accounts[addr] = acc
+	}
+	return acc
+}
+
+func (k *mockKeeper) Exist(addr common.Address) bool {
+	_, ok := k.accounts[addr]
+	return ok
+}
+
+func (k *mockKeeper) GetBalance(addr common.Address) *big.Int {
+	return new(big.Int).Set(k.account(addr).balance)
+}
+
+func (k *mockKeeper) GetNonce(addr common.Address) uint64 { return k.account(addr).nonce }
+
+func (k *mockKeeper) GetCodeHash(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(k.account(addr).code)
+}
+
+func (k *mockKeeper) GetCode(addr common.Address) []byte { return k.account(addr).code }
+
+func (k *mockKeeper) GetState(addr common.Address, key common.Hash) common.Hash {
+	return k.account(addr).storage[key]
+}
+
+func (k *mockKeeper) ForEachStorage(addr common.Address, cb func(key, value common.Hash) bool) error {
+	for key, value := range k.account(addr).storage {
+		if cb(key, value) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (k *mockKeeper) CreateAccount(addr common.Address) {
+	k.writes++
+	balance := k.account(addr).balance
+	k.accounts[addr] = &mockAccount{balance: balance, storage: make(map[common.Hash]common.Hash)}
+}
+
+func (k *mockKeeper) AddBalance(addr common.Address, amount *big.Int) {
+	k.writes++
+	k.account(addr).balance.Add(k.account(addr).balance, amount)
+}
+
+func (k *mockKeeper) SubBalance(addr common.Address, amount *big.Int) {
+	k.writes++
+	k.account(addr).balance.Sub(k.account(addr).balance, amount)
+}
+
+func (k *mockKeeper) SetNonce(addr common.Address, nonce uint64) {
+	k.writes++
+	k.account(addr).nonce = nonce
+}
+
+func (k *mockKeeper) SetCode(addr common.Address, code []byte) {
+	k.writes++
+	k.account(addr).code = code
+}
+
+func (k *mockKeeper) SetState(addr common.Address, key, value common.Hash) {
+	k.writes++
+	if (value == common.Hash{}) {
+		delete(k.account(addr).storage, key)
+		return
+	}
+	k.account(addr).storage[key] = value
+}
+
+func (k *mockKeeper) Suicide(addr common.Address) bool {
+	k.writes++
+	k.account(addr).balance = new(big.Int)
+	return true
+}
+
+func (k *mockKeeper) AddLog(log *ethtypes.Log) {
+	log.Index = uint(len(k.logs))
+	k.logs = append(k.logs, log)
+}
+
+func (k *mockKeeper) StateError() error { return nil }
+
+func TestSnapshot(t *testing.T) {
+	keeper := newMockKeeper()
+	keeper.account(address).balance = big.NewInt(100)
+	keeper.account(address).storage[key1] = value1
+
+	db := New(keeper)
+
+	rev1 := db.Snapshot()
+	db.SubBalance(address, big.NewInt(30))
+	db.SetNonce(address, 1)
+	db.SetState(address, key1, value2)
+	db.AddLog(&ethtypes.Log{Address: address})
+	db.AddRefund(10)
+
+	rev2 := db.Snapshot()
+	db.SetState(address, key1, common.Hash{})
+	db.SetState(address, key2, value1)
+	db.SetCode(address, []byte("code"))
+	db.AddLog(&ethtypes.Log{Address: address})
+	db.SubRefund(5)
+	db.AddSlotToAccessList(address, key1)
+
+	require.Equal(t, common.Hash{}, db.GetState(address, key1))
+	require.Equal(t, value1, db.GetCommittedState(address, key1))
+	require.Equal(t, []byte("code"), db.GetCode(address))
+	require.Len(t, db.Logs(), 2)
+
+	db.RevertToSnapshot(rev2)
+	require.Equal(t, value2, db.GetState(address, key1))
+	require.Equal(t, common.Hash{}, db.GetState(address, key2))
+	require.Nil(t, db.GetCode(address))
+	require.Len(t, db.Logs(), 1)
+	require.Equal(t, uint64(10), db.GetRefund())
+	addrOk, slotOk := db.SlotInAccessList(address, key1)
+	require.False(t, addrOk)
+	require.False(t, slotOk)
+	require.Equal(t, big.NewInt(70), db.GetBalance(address))
+	require.Equal(t, uint64(1), db.GetNonce(address))
+
+	db.RevertToSnapshot(rev1)
+	require.Equal(t, value1, db.GetState(address, key1))
+	require.Equal(t, big.NewInt(100), db.GetBalance(address))
+	require.Equal(t, uint64(0), db.GetNonce(address))
+	require.Empty(t, db.Logs())
+	require.Zero(t, db.GetRefund())
+
+	// reverted revisions are no longer valid
+	require.Panics(t, func() { db.RevertToSnapshot(rev2) })
+}
+
+func TestCommit(t *testing.T) {
+	keeper := newMockKeeper()
+	keeper.account(address).balance = big.NewInt(100)
+	keeper.account(address).storage[key1] = value1
+
+	db := New(keeper)
+
+	// the accessed state is not written to the keeper
+	db.GetBalance(address)
+	db.GetState(address, key2)
+	db.SetState(address, key1, value1)
+
+	db.SubBalance(address, big.NewInt(40))
+	db.AddBalance(address2, big.NewInt(40))
+	db.SetNonce(address, 2)
+	db.SetState(address, key2, value2)
+	db.SetCode(address2, []byte("code"))
+
+	// the reverted changes are discarded
+	rev := db.Snapshot()
+	db.SetState(address, key1, value2)
+	db.AddBalance(address, big.NewInt(1000))
+	db.RevertToSnapshot(rev)
+
+	db.AddLog(&ethtypes.Log{Address: address})
+	db.AddLog(&ethtypes.Log{Address: address2})
+
+	// nothing is written before commit
+	require.Zero(t, keeper.writes)
+	require.NoError(t, db.Commit())
+
+	require.Equal(t, big.NewInt(60), keeper.accounts[address].balance)
+	require.Equal(t, uint64(2), keeper.accounts[address].nonce)
+	require.Equal(t, value1, keeper.accounts[address].storage[key1])
+	require.Equal(t, value2, keeper.accounts[address].storage[key2])
+	require.Equal(t, big.NewInt(40), keeper.accounts[address2].balance)
+	require.Equal(t, []byte("code"), keeper.accounts[address2].code)
+
+	// balance, nonce and storage of the first account; creation, balance and code of the second one
+	require.Equal(t, 6, keeper.writes)
+
+	require.Len(t, keeper.logs, 2)
+	require.Equal(t, address2, keeper.logs[1].Address)
+	require.Equal(t, uint(1), keeper.logs[1].Index)
+}
+
+func TestCreateAccount(t *testing.T) {
+	keeper := newMockKeeper()
+	keeper.account(address).balance = big.NewInt(100)
+	keeper.account(address).nonce = 5
+	keeper.account(address).code = []byte("code")
+	keeper.account(address).storage[key1] = value1
+
+	db := New(keeper)
+	db.CreateAccount(address)
+
+	// the balance is carried over while the nonce, code and storage are discarded
+	require.True(t, db.Exist(address))
+	require.Equal(t, big.NewInt(100), db.GetBalance(address))
+	require.Zero(t, db.GetNonce(address))
+	require.Nil(t, db.GetCode(address))
+	require.Equal(t, common.Hash{}, db.GetState(address, key1))
+	require.Equal(t, common.Hash{}, db.GetCommittedState(address, key1))
+
+	db.SetState(address, key2, value2)
+	require.NoError(t, db.Commit())
+
+	require.Equal(t, big.NewInt(100), keeper.accounts[address].balance)
+	require.Zero(t, keeper.accounts[address].nonce)
+	require.Nil(t, keeper.accounts[address].code)
+	require.Equal(t, map[common.Hash]common.Hash{key2: value2}, keeper.accounts[address].storage)
+}
+
+func TestSuicide(t *testing.T) {
+	keeper := newMockKeeper()
+	keeper.account(address).balance = big.NewInt(100)
+
+	db := New(keeper)
+	require.False(t, db.Suicide(address2))
+
+	rev := db.Snapshot()
+	require.True(t, db.Suicide(address))
+	require.True(t, db.HasSuicided(address))
+	require.True(t, db.Exist(address))
+	require.Zero(t, db.GetBalance(address).Sign())
+
+	db.RevertToSnapshot(rev)
+	require.False(t, db.HasSuicided(address))
+	require.Equal(t, big.NewInt(100), db.GetBalance(address))
+
+	db.Suicide(address)
+	require.NoError(t, db.Commit())
+	require.Zero(t, keeper.accounts[address].balance.Sign())
+}
+
+func TestExistAndEmpty(t *testing.T) {
+	keeper := newMockKeeper()
+	db := New(keeper)
+
+	require.False(t, db.Exist(address))
+	require.True(t, db.Empty(address))
+	require.Equal(t, common.Hash{}, db.GetCodeHash(address))
+
+	db.CreateAccount(address)
+	require.True(t, db.Exist(address))
+	require.True(t, db.Empty(address))
+	require.Equal(t, emptyCodeHash, db.GetCodeHash(address))
+
+	db.AddBalance(address, big.NewInt(1))
+	require.False(t, db.Empty(address))
+}
+
+func TestForEachStorage(t *testing.T) {
+	keeper := newMockKeeper()
+	keeper.account(address).storage[key1] = value1
+
+	db := New(keeper)
+	db.SetState(address, key1, value2)
+	db.SetState(address, key2, value2)
+
+	storage := make(map[common.Hash]common.Hash)
+	err := db.ForEachStorage(address, func(key, value common.Hash) bool {
+		storage[key] = value
+		return false
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[common.Hash]common.Hash{key1: value2, key2: value2}, storage)
+}
+
+func BenchmarkSnapshotRevert(b *testing.B) {
+	keeper := newMockKeeper()
+	keeper.account(address).balance = big.NewInt(100)
+	db := New(keeper)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rev := db.Snapshot()
+		db.SetState(address, key1, common.BigToHash(big.NewInt(int64(i+1))))
+		db.AddBalance(address, big.NewInt(1))
+		db.RevertToSnapshot(rev)
+	}
+}
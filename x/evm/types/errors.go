@@ -30,9 +30,13 @@ const (
 	codeErrInconsistentGas
 	codeErrInvalidGasCap
 	codeErrInvalidBaseFee
+	codeErrUnsignedTx
 )
 
-var ErrPostTxProcessing = errors.New("failed to execute post processing")
+var (
+	ErrPreTxProcessing  = errors.New("failed to execute pre processing")
+	ErrPostTxProcessing = errors.New("failed to execute post processing")
+)
 
 var (
 	// ErrInvalidState returns an error resulting from an invalid Storage State.
@@ -85,6 +89,9 @@ var (
 
 	// ErrInvalidBaseFee returns an error if a the base fee cap value is invalid
 	ErrInvalidBaseFee = sdkerrors.Register(ModuleName, codeErrInvalidBaseFee, "invalid base fee")
+
+	// ErrUnsignedTx returns an error if an unsigned transaction is applied outside of a development chain
+	ErrUnsignedTx = sdkerrors.Register(ModuleName, codeErrUnsignedTx, "unsigned transactions are only accepted on development chains")
 )
 
 // NewExecErrorWithReason unpacks the revert return bytes and returns a wrapped error
@@ -37,6 +37,13 @@ type Params struct {
 	ExtraEIPs []int64 `protobuf:"varint,4,rep,packed,name=extra_eips,json=extraEips,proto3" json:"extra_eips,omitempty" yaml:"extra_eips"`
 	// chain config defines the EVM chain configuration parameters
 	ChainConfig ChainConfig `protobuf:"bytes,5,opt,name=chain_config,json=chainConfig,proto3" json:"chain_config" yaml:"chain_config"`
+	// enable state trie toggles the maintenance of a secondary Merkle-Patricia
+	// trie commitment of the EVM world state, updated at the end of every block
+	EnableStateTrie bool `protobuf:"varint,6,opt,name=enable_state_trie,json=enableStateTrie,proto3" json:"enable_state_trie,omitempty" yaml:"enable_state_trie"`
+	// invariant full scan toggles the check of all the accounts by the evm
+	// invariants, which otherwise only check a sample of the accounts selected
+	// from the block height
+	InvariantFullScan bool `protobuf:"varint,7,opt,name=invariant_full_scan,json=invariantFullScan,proto3" json:"invariant_full_scan,omitempty" yaml:"invariant_full_scan"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -106,6 +113,20 @@ func (m *Params) GetChainConfig() ChainConfig {
 	return ChainConfig{}
 }
 
+func (m *Params) GetEnableStateTrie() bool {
+	if m != nil {
+		return m.EnableStateTrie
+	}
+	return false
+}
+
+func (m *Params) GetInvariantFullScan() bool {
+	if m != nil {
+		return m.InvariantFullScan
+	}
+	return false
+}
+
 // ChainConfig defines the Ethereum ChainConfig parameters using *sdk.Int values
 // instead of *big.Int.
 type ChainConfig struct {
@@ -653,98 +674,103 @@ func init() {
 func init() { proto.RegisterFile("ethermint/evm/v1/evm.proto", fileDescriptor_d21ecc92c8c8583e) }
 
 var fileDescriptor_d21ecc92c8c8583e = []byte{
-	// 1452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4d, 0x6f, 0x1b, 0x37,
-	0x1a, 0xb6, 0x2d, 0xd9, 0x1e, 0x51, 0x9f, 0xa6, 0x1d, 0xaf, 0x92, 0x60, 0x3d, 0xde, 0x39, 0x2c,
-	0xbc, 0x40, 0x6c, 0xc5, 0x0e, 0x8c, 0x35, 0x12, 0xec, 0xc1, 0xb2, 0x9d, 0xac, 0xb3, 0xd9, 0xd6,
-	0xa0, 0x5d, 0x14, 0x68, 0x51, 0x0c, 0xa8, 0x19, 0x66, 0x3c, 0xf5, 0xcc, 0x50, 0x20, 0x39, 0xaa,
-	0x54, 0xf4, 0x07, 0x14, 0xe8, 0xa5, 0xc7, 0x1e, 0x7a, 0xe8, 0x9f, 0xe8, 0x7f, 0x08, 0x7a, 0xca,
-	0xb1, 0x68, 0x81, 0x41, 0xe1, 0xdc, 0x74, 0xd4, 0x2f, 0x28, 0xf8, 0xa1, 0x4f, 0x07, 0x6d, 0xed,
-	0x93, 0xf8, 0xbc, 0x1f, 0xcf, 0x43, 0xbe, 0x7c, 0x87, 0xa4, 0xc0, 0x03, 0x22, 0x2e, 0x09, 0x8b,
-	0xc3, 0x44, 0x34, 0x48, 0x27, 0x6e, 0x74, 0x76, 0xe5, 0xcf, 0x4e, 0x9b, 0x51, 0x41, 0x61, 0x6d,
-	0xe4, 0xdb, 0x91, 0xc6, 0xce, 0xee, 0x83, 0xb5, 0x80, 0x06, 0x54, 0x39, 0x1b, 0x72, 0xa4, 0xe3,
-	0x9c, 0x5f, 0x17, 0xc0, 0xd2, 0x19, 0x66, 0x38, 0xe6, 0x70, 0x17, 0x14, 0x48, 0x27, 0x76, 0x7d,
-	0x92, 0xd0, 0xb8, 0x3e, 0xbf, 0x39, 0xbf, 0x55, 0x68, 0xae, 0x0d, 0x32, 0xbb, 0xd6, 0xc3, 0x71,
-	0xf4, 0xd4, 0x19, 0xb9, 0x1c, 0x64, 0x91, 0x4e, 0x7c, 0x2c, 0x87, 0xf0, 0x3f, 0xa0, 0x4c, 0x12,
-	0xdc, 0x8a, 0x88, 0xeb, 0x31, 0x82, 0x05, 0xa9, 0x2f, 0x6c, 0xce, 0x6f, 0x59, 0xcd, 0xfa, 0x20,
-	0xb3, 0xd7, 0x4c, 0xda, 0xa4, 0xdb, 0x41, 0x25, 0x8d, 0x8f, 0x14, 0x84, 0xff, 0x06, 0xc5, 0xa1,
-	0x1f, 0x47, 0x51, 0x3d, 0xa7, 0x92, 0xd7, 0x07, 0x99, 0x0d, 0xa7, 0x93, 0x71, 0x14, 0x39, 0x08,
-	0x98, 0x54, 0x1c, 0x45, 0xf0, 0x10, 0x00, 0xd2, 0x15, 0x0c, 0xbb, 0x24, 0x6c, 0xf3, 0x7a, 0x7e,
-	0x33, 0xb7, 0x95, 0x6b, 0x3a, 0xd7, 0x99, 0x5d, 0x38, 0x91, 0xd6, 0x93, 0xd3, 0x33, 0x3e, 0xc8,
-	0xec, 0x15, 0x43, 0x32, 0x0a, 0x74, 0x50, 0x41, 0x81, 0x93, 0xb0, 0xcd, 0xe1, 0x67, 0xa0, 0xe4,
-	0x5d, 0xe2, 0x30, 0x71, 0x3d, 0x9a, 0xbc, 0x0e, 0x83, 0xfa, 0xe2, 0xe6, 0xfc, 0x56, 0x71, 0xef,
-	0xef, 0x3b, 0xb3, 0x75, 0xdb, 0x39, 0x92, 0x51, 0x47, 0x2a, 0xa8, 0xf9, 0xf0, 0x4d, 0x66, 0xcf,
-	0x0d, 0x32, 0x7b, 0x55, 0x53, 0x4f, 0x12, 0x38, 0xa8, 0xe8, 0x8d, 0x23, 0x9f, 0xe6, 0xbf, 0xfb,
-	0xc1, 0x9e, 0x73, 0xbe, 0x2f, 0x83, 0xe2, 0x44, 0x3e, 0x8c, 0x41, 0xf5, 0x92, 0xc6, 0x84, 0x0b,
-	0x82, 0x7d, 0xb7, 0x15, 0x51, 0xef, 0xca, 0x14, 0xfa, 0xf8, 0x97, 0xcc, 0xfe, 0x67, 0x10, 0x8a,
-	0xcb, 0xb4, 0xb5, 0xe3, 0xd1, 0xb8, 0xe1, 0x51, 0x1e, 0x53, 0x6e, 0x7e, 0xb6, 0xb9, 0x7f, 0xd5,
-	0x10, 0xbd, 0x36, 0xe1, 0x3b, 0xa7, 0x89, 0x18, 0x64, 0xf6, 0xba, 0x96, 0x9f, 0xa1, 0x72, 0x50,
-	0x65, 0x64, 0x69, 0x4a, 0x03, 0xec, 0x81, 0x8a, 0x8f, 0xa9, 0xfb, 0x9a, 0xb2, 0x2b, 0xa3, 0xb6,
-	0xa0, 0xd4, 0xce, 0xff, 0xba, 0xda, 0x75, 0x66, 0x97, 0x8e, 0x0f, 0x3f, 0x7c, 0x4e, 0xd9, 0x95,
-	0xe2, 0x1c, 0x64, 0xf6, 0x3d, 0xad, 0x3e, 0xcd, 0xec, 0xa0, 0x92, 0x8f, 0xe9, 0x28, 0x0c, 0x7e,
-	0x0c, 0x6a, 0xa3, 0x00, 0x9e, 0xb6, 0xdb, 0x94, 0x09, 0xb3, 0xbf, 0xdb, 0xd7, 0x99, 0x5d, 0x31,
-	0x94, 0xe7, 0xda, 0x33, 0xc8, 0xec, 0xbf, 0xcd, 0x90, 0x9a, 0x1c, 0x07, 0x55, 0x0c, 0xad, 0x09,
-	0x85, 0x1c, 0x94, 0x48, 0xd8, 0xde, 0xdd, 0x7f, 0x6c, 0x56, 0x94, 0x57, 0x2b, 0x3a, 0xbb, 0xd5,
-	0x8a, 0x8a, 0x27, 0xa7, 0x67, 0xbb, 0xfb, 0x8f, 0x87, 0x0b, 0x32, 0xbb, 0x39, 0x49, 0xeb, 0xa0,
-	0xa2, 0x86, 0x7a, 0x35, 0xa7, 0xc0, 0x40, 0xf7, 0x12, 0xf3, 0x4b, 0xd5, 0x2b, 0x85, 0xe6, 0xd6,
-	0x75, 0x66, 0x03, 0xcd, 0xf4, 0x5f, 0xcc, 0x2f, 0xc7, 0xfb, 0xd2, 0xea, 0x7d, 0x89, 0x13, 0x11,
-	0xa6, 0xf1, 0x90, 0x0b, 0xe8, 0x64, 0x19, 0x35, 0x9a, 0xff, 0xbe, 0x99, 0xff, 0xd2, 0x9d, 0xe7,
-	0xbf, 0xff, 0xbe, 0xf9, 0xef, 0x4f, 0xcf, 0x5f, 0xc7, 0x8c, 0x44, 0x0f, 0x8c, 0xe8, 0xf2, 0x9d,
-	0x45, 0x0f, 0xde, 0x27, 0x7a, 0x30, 0x2d, 0xaa, 0x63, 0x64, 0xb3, 0xcf, 0x54, 0xa2, 0x6e, 0xdd,
-	0xbd, 0xd9, 0x6f, 0x14, 0xb5, 0x32, 0xb2, 0x68, 0xb9, 0xaf, 0xc0, 0x9a, 0x47, 0x13, 0x2e, 0xa4,
-	0x2d, 0xa1, 0xed, 0x88, 0x18, 0xcd, 0x82, 0xd2, 0x3c, 0xbd, 0x95, 0xe6, 0x43, 0xf3, 0x7d, 0xbf,
-	0x87, 0xcf, 0x41, 0xab, 0xd3, 0x66, 0xad, 0xde, 0x06, 0xb5, 0x36, 0x11, 0x84, 0xf1, 0x56, 0xca,
-	0x02, 0xa3, 0x0c, 0x94, 0xf2, 0xc9, 0xad, 0x94, 0xcd, 0x77, 0x30, 0xcb, 0xe5, 0xa0, 0xea, 0xd8,
-	0xa4, 0x15, 0x3f, 0x07, 0x95, 0x50, 0x4e, 0xa3, 0x95, 0x46, 0x46, 0xaf, 0xa8, 0xf4, 0x8e, 0x6e,
-	0xa5, 0x67, 0x3e, 0xe6, 0x69, 0x26, 0x07, 0x95, 0x87, 0x06, 0xad, 0x95, 0x02, 0x18, 0xa7, 0x21,
-	0x73, 0x83, 0x08, 0x7b, 0x21, 0x61, 0x46, 0xaf, 0xa4, 0xf4, 0x5e, 0xdc, 0x4a, 0xef, 0xbe, 0xd6,
-	0xbb, 0xc9, 0xe6, 0xa0, 0x9a, 0x34, 0xbe, 0xd0, 0x36, 0x2d, 0xeb, 0x83, 0x52, 0x8b, 0xb0, 0x28,
-	0x4c, 0x8c, 0x60, 0x59, 0x09, 0x1e, 0xde, 0x4a, 0xd0, 0xf4, 0xe9, 0x24, 0x8f, 0x83, 0x8a, 0x1a,
-	0x8e, 0x0a, 0xe9, 0x61, 0x81, 0xa3, 0x1e, 0x17, 0x46, 0xa7, 0x76, 0xf7, 0x42, 0x4e, 0x33, 0x39,
-	0xa8, 0x3c, 0x34, 0x8c, 0x56, 0x14, 0xd1, 0xc4, 0xa7, 0xc3, 0x15, 0xad, 0xdc, 0x7d, 0x45, 0x93,
-	0x3c, 0x0e, 0x2a, 0x6a, 0xa8, 0x54, 0x5e, 0xe6, 0xad, 0x4a, 0xad, 0xfa, 0x32, 0x6f, 0x55, 0x6b,
-	0x35, 0x54, 0xee, 0xd1, 0x88, 0xba, 0x9d, 0x27, 0x3a, 0x10, 0x15, 0xc9, 0x17, 0x98, 0x0f, 0xbf,
-	0xa1, 0x06, 0x58, 0x3c, 0x17, 0xf2, 0x22, 0xae, 0x81, 0xdc, 0x15, 0xe9, 0xe9, 0xbb, 0x08, 0xc9,
-	0x21, 0x5c, 0x03, 0x8b, 0x1d, 0x1c, 0xa5, 0xfa, 0x46, 0x2f, 0x20, 0x0d, 0x9c, 0x33, 0x50, 0xbd,
-	0x60, 0x38, 0xe1, 0xd8, 0x13, 0x21, 0x4d, 0x5e, 0xd1, 0x80, 0x43, 0x08, 0xf2, 0xea, 0x4c, 0xd4,
-	0xb9, 0x6a, 0x0c, 0xff, 0x05, 0xf2, 0x11, 0x0d, 0x78, 0x7d, 0x61, 0x33, 0xb7, 0x55, 0xdc, 0xbb,
-	0x77, 0xf3, 0x4e, 0x7d, 0x45, 0x03, 0xa4, 0x42, 0x9c, 0x9f, 0x16, 0x40, 0xee, 0x15, 0x0d, 0x60,
-	0x1d, 0x2c, 0x63, 0xdf, 0x67, 0x84, 0x73, 0xc3, 0x34, 0x84, 0x70, 0x1d, 0x2c, 0x09, 0xda, 0x0e,
-	0x3d, 0x4d, 0x57, 0x40, 0x06, 0x49, 0x61, 0x1f, 0x0b, 0xac, 0x6e, 0x95, 0x12, 0x52, 0x63, 0xb8,
-	0x07, 0x4a, 0x6a, 0x65, 0x6e, 0x92, 0xc6, 0x2d, 0xc2, 0xd4, 0xe5, 0x90, 0x6f, 0x56, 0xfb, 0x99,
-	0x5d, 0x54, 0xf6, 0x0f, 0x94, 0x19, 0x4d, 0x02, 0xf8, 0x08, 0x2c, 0x8b, 0xee, 0xe4, 0xb9, 0xbe,
-	0xda, 0xcf, 0xec, 0xaa, 0x18, 0x2f, 0x53, 0x1e, 0xdb, 0x68, 0x49, 0x74, 0xd5, 0xf1, 0xdd, 0x00,
-	0x96, 0xe8, 0xba, 0x61, 0xe2, 0x93, 0xae, 0x3a, 0xba, 0xf3, 0xcd, 0xb5, 0x7e, 0x66, 0xd7, 0x26,
-	0xc2, 0x4f, 0xa5, 0x0f, 0x2d, 0x8b, 0xae, 0x1a, 0xc0, 0x47, 0x00, 0xe8, 0x29, 0x29, 0x05, 0x7d,
-	0xf0, 0x96, 0xfb, 0x99, 0x5d, 0x50, 0x56, 0xc5, 0x3d, 0x1e, 0x42, 0x07, 0x2c, 0x6a, 0x6e, 0x4b,
-	0x71, 0x97, 0xfa, 0x99, 0x6d, 0x45, 0x34, 0xd0, 0x9c, 0xda, 0x25, 0x4b, 0xc5, 0x48, 0x4c, 0x3b,
-	0xc4, 0x57, 0x67, 0x9b, 0x85, 0x86, 0xd0, 0xf9, 0x66, 0x01, 0x58, 0x17, 0x5d, 0x44, 0x78, 0x1a,
-	0x09, 0xf8, 0x1c, 0xd4, 0x3c, 0x9a, 0x08, 0x86, 0x3d, 0xe1, 0x4e, 0x95, 0xb6, 0xf9, 0x70, 0x7c,
-	0xce, 0xcc, 0x46, 0x38, 0xa8, 0x3a, 0x34, 0x1d, 0x9a, 0xfa, 0xaf, 0x81, 0xc5, 0x56, 0x44, 0x69,
-	0xac, 0x3a, 0xa1, 0x84, 0x34, 0x80, 0x48, 0x55, 0x4d, 0xed, 0x72, 0x4e, 0xbd, 0x9c, 0xfe, 0x71,
-	0x73, 0x97, 0x67, 0x5a, 0xa5, 0xb9, 0x6e, 0x5e, 0x4f, 0x15, 0xad, 0x6d, 0xf2, 0x1d, 0x59, 0x5b,
-	0xd5, 0x4a, 0x35, 0x90, 0x63, 0x44, 0xa8, 0x4d, 0x2b, 0x21, 0x39, 0x84, 0x0f, 0x80, 0xc5, 0x48,
-	0x87, 0x30, 0x41, 0x7c, 0xb5, 0x39, 0x16, 0x1a, 0x61, 0x78, 0x1f, 0x58, 0x01, 0xe6, 0x6e, 0xca,
-	0x89, 0xaf, 0x77, 0x02, 0x2d, 0x07, 0x98, 0x7f, 0xc4, 0x89, 0xff, 0x34, 0xff, 0xb5, 0x7c, 0x7c,
-	0x61, 0x50, 0x3c, 0xf4, 0x3c, 0xc2, 0xf9, 0x45, 0xda, 0x8e, 0xc8, 0x1f, 0x74, 0xd8, 0x1e, 0x28,
-	0x71, 0x41, 0x19, 0x0e, 0x88, 0x7b, 0x45, 0x7a, 0xa6, 0xcf, 0x74, 0xd7, 0x18, 0xfb, 0xff, 0x48,
-	0x8f, 0xa3, 0x49, 0x60, 0x24, 0x7e, 0xcc, 0x81, 0xe2, 0x05, 0xc3, 0x1e, 0x31, 0xef, 0x3b, 0xd9,
-	0xab, 0x12, 0x32, 0x23, 0x61, 0x90, 0xd4, 0x16, 0x61, 0x4c, 0x68, 0x2a, 0xcc, 0xf7, 0x34, 0x84,
-	0x32, 0x83, 0x11, 0xd2, 0x25, 0x9e, 0x2a, 0x63, 0x1e, 0x19, 0x04, 0x0f, 0x40, 0xc5, 0x0f, 0xb9,
-	0x7a, 0xfe, 0xc6, 0x24, 0xa6, 0xac, 0xa7, 0xca, 0x62, 0x35, 0x57, 0xfa, 0x99, 0x5d, 0x36, 0x9e,
-	0xff, 0x2b, 0x07, 0x9a, 0x86, 0x70, 0x1f, 0x0c, 0x0d, 0x2e, 0x17, 0xd8, 0xbb, 0xd2, 0x85, 0x6b,
-	0xd6, 0xfa, 0x99, 0x5d, 0x32, 0x8e, 0x73, 0x69, 0x47, 0x53, 0x08, 0x3e, 0x03, 0xd5, 0x71, 0x9a,
-	0x5a, 0xa7, 0xaa, 0xaa, 0xd5, 0x84, 0xfd, 0xcc, 0xae, 0x8c, 0x42, 0x95, 0x07, 0xcd, 0x60, 0x78,
-	0x02, 0x56, 0x87, 0xc9, 0x8c, 0x88, 0x94, 0x25, 0xae, 0xfa, 0x34, 0x97, 0x15, 0xc1, 0xbd, 0x7e,
-	0x66, 0xaf, 0x18, 0x37, 0x52, 0xde, 0x63, 0x2c, 0x30, 0xba, 0x69, 0x92, 0xad, 0xe6, 0x93, 0x56,
-	0x1a, 0xa8, 0xee, 0xb7, 0x90, 0x06, 0xd2, 0x1a, 0x85, 0x71, 0x28, 0x54, 0xb7, 0x2f, 0x22, 0x0d,
-	0xe0, 0x33, 0x50, 0xa0, 0x1d, 0xc2, 0x58, 0xe8, 0x13, 0xae, 0x6e, 0xda, 0x3f, 0x7b, 0xbc, 0xa3,
-	0x71, 0x7c, 0xf3, 0xd3, 0x37, 0xd7, 0x1b, 0xf3, 0x6f, 0xaf, 0x37, 0xe6, 0x7f, 0xbb, 0xde, 0x98,
-	0xff, 0xf6, 0xdd, 0xc6, 0xdc, 0xdb, 0x77, 0x1b, 0x73, 0x3f, 0xbf, 0xdb, 0x98, 0xfb, 0xe4, 0x70,
-	0xe2, 0x18, 0x3e, 0x89, 0x88, 0x27, 0x18, 0x4d, 0x42, 0x6f, 0xfb, 0x3c, 0x0c, 0x12, 0x2c, 0x52,
-	0x46, 0xf8, 0xf6, 0x69, 0xe2, 0xa7, 0x5c, 0xb0, 0x90, 0xf0, 0x06, 0x4e, 0x3c, 0x9a, 0x6c, 0xcb,
-	0xff, 0x5e, 0x5d, 0xf5, 0x0f, 0x4c, 0x9d, 0xd2, 0xad, 0x25, 0xf5, 0xcf, 0xea, 0xc9, 0xef, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x1f, 0xb7, 0x1d, 0x2a, 0x9f, 0x0d, 0x00, 0x00,
+	// 1521 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcd, 0x4e, 0x1c, 0xc7,
+	0x16, 0x06, 0x66, 0x80, 0x99, 0x9a, 0x5f, 0x0a, 0xcc, 0x1d, 0xe3, 0x7b, 0x69, 0x6e, 0x2f, 0xae,
+	0xb8, 0x92, 0x61, 0x0c, 0x16, 0xba, 0xc8, 0xd6, 0x5d, 0x30, 0x80, 0x6d, 0x1c, 0xc7, 0x41, 0x05,
+	0x51, 0xa4, 0x44, 0x51, 0xab, 0xa6, 0xbb, 0xdc, 0x74, 0xe8, 0xee, 0x1a, 0x55, 0x55, 0x4f, 0x66,
+	0xa2, 0x3c, 0x40, 0xa4, 0x6c, 0xb2, 0xcc, 0x22, 0x8b, 0xbc, 0x44, 0xde, 0xc1, 0xca, 0xca, 0xcb,
+	0x28, 0x8b, 0x56, 0x84, 0x77, 0xb3, 0x1c, 0x29, 0xfb, 0xa8, 0x7e, 0xe6, 0x17, 0x14, 0x19, 0x56,
+	0x5d, 0xe7, 0x3b, 0xa7, 0xbe, 0xaf, 0xce, 0xa9, 0xd3, 0xd5, 0xd5, 0x60, 0x8d, 0x88, 0x0b, 0xc2,
+	0xa2, 0x20, 0x16, 0x75, 0xd2, 0x8e, 0xea, 0xed, 0x1d, 0xf9, 0xd8, 0x6e, 0x31, 0x2a, 0x28, 0xac,
+	0x0e, 0x7d, 0xdb, 0x12, 0x6c, 0xef, 0xac, 0xad, 0xf8, 0xd4, 0xa7, 0xca, 0x59, 0x97, 0x23, 0x1d,
+	0x67, 0xff, 0x99, 0x01, 0x0b, 0xa7, 0x98, 0xe1, 0x88, 0xc3, 0x1d, 0x90, 0x27, 0xed, 0xc8, 0xf1,
+	0x48, 0x4c, 0xa3, 0xda, 0xec, 0xc6, 0xec, 0x66, 0xbe, 0xb1, 0xd2, 0x4f, 0xad, 0x6a, 0x17, 0x47,
+	0xe1, 0x13, 0x7b, 0xe8, 0xb2, 0x51, 0x8e, 0xb4, 0xa3, 0x23, 0x39, 0x84, 0xff, 0x07, 0x25, 0x12,
+	0xe3, 0x66, 0x48, 0x1c, 0x97, 0x11, 0x2c, 0x48, 0x6d, 0x6e, 0x63, 0x76, 0x33, 0xd7, 0xa8, 0xf5,
+	0x53, 0x6b, 0xc5, 0x4c, 0x1b, 0x77, 0xdb, 0xa8, 0xa8, 0xed, 0x43, 0x65, 0xc2, 0xff, 0x81, 0xc2,
+	0xc0, 0x8f, 0xc3, 0xb0, 0x96, 0x51, 0x93, 0x57, 0xfb, 0xa9, 0x05, 0x27, 0x27, 0xe3, 0x30, 0xb4,
+	0x11, 0x30, 0x53, 0x71, 0x18, 0xc2, 0x03, 0x00, 0x48, 0x47, 0x30, 0xec, 0x90, 0xa0, 0xc5, 0x6b,
+	0xd9, 0x8d, 0xcc, 0x66, 0xa6, 0x61, 0x5f, 0xa5, 0x56, 0xfe, 0x58, 0xa2, 0xc7, 0x27, 0xa7, 0xbc,
+	0x9f, 0x5a, 0x4b, 0x86, 0x64, 0x18, 0x68, 0xa3, 0xbc, 0x32, 0x8e, 0x83, 0x16, 0x87, 0x5f, 0x82,
+	0xa2, 0x7b, 0x81, 0x83, 0xd8, 0x71, 0x69, 0xfc, 0x26, 0xf0, 0x6b, 0xf3, 0x1b, 0xb3, 0x9b, 0x85,
+	0xdd, 0x7f, 0x6d, 0x4f, 0xd7, 0x6d, 0xfb, 0x50, 0x46, 0x1d, 0xaa, 0xa0, 0xc6, 0x83, 0xb7, 0xa9,
+	0x35, 0xd3, 0x4f, 0xad, 0x65, 0x4d, 0x3d, 0x4e, 0x60, 0xa3, 0x82, 0x3b, 0x8a, 0x84, 0x2f, 0xc0,
+	0x92, 0x59, 0x3d, 0x17, 0x58, 0x10, 0x47, 0xb0, 0x80, 0xd4, 0x16, 0x54, 0x82, 0xff, 0xec, 0xa7,
+	0x56, 0x6d, 0x22, 0xc1, 0x51, 0x88, 0x8d, 0x2a, 0x1a, 0x3b, 0x93, 0xd0, 0x39, 0x0b, 0x08, 0x7c,
+	0x0d, 0x96, 0x83, 0xb8, 0x8d, 0x59, 0x80, 0x63, 0xe1, 0xbc, 0x49, 0xc2, 0xd0, 0xe1, 0x2e, 0x8e,
+	0x6b, 0x8b, 0x8a, 0x6b, 0xbd, 0x9f, 0x5a, 0x6b, 0x9a, 0xeb, 0x86, 0x20, 0x1b, 0x2d, 0x0d, 0xd1,
+	0x67, 0x49, 0x18, 0x9e, 0xb9, 0x38, 0x7e, 0x92, 0xfd, 0xf1, 0x67, 0x6b, 0xc6, 0xfe, 0xa9, 0x04,
+	0x0a, 0x63, 0x99, 0xc1, 0x08, 0x54, 0x2e, 0x68, 0x44, 0xb8, 0x20, 0xd8, 0x73, 0x9a, 0x21, 0x75,
+	0x2f, 0x4d, 0x0b, 0x1c, 0xfd, 0x9e, 0x5a, 0xff, 0xf1, 0x03, 0x71, 0x91, 0x34, 0xb7, 0x5d, 0x1a,
+	0xd5, 0x5d, 0xca, 0x23, 0xca, 0xcd, 0x63, 0x8b, 0x7b, 0x97, 0x75, 0xd1, 0x6d, 0x11, 0xbe, 0x7d,
+	0x12, 0x8b, 0x7e, 0x6a, 0xad, 0xea, 0xb5, 0x4c, 0x51, 0xd9, 0xa8, 0x3c, 0x44, 0x1a, 0x12, 0x80,
+	0x5d, 0x50, 0xf6, 0x30, 0x75, 0xde, 0x50, 0x76, 0x69, 0xd4, 0xe6, 0x94, 0xda, 0xd9, 0x87, 0xab,
+	0x5d, 0xa5, 0x56, 0xf1, 0xe8, 0xe0, 0x93, 0x67, 0x94, 0x5d, 0x2a, 0xce, 0x7e, 0x6a, 0xdd, 0xd3,
+	0xea, 0x93, 0xcc, 0x36, 0x2a, 0x7a, 0x98, 0x0e, 0xc3, 0xe0, 0x67, 0xa0, 0x3a, 0x0c, 0xe0, 0x49,
+	0xab, 0x45, 0x99, 0x30, 0x9d, 0xb7, 0x75, 0x95, 0x5a, 0x65, 0x43, 0x79, 0xa6, 0x3d, 0xfd, 0xd4,
+	0xfa, 0xc7, 0x14, 0xa9, 0x99, 0x63, 0xa3, 0xb2, 0xa1, 0x35, 0xa1, 0x90, 0x83, 0x22, 0x09, 0x5a,
+	0x3b, 0x7b, 0x8f, 0x4c, 0x46, 0x59, 0x95, 0xd1, 0xe9, 0xad, 0x32, 0x2a, 0x1c, 0x9f, 0x9c, 0xee,
+	0xec, 0x3d, 0x1a, 0x24, 0x64, 0xfa, 0x6c, 0x9c, 0xd6, 0x46, 0x05, 0x6d, 0xea, 0x6c, 0x4e, 0x80,
+	0x31, 0x9d, 0x0b, 0xcc, 0x2f, 0x54, 0x17, 0xe7, 0x1b, 0x9b, 0x57, 0xa9, 0x05, 0x34, 0xd3, 0x0b,
+	0xcc, 0x2f, 0x46, 0xfb, 0xd2, 0xec, 0x7e, 0x83, 0x63, 0x11, 0x24, 0xd1, 0x80, 0x0b, 0xe8, 0xc9,
+	0x32, 0x6a, 0xb8, 0xfe, 0x3d, 0xb3, 0xfe, 0x85, 0x3b, 0xaf, 0x7f, 0xef, 0xa6, 0xf5, 0xef, 0x4d,
+	0xae, 0x5f, 0xc7, 0x0c, 0x45, 0xf7, 0x8d, 0xe8, 0xe2, 0x9d, 0x45, 0xf7, 0x6f, 0x12, 0xdd, 0x9f,
+	0x14, 0xd5, 0x31, 0xb2, 0xd9, 0xa7, 0x2a, 0x51, 0xcb, 0xdd, 0xbd, 0xd9, 0xaf, 0x15, 0xb5, 0x3c,
+	0x44, 0xb4, 0xdc, 0xb7, 0x60, 0xc5, 0xa5, 0x31, 0x17, 0x12, 0x8b, 0x69, 0x2b, 0x24, 0x46, 0x33,
+	0xaf, 0x34, 0x4f, 0x6e, 0xa5, 0xf9, 0xc0, 0x9c, 0x3c, 0x37, 0xf0, 0xd9, 0x68, 0x79, 0x12, 0xd6,
+	0xea, 0x2d, 0x50, 0x6d, 0x11, 0x41, 0x18, 0x6f, 0x26, 0xcc, 0x37, 0xca, 0x40, 0x29, 0x1f, 0xdf,
+	0x4a, 0xd9, 0xbc, 0x07, 0xd3, 0x5c, 0x36, 0xaa, 0x8c, 0x20, 0xad, 0xf8, 0x15, 0x28, 0x07, 0x72,
+	0x19, 0xcd, 0x24, 0x34, 0x7a, 0x05, 0xa5, 0x77, 0x78, 0x2b, 0x3d, 0xf3, 0x32, 0x4f, 0x32, 0xd9,
+	0xa8, 0x34, 0x00, 0xb4, 0x56, 0x02, 0x60, 0x94, 0x04, 0xcc, 0xf1, 0x43, 0xec, 0x06, 0x84, 0x19,
+	0xbd, 0xa2, 0xd2, 0x7b, 0x7e, 0x2b, 0xbd, 0xfb, 0x5a, 0xef, 0x3a, 0x9b, 0x8d, 0xaa, 0x12, 0x7c,
+	0xae, 0x31, 0x2d, 0xeb, 0x81, 0x62, 0x93, 0xb0, 0x30, 0x88, 0x8d, 0x60, 0x49, 0x09, 0x1e, 0xdc,
+	0x4a, 0xd0, 0xf4, 0xe9, 0x38, 0x8f, 0x8d, 0x0a, 0xda, 0x1c, 0x16, 0xd2, 0xc5, 0x02, 0x87, 0x5d,
+	0x2e, 0x8c, 0x4e, 0xf5, 0xee, 0x85, 0x9c, 0x64, 0xb2, 0x51, 0x69, 0x00, 0x0c, 0x33, 0x0a, 0x69,
+	0xec, 0xd1, 0x41, 0x46, 0x4b, 0x77, 0xcf, 0x68, 0x9c, 0xc7, 0x46, 0x05, 0x6d, 0x2a, 0x95, 0x97,
+	0xd9, 0x5c, 0xb9, 0x5a, 0x79, 0x99, 0xcd, 0x55, 0xaa, 0x55, 0x54, 0xea, 0xd2, 0x90, 0x3a, 0xed,
+	0xc7, 0x3a, 0x10, 0x15, 0xc8, 0xd7, 0x98, 0x0f, 0xde, 0xa1, 0x3a, 0x98, 0x57, 0x5f, 0x40, 0x58,
+	0x05, 0x99, 0x4b, 0xd2, 0xd5, 0xdf, 0x22, 0x24, 0x87, 0x70, 0x05, 0xcc, 0xb7, 0x71, 0x98, 0xe8,
+	0xbb, 0x46, 0x1e, 0x69, 0xc3, 0x3e, 0x05, 0x95, 0x73, 0x86, 0x63, 0x8e, 0x5d, 0x11, 0xd0, 0xf8,
+	0x15, 0xf5, 0x39, 0x84, 0x20, 0xab, 0xce, 0x44, 0x3d, 0x57, 0x8d, 0xe1, 0x7f, 0x41, 0x36, 0xa4,
+	0x3e, 0xaf, 0xcd, 0x6d, 0x64, 0x36, 0x0b, 0xbb, 0xf7, 0xae, 0x7f, 0xed, 0x5f, 0x51, 0x1f, 0xa9,
+	0x10, 0xfb, 0xd7, 0x39, 0x90, 0x79, 0x45, 0x7d, 0x58, 0x03, 0x8b, 0xd8, 0xf3, 0x18, 0xe1, 0xdc,
+	0x30, 0x0d, 0x4c, 0xb8, 0x0a, 0x16, 0x04, 0x6d, 0x05, 0xae, 0xa6, 0xcb, 0x23, 0x63, 0x49, 0x61,
+	0x0f, 0x0b, 0xac, 0xbe, 0x2a, 0x45, 0xa4, 0xc6, 0x70, 0x17, 0x14, 0x55, 0x66, 0x4e, 0x9c, 0x44,
+	0x4d, 0xc2, 0xd4, 0xc7, 0x21, 0xdb, 0xa8, 0xf4, 0x52, 0xab, 0xa0, 0xf0, 0xd7, 0x0a, 0x46, 0xe3,
+	0x06, 0x7c, 0x08, 0x16, 0x45, 0x67, 0xfc, 0x5c, 0x5f, 0xee, 0xa5, 0x56, 0x45, 0x8c, 0xd2, 0x94,
+	0xc7, 0x36, 0x5a, 0x10, 0x1d, 0xf9, 0x84, 0x75, 0x90, 0x13, 0x1d, 0x27, 0x88, 0x3d, 0xd2, 0x51,
+	0x47, 0x77, 0xb6, 0xb1, 0xd2, 0x4b, 0xad, 0xea, 0x58, 0xf8, 0x89, 0xf4, 0xa1, 0x45, 0xd1, 0x51,
+	0x03, 0xf8, 0x10, 0x00, 0xbd, 0x24, 0xa5, 0xa0, 0x0f, 0xde, 0x52, 0x2f, 0xb5, 0xf2, 0x0a, 0x55,
+	0xdc, 0xa3, 0x21, 0xb4, 0xc1, 0xbc, 0xe6, 0xce, 0x29, 0xee, 0x62, 0x2f, 0xb5, 0x72, 0x21, 0xf5,
+	0x35, 0xa7, 0x76, 0xc9, 0x52, 0x31, 0x12, 0xd1, 0x36, 0xf1, 0xd4, 0xd9, 0x96, 0x43, 0x03, 0xd3,
+	0xfe, 0x7e, 0x0e, 0xe4, 0xce, 0x3b, 0x88, 0xf0, 0x24, 0x14, 0xf0, 0x19, 0xa8, 0xba, 0x34, 0x16,
+	0x0c, 0xbb, 0xc2, 0x99, 0x28, 0x6d, 0xe3, 0xc1, 0xe8, 0x9c, 0x99, 0x8e, 0xb0, 0x51, 0x65, 0x00,
+	0x1d, 0x98, 0xfa, 0xaf, 0x80, 0xf9, 0x66, 0x48, 0x69, 0xa4, 0x3a, 0xa1, 0x88, 0xb4, 0x01, 0x91,
+	0xaa, 0x9a, 0xda, 0xe5, 0x8c, 0xba, 0xd3, 0xfd, 0xfb, 0xfa, 0x2e, 0x4f, 0xb5, 0x4a, 0x63, 0xd5,
+	0xdc, 0xeb, 0xca, 0x5a, 0xdb, 0xcc, 0xb7, 0x65, 0x6d, 0x55, 0x2b, 0x55, 0x41, 0x86, 0x11, 0xa1,
+	0x36, 0xad, 0x88, 0xe4, 0x10, 0xae, 0x81, 0x1c, 0x23, 0x6d, 0xc2, 0x04, 0xf1, 0xd4, 0xe6, 0xe4,
+	0xd0, 0xd0, 0x86, 0xf7, 0x41, 0xce, 0xc7, 0xdc, 0x49, 0x38, 0xf1, 0xf4, 0x4e, 0xa0, 0x45, 0x1f,
+	0xf3, 0x4f, 0x39, 0xf1, 0x9e, 0x64, 0xbf, 0x93, 0x97, 0x2f, 0x0c, 0x0a, 0x07, 0xae, 0x4b, 0x38,
+	0x3f, 0x4f, 0x5a, 0x21, 0xf9, 0x9b, 0x0e, 0xdb, 0x05, 0x45, 0x2e, 0x28, 0xc3, 0x3e, 0x71, 0x2e,
+	0x49, 0xd7, 0xf4, 0x99, 0xee, 0x1a, 0x83, 0x7f, 0x44, 0xba, 0x1c, 0x8d, 0x1b, 0x46, 0xe2, 0x97,
+	0x0c, 0x28, 0x9c, 0x33, 0xec, 0x12, 0x73, 0xbf, 0x93, 0xbd, 0x2a, 0x4d, 0x66, 0x24, 0x8c, 0x25,
+	0xb5, 0x45, 0x10, 0x11, 0x9a, 0x08, 0xf3, 0x3e, 0x0d, 0x4c, 0x39, 0x83, 0x11, 0xd2, 0x21, 0xae,
+	0x2a, 0x63, 0x16, 0x19, 0x0b, 0xee, 0x83, 0xb2, 0x17, 0x70, 0x75, 0x6f, 0x8d, 0x48, 0x44, 0x59,
+	0x57, 0x95, 0x25, 0xd7, 0x58, 0xea, 0xa5, 0x56, 0xc9, 0x78, 0x3e, 0x56, 0x0e, 0x34, 0x69, 0xc2,
+	0x3d, 0x30, 0x00, 0xe4, 0x8d, 0xd7, 0xbd, 0xd4, 0x85, 0x6b, 0x54, 0x7b, 0xa9, 0x55, 0x34, 0x8e,
+	0x33, 0x89, 0xa3, 0x09, 0x0b, 0x3e, 0x05, 0x95, 0xd1, 0x34, 0x95, 0xa7, 0xb9, 0x48, 0xc3, 0x5e,
+	0x6a, 0x95, 0x87, 0xa1, 0xca, 0x83, 0xa6, 0x6c, 0x78, 0x0c, 0x96, 0x07, 0x93, 0x19, 0x11, 0x09,
+	0x8b, 0x1d, 0xf5, 0x6a, 0xea, 0xdb, 0xf3, 0xbd, 0x5e, 0x6a, 0x2d, 0x19, 0x37, 0x52, 0xde, 0x23,
+	0x2c, 0x30, 0xba, 0x0e, 0xc9, 0x56, 0xf3, 0x48, 0x33, 0xf1, 0x55, 0xf7, 0xe7, 0x90, 0x36, 0x24,
+	0x1a, 0x06, 0x51, 0x20, 0x54, 0xb7, 0xcf, 0x23, 0x6d, 0xc0, 0xa7, 0x20, 0x4f, 0xdb, 0x84, 0xb1,
+	0xc0, 0x23, 0xbc, 0x06, 0x3e, 0xe0, 0xb7, 0x02, 0x8d, 0xe2, 0x1b, 0x5f, 0xbc, 0xbd, 0x5a, 0x9f,
+	0x7d, 0x77, 0xb5, 0x3e, 0xfb, 0xc7, 0xd5, 0xfa, 0xec, 0x0f, 0xef, 0xd7, 0x67, 0xde, 0xbd, 0x5f,
+	0x9f, 0xf9, 0xed, 0xfd, 0xfa, 0xcc, 0xe7, 0x07, 0x63, 0xc7, 0xf0, 0x71, 0x48, 0x5c, 0xc1, 0x68,
+	0x1c, 0xb8, 0x5b, 0x67, 0x81, 0x1f, 0x63, 0x91, 0x30, 0xc2, 0xb7, 0x4e, 0x62, 0x2f, 0xe1, 0xf2,
+	0x2f, 0x82, 0xd7, 0x71, 0xec, 0xd2, 0x78, 0x4b, 0xfe, 0x15, 0x76, 0xd4, 0xbf, 0xa1, 0x3a, 0xa5,
+	0x9b, 0x0b, 0xea, 0x9f, 0xef, 0xf1, 0x5f, 0x03, 0x00, 0xc9, 0xe3, 0x2c, 0xdf, 0x39, 0x0e, 0x00,
+	0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -767,6 +793,26 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.InvariantFullScan {
+		i--
+		if m.InvariantFullScan {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.EnableStateTrie {
+		i--
+		if m.EnableStateTrie {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
 	{
 		size, err := m.ChainConfig.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -1445,6 +1491,12 @@ func (m *Params) Size() (n int) {
 	}
 	l = m.ChainConfig.Size()
 	n += 1 + l + sovEvm(uint64(l))
+	if m.EnableStateTrie {
+		n += 2
+	}
+	if m.InvariantFullScan {
+		n += 2
+	}
 	return n
 }
 
@@ -1901,6 +1953,46 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnableStateTrie", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnableStateTrie = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvariantFullScan", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.InvariantFullScan = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvm(dAtA[iNdEx:])
@@ -1,11 +1,14 @@
 package types
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // AccountKeeper defines the expected account keeper interface
@@ -19,11 +22,13 @@ type AccountKeeper interface {
 	SetAccount(ctx sdk.Context, account authtypes.AccountI)
 	RemoveAccount(ctx sdk.Context, account authtypes.AccountI)
 	GetParams(ctx sdk.Context) (params authtypes.Params)
+	Accounts(ctx context.Context, req *authtypes.QueryAccountsRequest) (*authtypes.QueryAccountsResponse, error)
 }
 
-// BankKeeper defines the expected interface needed to retrieve account balances.
+// BankKeeper defines the expected interface needed to retrieve account balances and supply.
 type BankKeeper interface {
 	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	GetSupply(ctx sdk.Context, denom string) sdk.Coin
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
 	// SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
@@ -40,8 +45,34 @@ type StakingKeeper interface {
 // Event Hooks
 // These can be utilized to customize evm transaction processing.
 
-// EvmHooks event hooks for evm tx processing
+// HookFailurePolicy defines how the failure of an EVM transaction hook is handled.
+type HookFailurePolicy int
+
+const (
+	// HookFailureRevert rejects the transaction if the pre processing fails, and reverts the whole
+	// transaction if the post processing fails.
+	HookFailureRevert HookFailurePolicy = iota
+	// HookFailureLog discards the state changes and events of the failed hook and logs the error,
+	// the transaction is processed as if the hook succeeded.
+	HookFailureLog
+)
+
+// EvmHooks event hooks for evm tx processing. Each hook runs on a branch of the given context, which
+// is discarded if the hook fails, so the hooks must only write to the state through that context.
 type EvmHooks interface {
-	// Must be called after tx is processed successfully, if return an error, the whole transaction is reverted.
-	PostTxProcessing(ctx sdk.Context, txHash common.Hash, logs []*ethtypes.Log) error
+	// PreTxProcessing is called before the message of an ethereum transaction is applied. If it
+	// returns an error and the failure policy is HookFailureRevert, the transaction is rejected.
+	PreTxProcessing(ctx sdk.Context, msg core.Message) error
+	// PostTxProcessing is called after the ethereum transaction is processed, with its message and
+	// receipt, which include the sender, recipient, gas used, status and logs of the transaction. If it
+	// returns an error and the failure policy is HookFailureRevert, the whole transaction is reverted.
+	PostTxProcessing(ctx sdk.Context, msg core.Message, receipt *ethtypes.Receipt) error
+	// BeginBlockEVM is called on the evm module BeginBlock. The state changes of a failed hook
+	// are discarded and the error is logged.
+	BeginBlockEVM(ctx sdk.Context, req abci.RequestBeginBlock) error
+	// EndBlockEVM is called on the evm module EndBlock, before the block bloom is emitted. The
+	// state changes of a failed hook are discarded and the error is logged.
+	EndBlockEVM(ctx sdk.Context, req abci.RequestEndBlock) error
+	// FailurePolicy returns how the failures of the transaction hooks are handled.
+	FailurePolicy() HookFailurePolicy
 }
@@ -25,6 +25,11 @@ const (
 const (
 	prefixCode = iota + 1
 	prefixStorage
+	prefixChainConfig
+	prefixStateTrie
+	prefixStateRoot
+	prefixStateTrieRefs
+	prefixStateTrieBuild
 )
 
 // prefix bytes for the EVM transient store
@@ -38,12 +43,19 @@ const (
 	prefixTransientTxHash
 	prefixTransientLogSize
 	prefixTransientTxLogs
+	prefixTransientStateTrieAccount
+	prefixTransientStateTrieSlot
 )
 
 // KVStore key prefixes
 var (
-	KeyPrefixCode    = []byte{prefixCode}
-	KeyPrefixStorage = []byte{prefixStorage}
+	KeyPrefixCode           = []byte{prefixCode}
+	KeyPrefixStorage        = []byte{prefixStorage}
+	KeyPrefixChainConfig    = []byte{prefixChainConfig}
+	KeyPrefixStateTrie      = []byte{prefixStateTrie}
+	KeyPrefixStateRoot      = []byte{prefixStateRoot}
+	KeyPrefixStateTrieRefs  = []byte{prefixStateTrieRefs}
+	KeyPrefixStateTrieBuild = []byte{prefixStateTrieBuild}
 )
 
 // Transient Store key prefixes
@@ -57,6 +69,8 @@ var (
 	KeyPrefixTransientTxHash            = []byte{prefixTransientTxHash}
 	KeyPrefixTransientLogSize           = []byte{prefixTransientLogSize}
 	KeyPrefixTransientTxLogs            = []byte{prefixTransientTxLogs}
+	KeyPrefixTransientStateTrieAccount  = []byte{prefixTransientStateTrieAccount}
+	KeyPrefixTransientStateTrieSlot     = []byte{prefixTransientStateTrieSlot}
 )
 
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
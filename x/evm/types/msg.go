@@ -241,6 +241,32 @@ func (msg MsgEthereumTx) AsMessage(signer ethtypes.Signer) (core.Message, error)
 	return msg.AsTransaction().AsMessage(signer)
 }
 
+// NewUnsignedMessage creates an Ethereum core.Message from an unsigned transaction and its sender.
+func NewUnsignedMessage(tx *ethtypes.Transaction, from common.Address) core.Message {
+	return ethtypes.NewMessage(
+		from, tx.To(), tx.Nonce(), tx.Value(), tx.Gas(), tx.GasPrice(), tx.Data(), tx.AccessList(), true,
+	)
+}
+
+// IsUnsigned returns true if the signature values of the transaction are all zero. The unsigned transactions
+// are only accepted from the impersonated accounts of development chains, their sender is the From field.
+// As the sender can't be recovered from the signature, GetSender returns an error and GetSigners panics.
+func (msg MsgEthereumTx) IsUnsigned() bool {
+	txData, err := UnpackTxData(msg.Data)
+	if err != nil {
+		return false
+	}
+
+	v, r, s := txData.GetRawSignatureValues()
+	for _, value := range []*big.Int{v, r, s} {
+		if value != nil && value.Sign() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 // GetSender extracts the sender address from the signature values using the latest signer for the given chainID.
 func (msg *MsgEthereumTx) GetSender(chainID *big.Int) (common.Address, error) {
 	signer := ethtypes.LatestSignerForChainID(chainID)
@@ -183,3 +183,24 @@ func (suite *MsgsTestSuite) TestMsgEthereumTx_Sign() {
 		}
 	}
 }
+
+func (suite *MsgsTestSuite) TestMsgEthereumTx_GetSenderUnsigned() {
+	tx := NewTx(suite.chainID, 0, &suite.to, nil, 100000, nil, []byte("test"), nil)
+	tx.From = suite.to.Hex()
+	suite.Require().True(tx.IsUnsigned())
+
+	// the sender of an unsigned transaction can't be recovered, it's only accepted by development chains
+	_, err := tx.GetSender(suite.chainID)
+	suite.Require().Error(err)
+	suite.Require().Panics(func() { tx.GetSigners() })
+
+	tx.From = suite.from.Hex()
+	suite.Require().NoError(tx.Sign(types.NewEIP155Signer(suite.chainID), suite.signer))
+	suite.Require().False(tx.IsUnsigned())
+
+	// the sender of a signed transaction is recovered from the signature
+	tx.From = suite.to.Hex()
+	sender, err := tx.GetSender(suite.chainID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(suite.from, sender)
+}
@@ -23,9 +23,11 @@ var (
 	ParamStoreKeyEnableCreate = []byte("EnableCreate")
 	ParamStoreKeyEnableCall   = []byte("EnableCall")
 	ParamStoreKeyExtraEIPs    = []byte("EnableExtraEIPs")
-	ParamStoreKeyChainConfig  = []byte("ChainConfig")
 	ParamStoreKeyNoBaseFee    = []byte("NoBaseFee")
 
+	ParamStoreKeyEnableStateTrie   = []byte("EnableStateTrie")
+	ParamStoreKeyInvariantFullScan = []byte("InvariantFullScan")
+
 	// AvailableExtraEIPs define the list of all EIPs that can be enabled by the EVM interpreter. These EIPs are applied in
 	// order and can override the instruction sets from the latest hard fork enabled by the ChainConfig. For more info
 	// check: https://github.com/ethereum/go-ethereum/blob/v1.10.4/core/vm/interpreter.go#L122
@@ -66,14 +68,16 @@ func (p Params) String() string {
 	return string(out)
 }
 
-// ParamSetPairs returns the parameter set pairs.
+// ParamSetPairs returns the parameter set pairs. The ChainConfig is not part of the
+// param space, as it is stored under its own key on the evm store.
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
 		paramtypes.NewParamSetPair(ParamStoreKeyEVMDenom, &p.EvmDenom, validateEVMDenom),
 		paramtypes.NewParamSetPair(ParamStoreKeyEnableCreate, &p.EnableCreate, validateBool),
 		paramtypes.NewParamSetPair(ParamStoreKeyEnableCall, &p.EnableCall, validateBool),
 		paramtypes.NewParamSetPair(ParamStoreKeyExtraEIPs, &p.ExtraEIPs, validateEIPs),
-		paramtypes.NewParamSetPair(ParamStoreKeyChainConfig, &p.ChainConfig, validateChainConfig),
+		paramtypes.NewParamSetPair(ParamStoreKeyEnableStateTrie, &p.EnableStateTrie, validateBool),
+		paramtypes.NewParamSetPair(ParamStoreKeyInvariantFullScan, &p.InvariantFullScan, validateBool),
 	}
 }
 
@@ -130,12 +134,3 @@ func validateEIPs(i interface{}) error {
 
 	return nil
 }
-
-func validateChainConfig(i interface{}) error {
-	cfg, ok := i.(ChainConfig)
-	if !ok {
-		return fmt.Errorf("invalid chain config type: %T", i)
-	}
-
-	return cfg.Validate()
-}
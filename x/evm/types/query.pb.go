@@ -1037,6 +1037,301 @@ func (m *QueryTraceTxResponse) GetData() []byte {
 	return nil
 }
 
+// QueryStateRootRequest defines the request type for querying the root of the
+// EVM world state trie.
+type QueryStateRootRequest struct {
+}
+
+func (m *QueryStateRootRequest) Reset()         { *m = QueryStateRootRequest{} }
+func (m *QueryStateRootRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryStateRootRequest) ProtoMessage()    {}
+func (*QueryStateRootRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e15a877459347994, []int{21}
+}
+func (m *QueryStateRootRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStateRootRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStateRootRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStateRootRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateRootRequest.Merge(m, src)
+}
+func (m *QueryStateRootRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStateRootRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateRootRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateRootRequest proto.InternalMessageInfo
+
+// QueryStateRootResponse defines the response type for querying the root of
+// the EVM world state trie.
+type QueryStateRootResponse struct {
+	// root is the hex hash of the world state trie root.
+	Root string `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+}
+
+func (m *QueryStateRootResponse) Reset()         { *m = QueryStateRootResponse{} }
+func (m *QueryStateRootResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryStateRootResponse) ProtoMessage()    {}
+func (*QueryStateRootResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e15a877459347994, []int{22}
+}
+func (m *QueryStateRootResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStateRootResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStateRootResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStateRootResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateRootResponse.Merge(m, src)
+}
+func (m *QueryStateRootResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStateRootResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateRootResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateRootResponse proto.InternalMessageInfo
+
+func (m *QueryStateRootResponse) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+// QueryProofRequest defines the request type for querying the EVM world state
+// trie proofs of an account.
+type QueryProofRequest struct {
+	// address is the ethereum hex address to query the proofs for.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// storage_keys defines the hex keys of the storage slots to prove.
+	StorageKeys []string `protobuf:"bytes,2,rep,name=storage_keys,json=storageKeys,proto3" json:"storage_keys,omitempty"`
+}
+
+func (m *QueryProofRequest) Reset()         { *m = QueryProofRequest{} }
+func (m *QueryProofRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryProofRequest) ProtoMessage()    {}
+func (*QueryProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e15a877459347994, []int{23}
+}
+func (m *QueryProofRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryProofRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryProofRequest.Merge(m, src)
+}
+func (m *QueryProofRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryProofRequest proto.InternalMessageInfo
+
+// QueryProofResponse defines the response type for querying the EVM world
+// state trie proofs of an account.
+type QueryProofResponse struct {
+	// state_root is the hex hash of the world state trie root the proofs are
+	// generated against.
+	StateRoot string `protobuf:"bytes,1,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	// account_proof defines the hex encoded trie nodes from the root to the
+	// account leaf.
+	AccountProof []string `protobuf:"bytes,2,rep,name=account_proof,json=accountProof,proto3" json:"account_proof,omitempty"`
+	// balance is the balance of the EVM denomination.
+	Balance string `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	// code_hash is the hex hash of the account code.
+	CodeHash string `protobuf:"bytes,4,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+	// nonce is the account's sequence number.
+	Nonce uint64 `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// storage_hash is the hex hash of the account storage trie root.
+	StorageHash string `protobuf:"bytes,6,opt,name=storage_hash,json=storageHash,proto3" json:"storage_hash,omitempty"`
+	// storage_proofs defines the proofs of the requested storage slots.
+	StorageProofs []StorageProof `protobuf:"bytes,7,rep,name=storage_proofs,json=storageProofs,proto3" json:"storage_proofs"`
+}
+
+func (m *QueryProofResponse) Reset()         { *m = QueryProofResponse{} }
+func (m *QueryProofResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryProofResponse) ProtoMessage()    {}
+func (*QueryProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e15a877459347994, []int{24}
+}
+func (m *QueryProofResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryProofResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryProofResponse.Merge(m, src)
+}
+func (m *QueryProofResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryProofResponse proto.InternalMessageInfo
+
+func (m *QueryProofResponse) GetStateRoot() string {
+	if m != nil {
+		return m.StateRoot
+	}
+	return ""
+}
+
+func (m *QueryProofResponse) GetAccountProof() []string {
+	if m != nil {
+		return m.AccountProof
+	}
+	return nil
+}
+
+func (m *QueryProofResponse) GetBalance() string {
+	if m != nil {
+		return m.Balance
+	}
+	return ""
+}
+
+func (m *QueryProofResponse) GetCodeHash() string {
+	if m != nil {
+		return m.CodeHash
+	}
+	return ""
+}
+
+func (m *QueryProofResponse) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *QueryProofResponse) GetStorageHash() string {
+	if m != nil {
+		return m.StorageHash
+	}
+	return ""
+}
+
+func (m *QueryProofResponse) GetStorageProofs() []StorageProof {
+	if m != nil {
+		return m.StorageProofs
+	}
+	return nil
+}
+
+// StorageProof defines the EVM world state trie proof of a storage slot.
+type StorageProof struct {
+	// key is the hex key of the storage slot.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the hex value of the storage slot.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// proof defines the hex encoded trie nodes from the storage root to the slot
+	// leaf.
+	Proof []string `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *StorageProof) Reset()         { *m = StorageProof{} }
+func (m *StorageProof) String() string { return proto.CompactTextString(m) }
+func (*StorageProof) ProtoMessage()    {}
+func (*StorageProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e15a877459347994, []int{25}
+}
+func (m *StorageProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageProof.Merge(m, src)
+}
+func (m *StorageProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageProof proto.InternalMessageInfo
+
+func (m *StorageProof) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageProof) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StorageProof) GetProof() []string {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QueryAccountRequest)(nil), "ethermint.evm.v1.QueryAccountRequest")
 	proto.RegisterType((*QueryAccountResponse)(nil), "ethermint.evm.v1.QueryAccountResponse")
@@ -1059,85 +1354,104 @@ func init() {
 	proto.RegisterType((*EstimateGasResponse)(nil), "ethermint.evm.v1.EstimateGasResponse")
 	proto.RegisterType((*QueryTraceTxRequest)(nil), "ethermint.evm.v1.QueryTraceTxRequest")
 	proto.RegisterType((*QueryTraceTxResponse)(nil), "ethermint.evm.v1.QueryTraceTxResponse")
+	proto.RegisterType((*QueryStateRootRequest)(nil), "ethermint.evm.v1.QueryStateRootRequest")
+	proto.RegisterType((*QueryStateRootResponse)(nil), "ethermint.evm.v1.QueryStateRootResponse")
+	proto.RegisterType((*QueryProofRequest)(nil), "ethermint.evm.v1.QueryProofRequest")
+	proto.RegisterType((*QueryProofResponse)(nil), "ethermint.evm.v1.QueryProofResponse")
+	proto.RegisterType((*StorageProof)(nil), "ethermint.evm.v1.StorageProof")
 }
 
 func init() { proto.RegisterFile("ethermint/evm/v1/query.proto", fileDescriptor_e15a877459347994) }
 
 var fileDescriptor_e15a877459347994 = []byte{
-	// 1162 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
-	0x14, 0xf7, 0x26, 0x4e, 0x9c, 0x3e, 0x27, 0x25, 0x4c, 0x83, 0x9a, 0x2c, 0xa9, 0x93, 0x6e, 0x9a,
-	0xef, 0x64, 0x17, 0x1b, 0x54, 0x89, 0x4a, 0x08, 0x92, 0x28, 0x94, 0xaa, 0x2d, 0x2a, 0x6e, 0xc4,
-	0x01, 0x0e, 0xd6, 0x78, 0x3d, 0xac, 0x57, 0xb5, 0x77, 0xdc, 0x9d, 0xb1, 0x71, 0x5a, 0xc2, 0x01,
-	0x89, 0x0a, 0xd4, 0x0b, 0x12, 0x77, 0xd4, 0x0b, 0x67, 0xfe, 0x8d, 0x1e, 0x2b, 0x71, 0xe1, 0x84,
-	0x50, 0x82, 0x10, 0x7f, 0x06, 0x9a, 0x8f, 0xf5, 0xd7, 0x7a, 0xeb, 0x14, 0xf5, 0x36, 0x1f, 0xef,
-	0xbd, 0xdf, 0xef, 0xcd, 0x7b, 0xfb, 0x7b, 0x5a, 0x58, 0x24, 0xbc, 0x4a, 0xc2, 0xba, 0x1f, 0x70,
-	0x87, 0xb4, 0xea, 0x4e, 0x2b, 0xef, 0x3c, 0x6c, 0x92, 0xf0, 0xd8, 0x6e, 0x84, 0x94, 0x53, 0x34,
-	0xdb, 0xb9, 0xb5, 0x49, 0xab, 0x6e, 0xb7, 0xf2, 0xe6, 0x9c, 0x47, 0x3d, 0x2a, 0x2f, 0x1d, 0xb1,
-	0x52, 0x76, 0xe6, 0x96, 0x4b, 0x59, 0x9d, 0x32, 0xa7, 0x8c, 0x19, 0x51, 0x01, 0x9c, 0x56, 0xbe,
-	0x4c, 0x38, 0xce, 0x3b, 0x0d, 0xec, 0xf9, 0x01, 0xe6, 0x3e, 0x0d, 0xb4, 0xed, 0xa2, 0x47, 0xa9,
-	0x57, 0x23, 0x0e, 0x6e, 0xf8, 0x0e, 0x0e, 0x02, 0xca, 0xe5, 0x25, 0xd3, 0xb7, 0x66, 0x8c, 0x8f,
-	0x00, 0x56, 0x77, 0x0b, 0xb1, 0x3b, 0xde, 0x56, 0x57, 0xd6, 0xfb, 0x70, 0xe9, 0x33, 0x01, 0xbb,
-	0xe7, 0xba, 0xb4, 0x19, 0xf0, 0x22, 0x79, 0xd8, 0x24, 0x8c, 0xa3, 0x79, 0xc8, 0xe0, 0x4a, 0x25,
-	0x24, 0x8c, 0xcd, 0x1b, 0xcb, 0xc6, 0xc6, 0x85, 0x62, 0xb4, 0xbd, 0x31, 0xf5, 0xc3, 0xb3, 0xa5,
-	0xd4, 0xbf, 0xcf, 0x96, 0x52, 0x96, 0x0b, 0x73, 0xfd, 0xae, 0xac, 0x41, 0x03, 0x46, 0x84, 0x6f,
-	0x19, 0xd7, 0x70, 0xe0, 0x92, 0xc8, 0x57, 0x6f, 0xd1, 0xdb, 0x70, 0xc1, 0xa5, 0x15, 0x52, 0xaa,
-	0x62, 0x56, 0x9d, 0x1f, 0x93, 0x77, 0x53, 0xe2, 0xe0, 0x13, 0xcc, 0xaa, 0x68, 0x0e, 0x26, 0x02,
-	0x2a, 0x9c, 0xc6, 0x97, 0x8d, 0x8d, 0x74, 0x51, 0x6d, 0xac, 0x0f, 0x61, 0x41, 0x82, 0x1c, 0xc8,
-	0x77, 0xfa, 0x1f, 0x2c, 0x9f, 0x18, 0x60, 0x0e, 0x8b, 0xa0, 0xc9, 0xae, 0xc2, 0x45, 0x55, 0x82,
-	0x52, 0x7f, 0xa4, 0x19, 0x75, 0xba, 0xa7, 0x0e, 0x91, 0x09, 0x53, 0x4c, 0x80, 0x0a, 0x7e, 0x63,
-	0x92, 0x5f, 0x67, 0x2f, 0x42, 0x60, 0x15, 0xb5, 0x14, 0x34, 0xeb, 0x65, 0x12, 0xea, 0x0c, 0x66,
-	0xf4, 0xe9, 0xa7, 0xf2, 0xd0, 0xba, 0x0d, 0x8b, 0x92, 0xc7, 0xe7, 0xb8, 0xe6, 0x57, 0x30, 0xa7,
-	0xe1, 0x40, 0x32, 0x57, 0x61, 0xda, 0xa5, 0xc1, 0x20, 0x8f, 0xac, 0x38, 0xdb, 0x8b, 0x65, 0xf5,
-	0xd4, 0x80, 0x2b, 0x09, 0xd1, 0x74, 0x62, 0xeb, 0xf0, 0x46, 0xc4, 0xaa, 0x3f, 0x62, 0x44, 0xf6,
-	0x35, 0xa6, 0x16, 0x35, 0xd1, 0xbe, 0xaa, 0xf3, 0xab, 0x94, 0xe7, 0x1d, 0xdd, 0x44, 0x1d, 0xd7,
-	0x51, 0x4d, 0x64, 0xdd, 0xd6, 0x60, 0xf7, 0x39, 0x0d, 0xb1, 0x37, 0x1a, 0x0c, 0xcd, 0xc2, 0xf8,
-	0x03, 0x72, 0xac, 0xfb, 0x4d, 0x2c, 0x7b, 0xe0, 0x77, 0x34, 0x7c, 0x27, 0x98, 0x86, 0x9f, 0x83,
-	0x89, 0x16, 0xae, 0x35, 0x23, 0x70, 0xb5, 0xb1, 0xae, 0xc3, 0xac, 0x6e, 0xa5, 0xca, 0x2b, 0x25,
-	0xb9, 0x0e, 0x6f, 0xf6, 0xf8, 0x69, 0x08, 0x04, 0x69, 0xd1, 0xfb, 0xd2, 0x6b, 0xba, 0x28, 0xd7,
-	0xd6, 0x23, 0x40, 0xd2, 0xf0, 0xa8, 0x7d, 0x87, 0x7a, 0x2c, 0x82, 0x40, 0x90, 0x96, 0x5f, 0x8c,
-	0x8a, 0x2f, 0xd7, 0xe8, 0x63, 0x80, 0xae, 0x40, 0xc8, 0xdc, 0xb2, 0x85, 0x35, 0x5b, 0x35, 0xad,
-	0x2d, 0xd4, 0xc4, 0x56, 0x72, 0xa4, 0xd5, 0xc4, 0xbe, 0xd7, 0x7d, 0xaa, 0x62, 0x8f, 0x67, 0x0f,
-	0xc9, 0x1f, 0x0d, 0xfd, 0xb0, 0x11, 0xb8, 0xe6, 0xb9, 0x09, 0xe9, 0x1a, 0xf5, 0x44, 0x76, 0xe3,
-	0x1b, 0xd9, 0xc2, 0x5b, 0xf6, 0xa0, 0xb2, 0xd9, 0x77, 0xa8, 0x57, 0x94, 0x26, 0xe8, 0xe6, 0x10,
-	0x52, 0xeb, 0x23, 0x49, 0x29, 0x9c, 0x5e, 0x56, 0xd6, 0x9c, 0x7e, 0x87, 0x7b, 0x38, 0xc4, 0xf5,
-	0xe8, 0x1d, 0xac, 0xbb, 0x9a, 0x60, 0x74, 0xaa, 0x09, 0x5e, 0x87, 0xc9, 0x86, 0x3c, 0x91, 0x0f,
-	0x94, 0x2d, 0xcc, 0xc7, 0x29, 0x2a, 0x8f, 0xfd, 0xf4, 0xf3, 0x3f, 0x97, 0x52, 0x45, 0x6d, 0x6d,
-	0xed, 0xc2, 0x65, 0x5d, 0x7b, 0xcc, 0x7d, 0xf7, 0x00, 0xd7, 0x6a, 0xbd, 0xb5, 0xa9, 0x60, 0x8e,
-	0xa3, 0xda, 0x88, 0xb5, 0xf5, 0x01, 0x5c, 0x3c, 0xe4, 0x55, 0x65, 0xd6, 0xa9, 0x0b, 0x0e, 0x3d,
-	0x16, 0x59, 0x89, 0x35, 0xba, 0x0c, 0x19, 0x0f, 0xb3, 0x92, 0x8b, 0x1b, 0xfa, 0x63, 0x9a, 0xf4,
-	0x30, 0x3b, 0xc0, 0x0d, 0x6b, 0x1d, 0x2e, 0x1d, 0x32, 0xee, 0xd7, 0x31, 0x27, 0x37, 0x71, 0x97,
-	0xfc, 0x2c, 0x8c, 0x7b, 0x58, 0x85, 0x48, 0x17, 0xc5, 0xd2, 0xfa, 0xb5, 0x53, 0x87, 0x10, 0xbb,
-	0xe4, 0xa8, 0x1d, 0xa1, 0xe5, 0x61, 0xbc, 0xce, 0x3c, 0x9d, 0xe3, 0x52, 0x3c, 0xc7, 0xbb, 0xcc,
-	0x3b, 0x14, 0x67, 0xa4, 0x59, 0x3f, 0x6a, 0x17, 0x85, 0x2d, 0x5a, 0x80, 0x29, 0xde, 0x2e, 0xf9,
-	0x41, 0x85, 0xb4, 0x35, 0x9b, 0x0c, 0x6f, 0xdf, 0x12, 0x5b, 0xf4, 0x11, 0x4c, 0x73, 0x11, 0xbf,
-	0xe4, 0xd2, 0xe0, 0x2b, 0xdf, 0x93, 0xdf, 0x75, 0xb6, 0x70, 0x25, 0x1e, 0x56, 0xb2, 0x38, 0x90,
-	0x46, 0xc5, 0x2c, 0xef, 0x6e, 0xac, 0x2d, 0xfd, 0xe9, 0x74, 0x68, 0x26, 0xbf, 0x5d, 0xe1, 0x1f,
-	0x80, 0x09, 0x69, 0x8c, 0xbe, 0x37, 0x20, 0xa3, 0xa5, 0x0a, 0xad, 0xc6, 0xd1, 0x86, 0xcc, 0x22,
-	0x73, 0x6d, 0x94, 0x99, 0x02, 0xb6, 0xb6, 0xbf, 0xfb, 0xfd, 0xef, 0x9f, 0xc7, 0x56, 0xd1, 0x8a,
-	0x13, 0x1b, 0x77, 0x5a, 0xae, 0x9c, 0xc7, 0xfa, 0xdb, 0x3c, 0x41, 0xbf, 0x18, 0x30, 0xd3, 0x37,
-	0x11, 0xd0, 0x76, 0x02, 0xcc, 0xb0, 0xc9, 0x63, 0xee, 0x9c, 0xcf, 0x58, 0x33, 0x2b, 0x48, 0x66,
-	0x3b, 0x68, 0x2b, 0xce, 0x2c, 0x1a, 0x3e, 0x31, 0x82, 0xbf, 0x19, 0x30, 0x3b, 0x28, 0xee, 0xc8,
-	0x4e, 0x80, 0x4d, 0x98, 0x29, 0xa6, 0x73, 0x6e, 0x7b, 0xcd, 0xf4, 0x86, 0x64, 0xfa, 0x1e, 0x2a,
-	0xc4, 0x99, 0xb6, 0x22, 0x9f, 0x2e, 0xd9, 0xde, 0x79, 0x75, 0x82, 0x9e, 0x18, 0x90, 0xd1, 0x32,
-	0x9e, 0x58, 0xda, 0xfe, 0x09, 0x91, 0x58, 0xda, 0x81, 0x69, 0x60, 0xed, 0x48, 0x5a, 0x6b, 0xe8,
-	0x5a, 0x9c, 0x96, 0x1e, 0x0b, 0xac, 0xe7, 0xe9, 0x9e, 0x1a, 0x90, 0xd1, 0x82, 0x9e, 0x48, 0xa4,
-	0x7f, 0x7a, 0x24, 0x12, 0x19, 0x98, 0x0b, 0x56, 0x5e, 0x12, 0xd9, 0x46, 0x9b, 0x71, 0x22, 0x4c,
-	0x99, 0x76, 0x79, 0x38, 0x8f, 0x1f, 0x90, 0xe3, 0x13, 0xf4, 0x08, 0xd2, 0x42, 0xf7, 0x91, 0x95,
-	0xd8, 0x32, 0x9d, 0x61, 0x62, 0xae, 0xbc, 0xd4, 0x46, 0x73, 0xd8, 0x94, 0x1c, 0x56, 0xd0, 0xd5,
-	0x61, 0xdd, 0x54, 0xe9, 0x7b, 0x89, 0xaf, 0x61, 0x52, 0x49, 0x1f, 0xba, 0x96, 0x10, 0xb9, 0x4f,
-	0x61, 0xcd, 0xd5, 0x11, 0x56, 0x9a, 0xc1, 0xb2, 0x64, 0x60, 0xa2, 0xf9, 0x38, 0x03, 0xa5, 0xad,
-	0xa8, 0x0d, 0x19, 0x2d, 0x96, 0x68, 0x39, 0x1e, 0xb3, 0x5f, 0x47, 0xcd, 0xf5, 0x51, 0x62, 0x16,
-	0xe1, 0x5a, 0x12, 0x77, 0x11, 0x99, 0x71, 0x5c, 0xc2, 0xab, 0x25, 0x57, 0xc0, 0x7d, 0x0b, 0xd9,
-	0x1e, 0x9d, 0x3d, 0x07, 0xfa, 0x90, 0x9c, 0x87, 0x08, 0xb5, 0xb5, 0x26, 0xb1, 0x97, 0x51, 0x6e,
-	0x08, 0xb6, 0x36, 0x2f, 0x79, 0x98, 0xa1, 0x6f, 0x20, 0xa3, 0x15, 0x31, 0xb1, 0xf7, 0xfa, 0x85,
-	0x3d, 0xb1, 0xf7, 0x06, 0x84, 0xf5, 0x65, 0xd9, 0x2b, 0x29, 0xe7, 0xed, 0xfd, 0x2f, 0x9f, 0x9f,
-	0xe6, 0x8c, 0x17, 0xa7, 0x39, 0xe3, 0xaf, 0xd3, 0x9c, 0xf1, 0xd3, 0x59, 0x2e, 0xf5, 0xe2, 0x2c,
-	0x97, 0xfa, 0xe3, 0x2c, 0x97, 0xfa, 0x62, 0xcf, 0xf3, 0x79, 0xb5, 0x59, 0xb6, 0x5d, 0x5a, 0x77,
-	0x0e, 0x6b, 0xc4, 0xe5, 0x21, 0x0d, 0x7c, 0x77, 0xf7, 0xbe, 0xef, 0x05, 0x98, 0x37, 0x43, 0xc2,
-	0x76, 0x6f, 0x05, 0x95, 0x26, 0xe3, 0xa1, 0x4f, 0x98, 0x83, 0x03, 0x97, 0x06, 0xbb, 0x22, 0x78,
-	0x5b, 0x42, 0xf0, 0xe3, 0x06, 0x61, 0xe5, 0x49, 0xf9, 0xcb, 0xf0, 0xee, 0x7f, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x84, 0x2d, 0xbd, 0xe1, 0xfb, 0x0c, 0x00, 0x00,
+	// 1381 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcf, 0x6f, 0x1b, 0xc5,
+	0x17, 0xcf, 0xc6, 0x4e, 0x9c, 0x3c, 0x3b, 0xfd, 0xe6, 0x3b, 0x71, 0xa9, 0xbb, 0xa4, 0x4e, 0xba,
+	0x49, 0x9c, 0xb4, 0x4d, 0xbc, 0xc4, 0xa0, 0x4a, 0x54, 0x42, 0x90, 0x46, 0xa1, 0x54, 0x69, 0x51,
+	0x71, 0x2b, 0x0e, 0xe5, 0x60, 0x4d, 0xd6, 0xd3, 0xb5, 0x55, 0x7b, 0xc7, 0xdd, 0x19, 0x1b, 0xa7,
+	0x25, 0x1c, 0x10, 0x54, 0xa0, 0x5e, 0x90, 0x10, 0x57, 0xd4, 0x0b, 0x67, 0xfe, 0x8d, 0x1e, 0x2b,
+	0x71, 0xe1, 0x84, 0x50, 0xcb, 0x81, 0x03, 0x7f, 0x04, 0x9a, 0x1f, 0x6b, 0xef, 0x66, 0xed, 0x6c,
+	0x8b, 0xb8, 0xed, 0xbc, 0x79, 0xef, 0x7d, 0x3e, 0x6f, 0xe6, 0xfd, 0x98, 0x85, 0x45, 0xc2, 0x1b,
+	0xc4, 0x6f, 0x37, 0x3d, 0x6e, 0x93, 0x5e, 0xdb, 0xee, 0x6d, 0xdb, 0x0f, 0xba, 0xc4, 0x3f, 0x2c,
+	0x77, 0x7c, 0xca, 0x29, 0x9a, 0x1f, 0xec, 0x96, 0x49, 0xaf, 0x5d, 0xee, 0x6d, 0x9b, 0x79, 0x97,
+	0xba, 0x54, 0x6e, 0xda, 0xe2, 0x4b, 0xe9, 0x99, 0x17, 0x1d, 0xca, 0xda, 0x94, 0xd9, 0x07, 0x98,
+	0x11, 0xe5, 0xc0, 0xee, 0x6d, 0x1f, 0x10, 0x8e, 0xb7, 0xed, 0x0e, 0x76, 0x9b, 0x1e, 0xe6, 0x4d,
+	0xea, 0x69, 0xdd, 0x45, 0x97, 0x52, 0xb7, 0x45, 0x6c, 0xdc, 0x69, 0xda, 0xd8, 0xf3, 0x28, 0x97,
+	0x9b, 0x4c, 0xef, 0x9a, 0x31, 0x3e, 0x02, 0x58, 0xed, 0x9d, 0x8d, 0xed, 0xf1, 0xbe, 0xda, 0xb2,
+	0xde, 0x85, 0x85, 0x4f, 0x04, 0xec, 0x8e, 0xe3, 0xd0, 0xae, 0xc7, 0xab, 0xe4, 0x41, 0x97, 0x30,
+	0x8e, 0x0a, 0x90, 0xc1, 0xf5, 0xba, 0x4f, 0x18, 0x2b, 0x18, 0xcb, 0xc6, 0xc6, 0x6c, 0x35, 0x58,
+	0x5e, 0x99, 0xf9, 0xf6, 0xe9, 0xd2, 0xc4, 0x5f, 0x4f, 0x97, 0x26, 0x2c, 0x07, 0xf2, 0x51, 0x53,
+	0xd6, 0xa1, 0x1e, 0x23, 0xc2, 0xf6, 0x00, 0xb7, 0xb0, 0xe7, 0x90, 0xc0, 0x56, 0x2f, 0xd1, 0x9b,
+	0x30, 0xeb, 0xd0, 0x3a, 0xa9, 0x35, 0x30, 0x6b, 0x14, 0x26, 0xe5, 0xde, 0x8c, 0x10, 0x7c, 0x84,
+	0x59, 0x03, 0xe5, 0x61, 0xca, 0xa3, 0xc2, 0x28, 0xb5, 0x6c, 0x6c, 0xa4, 0xab, 0x6a, 0x61, 0xbd,
+	0x0f, 0x67, 0x25, 0xc8, 0xae, 0x3c, 0xa7, 0x7f, 0xc1, 0xf2, 0xb1, 0x01, 0xe6, 0x28, 0x0f, 0x9a,
+	0xec, 0x1a, 0x9c, 0x52, 0x57, 0x50, 0x8b, 0x7a, 0x9a, 0x53, 0xd2, 0x1d, 0x25, 0x44, 0x26, 0xcc,
+	0x30, 0x01, 0x2a, 0xf8, 0x4d, 0x4a, 0x7e, 0x83, 0xb5, 0x70, 0x81, 0x95, 0xd7, 0x9a, 0xd7, 0x6d,
+	0x1f, 0x10, 0x5f, 0x47, 0x30, 0xa7, 0xa5, 0x1f, 0x4b, 0xa1, 0xb5, 0x0f, 0x8b, 0x92, 0xc7, 0xa7,
+	0xb8, 0xd5, 0xac, 0x63, 0x4e, 0xfd, 0x63, 0xc1, 0x9c, 0x87, 0x9c, 0x43, 0xbd, 0xe3, 0x3c, 0xb2,
+	0x42, 0xb6, 0x13, 0x8b, 0xea, 0x89, 0x01, 0xe7, 0xc6, 0x78, 0xd3, 0x81, 0xad, 0xc3, 0xff, 0x02,
+	0x56, 0x51, 0x8f, 0x01, 0xd9, 0xff, 0x30, 0xb4, 0x20, 0x89, 0xae, 0xaa, 0x7b, 0x7e, 0x9d, 0xeb,
+	0x79, 0x0b, 0xf2, 0x51, 0xd3, 0xa4, 0x24, 0xb2, 0xf6, 0x35, 0xd8, 0x6d, 0x4e, 0x7d, 0xec, 0x26,
+	0x83, 0xa1, 0x79, 0x48, 0xdd, 0x27, 0x87, 0x3a, 0xdf, 0xc4, 0x67, 0x08, 0x7e, 0x13, 0xf2, 0x51,
+	0x67, 0x1a, 0x3e, 0x0f, 0x53, 0x3d, 0xdc, 0xea, 0x06, 0xe0, 0x6a, 0x61, 0x5d, 0x86, 0x79, 0x9d,
+	0x4a, 0xf5, 0xd7, 0x0a, 0x72, 0x1d, 0xfe, 0x1f, 0xb2, 0xd3, 0x10, 0x08, 0xd2, 0x22, 0xf7, 0xa5,
+	0x55, 0xae, 0x2a, 0xbf, 0xad, 0x87, 0x80, 0xa4, 0xe2, 0x9d, 0xfe, 0x0d, 0xea, 0xb2, 0x00, 0x02,
+	0x41, 0x5a, 0x56, 0x8c, 0xf2, 0x2f, 0xbf, 0xd1, 0x87, 0x00, 0xc3, 0x06, 0x21, 0x63, 0xcb, 0x56,
+	0x4a, 0x65, 0x95, 0xb4, 0x65, 0xd1, 0x4d, 0xca, 0xaa, 0x1d, 0xe9, 0x6e, 0x52, 0xbe, 0x35, 0x3c,
+	0xaa, 0x6a, 0xc8, 0x32, 0x44, 0xf2, 0x3b, 0x03, 0x16, 0x22, 0xe0, 0x9a, 0xe7, 0x05, 0x48, 0xb7,
+	0xa8, 0x2b, 0xa2, 0x4b, 0x6d, 0x64, 0x2b, 0xa7, 0xcb, 0xc7, 0x3b, 0x5b, 0xf9, 0x06, 0x75, 0xab,
+	0x52, 0x05, 0x5d, 0x1b, 0x41, 0x6a, 0x3d, 0x91, 0x94, 0xc2, 0x09, 0xb3, 0xb2, 0xf2, 0xfa, 0x1c,
+	0x6e, 0x61, 0x1f, 0xb7, 0x83, 0x73, 0xb0, 0x6e, 0xc2, 0x42, 0x44, 0xaa, 0x09, 0x5e, 0x86, 0xe9,
+	0x8e, 0x94, 0xc8, 0x03, 0xca, 0x56, 0x0a, 0x71, 0x8a, 0xca, 0xe2, 0x6a, 0xfa, 0xd9, 0xef, 0x4b,
+	0x13, 0x55, 0xad, 0x6d, 0x6d, 0xc1, 0x19, 0x7d, 0xf7, 0x98, 0x37, 0x9d, 0x5d, 0xdc, 0x6a, 0x85,
+	0xef, 0xa6, 0x8e, 0x39, 0x0e, 0xee, 0x46, 0x7c, 0x5b, 0xef, 0xc1, 0xa9, 0x3d, 0xde, 0x50, 0x6a,
+	0x83, 0x7b, 0xc1, 0xbe, 0xcb, 0x02, 0x2d, 0xf1, 0x8d, 0xce, 0x40, 0xc6, 0xc5, 0xac, 0xe6, 0xe0,
+	0x8e, 0x2e, 0xa6, 0x69, 0x17, 0xb3, 0x5d, 0xdc, 0xb1, 0xd6, 0x61, 0x61, 0x8f, 0xf1, 0x66, 0x1b,
+	0x73, 0x72, 0x0d, 0x0f, 0xc9, 0xcf, 0x43, 0xca, 0xc5, 0xca, 0x45, 0xba, 0x2a, 0x3e, 0xad, 0x9f,
+	0x07, 0xf7, 0xe0, 0x63, 0x87, 0xdc, 0xe9, 0x07, 0x68, 0xdb, 0x90, 0x6a, 0x33, 0x57, 0xc7, 0xb8,
+	0x14, 0x8f, 0xf1, 0x26, 0x73, 0xf7, 0x84, 0x8c, 0x74, 0xdb, 0x77, 0xfa, 0x55, 0xa1, 0x8b, 0xce,
+	0xc2, 0x0c, 0xef, 0xd7, 0x9a, 0x5e, 0x9d, 0xf4, 0x35, 0x9b, 0x0c, 0xef, 0x5f, 0x17, 0x4b, 0xf4,
+	0x01, 0xe4, 0xb8, 0xf0, 0x5f, 0x73, 0xa8, 0x77, 0xaf, 0xe9, 0xca, 0xba, 0xce, 0x56, 0xce, 0xc5,
+	0xdd, 0x4a, 0x16, 0xbb, 0x52, 0xa9, 0x9a, 0xe5, 0xc3, 0x85, 0x75, 0x51, 0x97, 0xce, 0x80, 0xe6,
+	0x09, 0x67, 0x77, 0x06, 0x4e, 0x0f, 0x8e, 0x9a, 0x54, 0x29, 0x0d, 0x9a, 0x9e, 0xb5, 0x09, 0x6f,
+	0x1c, 0xdf, 0x18, 0xba, 0xf1, 0x29, 0xe5, 0x41, 0xd2, 0x8b, 0x6f, 0xeb, 0xae, 0xae, 0xa3, 0x5b,
+	0x3e, 0xa5, 0xf7, 0x92, 0x0b, 0xff, 0x3c, 0xe4, 0x98, 0xaa, 0xeb, 0xda, 0x7d, 0x72, 0xc8, 0x0a,
+	0x93, 0xcb, 0x29, 0xd1, 0x51, 0xb5, 0x6c, 0x9f, 0x1c, 0x86, 0x6b, 0xf4, 0xc7, 0x49, 0x40, 0x61,
+	0xe7, 0x9a, 0xc6, 0x39, 0x00, 0x26, 0xb8, 0xd5, 0x42, 0x64, 0x66, 0x59, 0xc0, 0x16, 0xad, 0x40,
+	0xd0, 0x0a, 0x6b, 0x1d, 0x61, 0xa7, 0x31, 0x72, 0x5a, 0x28, 0x7d, 0x85, 0x7b, 0x59, 0xea, 0x84,
+	0x81, 0x98, 0x1e, 0x37, 0x10, 0xa7, 0x42, 0x03, 0x31, 0x1c, 0x94, 0xb4, 0x9a, 0x56, 0x63, 0x42,
+	0xcb, 0xa4, 0xe1, 0x3e, 0x9c, 0x0a, 0x54, 0x24, 0x29, 0x56, 0xc8, 0xc8, 0xda, 0x2d, 0xc6, 0x6f,
+	0x57, 0xf7, 0x3d, 0xc9, 0x53, 0x97, 0xc7, 0x1c, 0x0b, 0xc9, 0x98, 0x75, 0x03, 0x72, 0x61, 0xa5,
+	0xa0, 0x9b, 0x1a, 0x83, 0x6e, 0x3a, 0xec, 0x95, 0x93, 0xa1, 0x5e, 0x29, 0xa4, 0xea, 0x44, 0x52,
+	0xf2, 0x44, 0xd4, 0xa2, 0xf2, 0x77, 0x0e, 0xa6, 0xe4, 0x29, 0xa3, 0x6f, 0x0c, 0xc8, 0xe8, 0x99,
+	0x85, 0xd6, 0xe2, 0xc4, 0x46, 0x3c, 0x4a, 0xcc, 0x52, 0x92, 0x9a, 0xba, 0x33, 0xeb, 0xd2, 0x57,
+	0xbf, 0xfe, 0xf9, 0xc3, 0xe4, 0x1a, 0x5a, 0xb1, 0x63, 0xef, 0x1e, 0x7d, 0x2f, 0xf6, 0x23, 0x9d,
+	0x23, 0x47, 0xe8, 0x27, 0x03, 0xe6, 0x22, 0x4f, 0x03, 0x74, 0x69, 0x0c, 0xcc, 0xa8, 0x27, 0x88,
+	0xb9, 0xf9, 0x6a, 0xca, 0x9a, 0x59, 0x45, 0x32, 0xdb, 0x44, 0x17, 0xe3, 0xcc, 0x82, 0x57, 0x48,
+	0x8c, 0xe0, 0x2f, 0x06, 0xcc, 0x1f, 0x9f, 0xf2, 0xa8, 0x3c, 0x06, 0x76, 0xcc, 0xe3, 0xc2, 0xb4,
+	0x5f, 0x59, 0x5f, 0x33, 0xbd, 0x22, 0x99, 0xbe, 0x83, 0x2a, 0x71, 0xa6, 0xbd, 0xc0, 0x66, 0x48,
+	0x36, 0xfc, 0x70, 0x39, 0x42, 0x8f, 0x0d, 0xc8, 0xe8, 0x79, 0x3e, 0xf6, 0x6a, 0xa3, 0x4f, 0x05,
+	0xb3, 0x94, 0xa4, 0xa6, 0x69, 0x6d, 0x4a, 0x5a, 0x25, 0xb4, 0x1a, 0xa7, 0xa5, 0x6b, 0x8a, 0x85,
+	0x8e, 0xee, 0x89, 0x01, 0x19, 0x9d, 0xbc, 0x63, 0x89, 0x44, 0x9f, 0x11, 0x66, 0x29, 0x49, 0x4d,
+	0x13, 0xd9, 0x96, 0x44, 0x2e, 0xa1, 0x0b, 0x71, 0x22, 0xba, 0x7e, 0x86, 0x3c, 0xec, 0x47, 0xf7,
+	0xc9, 0xe1, 0x11, 0x7a, 0x08, 0x69, 0xf1, 0x00, 0x40, 0xd6, 0xd8, 0x94, 0x19, 0xbc, 0x2a, 0xcc,
+	0x95, 0x13, 0x75, 0x34, 0x87, 0x0b, 0x92, 0xc3, 0x0a, 0x3a, 0x3f, 0x2a, 0x9b, 0xea, 0x91, 0x93,
+	0xf8, 0x1c, 0xa6, 0xd5, 0x0c, 0x44, 0xab, 0x63, 0x3c, 0x47, 0x46, 0xad, 0xb9, 0x96, 0xa0, 0xa5,
+	0x19, 0x2c, 0x4b, 0x06, 0x26, 0x2a, 0xc4, 0x19, 0xa8, 0x21, 0x8b, 0xfa, 0x90, 0xd1, 0x53, 0x13,
+	0x2d, 0xc7, 0x7d, 0x46, 0x07, 0xaa, 0xb9, 0x9e, 0x34, 0xd5, 0x02, 0x5c, 0x4b, 0xe2, 0x2e, 0x22,
+	0x33, 0x8e, 0x4b, 0x78, 0xa3, 0xe6, 0x08, 0xb8, 0x2f, 0x21, 0x1b, 0x1a, 0xb8, 0xaf, 0x80, 0x3e,
+	0x22, 0xe6, 0x11, 0x13, 0xdb, 0x2a, 0x49, 0xec, 0x65, 0x54, 0x1c, 0x81, 0xad, 0xd5, 0x6b, 0x2e,
+	0x66, 0xe8, 0x0b, 0xc8, 0xe8, 0xd1, 0x38, 0x36, 0xf7, 0xa2, 0x13, 0xde, 0x2c, 0x25, 0xa9, 0x25,
+	0x47, 0xaf, 0x66, 0x3a, 0xef, 0xa3, 0xaf, 0x0d, 0x98, 0x1d, 0x0c, 0x55, 0xb4, 0x3e, 0x36, 0xab,
+	0xa3, 0xf3, 0xd8, 0xdc, 0x48, 0x56, 0xd4, 0x24, 0x56, 0x25, 0x89, 0x22, 0x5a, 0x1c, 0x55, 0x00,
+	0xc1, 0xc0, 0x44, 0x47, 0x30, 0xa5, 0xc6, 0xc6, 0xb8, 0x84, 0x0e, 0x8f, 0x72, 0x73, 0xf5, 0x64,
+	0xa5, 0xe4, 0xb4, 0x97, 0x43, 0x66, 0x98, 0xf6, 0x57, 0x3f, 0x7b, 0xf6, 0xa2, 0x68, 0x3c, 0x7f,
+	0x51, 0x34, 0xfe, 0x78, 0x51, 0x34, 0xbe, 0x7f, 0x59, 0x9c, 0x78, 0xfe, 0xb2, 0x38, 0xf1, 0xdb,
+	0xcb, 0xe2, 0xc4, 0xdd, 0x1d, 0xb7, 0xc9, 0x1b, 0xdd, 0x83, 0xb2, 0x43, 0xdb, 0xf6, 0x5e, 0x8b,
+	0x38, 0xdc, 0xa7, 0x5e, 0xd3, 0xd9, 0xba, 0xdd, 0x74, 0x3d, 0xcc, 0xbb, 0x3e, 0x61, 0x5b, 0xd7,
+	0xbd, 0x7a, 0x97, 0x71, 0xbf, 0x49, 0x98, 0x8d, 0x3d, 0x87, 0x7a, 0x5b, 0x02, 0xa3, 0x2f, 0x91,
+	0xf8, 0x61, 0x87, 0xb0, 0x83, 0x69, 0xf9, 0x07, 0xfd, 0xf6, 0x3f, 0x03, 0x00, 0x37, 0x2b, 0x82,
+	0xc1, 0x0a, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1174,6 +1488,11 @@ type QueryClient interface {
 	EstimateGas(ctx context.Context, in *EthCallRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
 	// TraceTx implements the `debug_traceTransaction` rpc api
 	TraceTx(ctx context.Context, in *QueryTraceTxRequest, opts ...grpc.CallOption) (*QueryTraceTxResponse, error)
+	// StateRoot queries the root of the EVM world state trie.
+	StateRoot(ctx context.Context, in *QueryStateRootRequest, opts ...grpc.CallOption) (*QueryStateRootResponse, error)
+	// Proof queries the EVM world state trie proofs of an account and its
+	// storage.
+	Proof(ctx context.Context, in *QueryProofRequest, opts ...grpc.CallOption) (*QueryProofResponse, error)
 }
 
 type queryClient struct {
@@ -1274,6 +1593,24 @@ func (c *queryClient) TraceTx(ctx context.Context, in *QueryTraceTxRequest, opts
 	return out, nil
 }
 
+func (c *queryClient) StateRoot(ctx context.Context, in *QueryStateRootRequest, opts ...grpc.CallOption) (*QueryStateRootResponse, error) {
+	out := new(QueryStateRootResponse)
+	err := c.cc.Invoke(ctx, "/ethermint.evm.v1.Query/StateRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Proof(ctx context.Context, in *QueryProofRequest, opts ...grpc.CallOption) (*QueryProofResponse, error) {
+	out := new(QueryProofResponse)
+	err := c.cc.Invoke(ctx, "/ethermint.evm.v1.Query/Proof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// Account queries an Ethereum account.
@@ -1298,6 +1635,11 @@ type QueryServer interface {
 	EstimateGas(context.Context, *EthCallRequest) (*EstimateGasResponse, error)
 	// TraceTx implements the `debug_traceTransaction` rpc api
 	TraceTx(context.Context, *QueryTraceTxRequest) (*QueryTraceTxResponse, error)
+	// StateRoot queries the root of the EVM world state trie.
+	StateRoot(context.Context, *QueryStateRootRequest) (*QueryStateRootResponse, error)
+	// Proof queries the EVM world state trie proofs of an account and its
+	// storage.
+	Proof(context.Context, *QueryProofRequest) (*QueryProofResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -1334,6 +1676,12 @@ func (*UnimplementedQueryServer) EstimateGas(ctx context.Context, req *EthCallRe
 func (*UnimplementedQueryServer) TraceTx(ctx context.Context, req *QueryTraceTxRequest) (*QueryTraceTxResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TraceTx not implemented")
 }
+func (*UnimplementedQueryServer) StateRoot(ctx context.Context, req *QueryStateRootRequest) (*QueryStateRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StateRoot not implemented")
+}
+func (*UnimplementedQueryServer) Proof(ctx context.Context, req *QueryProofRequest) (*QueryProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Proof not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -1519,6 +1867,42 @@ func _Query_TraceTx_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_StateRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStateRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).StateRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethermint.evm.v1.Query/StateRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).StateRoot(ctx, req.(*QueryStateRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Proof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Proof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethermint.evm.v1.Query/Proof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Proof(ctx, req.(*QueryProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ethermint.evm.v1.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -1563,6 +1947,14 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TraceTx",
 			Handler:    _Query_TraceTx_Handler,
 		},
+		{
+			MethodName: "StateRoot",
+			Handler:    _Query_StateRoot_Handler,
+		},
+		{
+			MethodName: "Proof",
+			Handler:    _Query_Proof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ethermint/evm/v1/query.proto",
@@ -2289,39 +2681,256 @@ func (m *QueryTraceTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *QueryStateRootRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *QueryAccountRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *QueryStateRootRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStateRootRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *QueryAccountResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryStateRootResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Balance)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *QueryStateRootResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStateRootResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Root) > 0 {
+		i -= len(m.Root)
+		copy(dAtA[i:], m.Root)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Root)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryProofRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryProofRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryProofRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StorageKeys) > 0 {
+		for iNdEx := len(m.StorageKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.StorageKeys[iNdEx])
+			copy(dAtA[i:], m.StorageKeys[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.StorageKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryProofResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryProofResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StorageProofs) > 0 {
+		for iNdEx := len(m.StorageProofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StorageProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.StorageHash) > 0 {
+		i -= len(m.StorageHash)
+		copy(dAtA[i:], m.StorageHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.StorageHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.CodeHash) > 0 {
+		i -= len(m.CodeHash)
+		copy(dAtA[i:], m.CodeHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.CodeHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Balance) > 0 {
+		i -= len(m.Balance)
+		copy(dAtA[i:], m.Balance)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Balance)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AccountProof) > 0 {
+		for iNdEx := len(m.AccountProof) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AccountProof[iNdEx])
+			copy(dAtA[i:], m.AccountProof[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.AccountProof[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.StateRoot) > 0 {
+		i -= len(m.StateRoot)
+		copy(dAtA[i:], m.StateRoot)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.StateRoot)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proof) > 0 {
+		for iNdEx := len(m.Proof) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Proof[iNdEx])
+			copy(dAtA[i:], m.Proof[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.Proof[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryAccountRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryAccountResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Balance)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
 	}
 	l = len(m.CodeHash)
 	if l > 0 {
@@ -2609,54 +3218,158 @@ func (m *QueryTraceTxResponse) Size() (n int) {
 	return n
 }
 
-func sovQuery(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *QueryStateRootRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
 }
-func sozQuery(x uint64) (n int) {
-	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *QueryStateRootResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
 }
-func (m *QueryAccountRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowQuery
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: QueryAccountRequest: wiretype end group for non-group")
+
+func (m *QueryProofRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.StorageKeys) > 0 {
+		for _, s := range m.StorageKeys {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
 		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+	}
+	return n
+}
+
+func (m *QueryProofResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.StateRoot)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.AccountProof) > 0 {
+		for _, s := range m.AccountProof {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
 		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
+	}
+	l = len(m.Balance)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.CodeHash)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovQuery(uint64(m.Nonce))
+	}
+	l = len(m.StorageHash)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.StorageProofs) > 0 {
+		for _, e := range m.StorageProofs {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StorageProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.Proof) > 0 {
+		for _, s := range m.Proof {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryAccountRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryAccountRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryAccountRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
 				iNdEx++
 				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
@@ -4612,6 +5325,661 @@ func (m *QueryTraceTxResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryStateRootRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStateRootRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStateRootRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStateRootResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStateRootResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStateRootResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryProofRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryProofRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryProofRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageKeys = append(m.StorageKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryProofResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryProofResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateRoot", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateRoot = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountProof", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccountProof = append(m.AccountProof, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balance", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Balance = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageProofs = append(m.StorageProofs, StorageProof{})
+			if err := m.StorageProofs[len(m.StorageProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proof = append(m.Proof, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,29 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/types"
+)
+
+// NewDecodeStore returns a decoder function closure that unmarshals the KVPair's
+// Value to the corresponding feemarket type.
+func NewDecodeStore() func(kvA, kvB kv.Pair) string {
+	return func(kvA, kvB kv.Pair) string {
+		switch {
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixBlockGasUsed):
+			return fmt.Sprintf("%d\n%d", sdk.BigEndianToUint64(kvA.Value), sdk.BigEndianToUint64(kvB.Value))
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixBaseFee):
+			return fmt.Sprintf("%s\n%s", new(big.Int).SetBytes(kvA.Value), new(big.Int).SetBytes(kvB.Value))
+
+		default:
+			panic(fmt.Sprintf("invalid feemarket key prefix %X", kvA.Key[:1]))
+		}
+	}
+}
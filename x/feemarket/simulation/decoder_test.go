@@ -0,0 +1,50 @@
+package simulation_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/simulation"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/types"
+)
+
+func TestDecodeStore(t *testing.T) {
+	dec := simulation.NewDecodeStore()
+
+	blockGasUsed := uint64(21000)
+	baseFee := big.NewInt(875000000)
+
+	kvPairs := kv.Pairs{
+		Pairs: []kv.Pair{
+			{Key: types.KeyPrefixBlockGasUsed, Value: sdk.Uint64ToBigEndian(blockGasUsed)},
+			{Key: types.KeyPrefixBaseFee, Value: baseFee.Bytes()},
+			{Key: []byte{0x99}, Value: []byte{0x99}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		expectedLog string
+	}{
+		{"BlockGasUsed", fmt.Sprintf("%d\n%d", blockGasUsed, blockGasUsed)},
+		{"BaseFee", fmt.Sprintf("%s\n%s", baseFee, baseFee)},
+		{"other", ""},
+	}
+	for i, tt := range testCases {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			switch i {
+			case len(testCases) - 1:
+				require.Panics(t, func() { dec(kvPairs.Pairs[i], kvPairs.Pairs[i]) }, tt.name)
+			default:
+				require.Equal(t, tt.expectedLog, dec(kvPairs.Pairs[i], kvPairs.Pairs[i]), tt.name)
+			}
+		})
+	}
+}
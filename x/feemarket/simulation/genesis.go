@@ -0,0 +1,93 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/types"
+)
+
+// Simulation parameter constants
+const (
+	NoBaseFee                = "no_base_fee"
+	BaseFeeChangeDenominator = "base_fee_change_denominator"
+	ElasticityMultiplier     = "elasticity_multiplier"
+	InitialBaseFee           = "initial_base_fee"
+	EnableHeight             = "enable_height"
+)
+
+// GenNoBaseFee randomized NoBaseFee, the base fee is disabled 20% of the time
+func GenNoBaseFee(r *rand.Rand) bool {
+	return r.Intn(100) < 20
+}
+
+// GenBaseFeeChangeDenominator randomized BaseFeeChangeDenominator
+func GenBaseFeeChangeDenominator(r *rand.Rand) uint32 {
+	return uint32(r.Intn(16) + 1)
+}
+
+// GenElasticityMultiplier randomized ElasticityMultiplier
+func GenElasticityMultiplier(r *rand.Rand) uint32 {
+	return uint32(r.Intn(4) + 1)
+}
+
+// GenInitialBaseFee randomized InitialBaseFee. It is kept low, as the simulation accounts
+// balances are small compared to the ones of Ethereum accounts.
+func GenInitialBaseFee(r *rand.Rand) int64 {
+	return int64(r.Intn(10))
+}
+
+// GenEnableHeight randomized EnableHeight, within the first 100 blocks
+func GenEnableHeight(r *rand.Rand) int64 {
+	return int64(r.Intn(100))
+}
+
+// RandomizedGenState generates a random GenesisState for the feemarket module
+func RandomizedGenState(simState *module.SimulationState) {
+	var noBaseFee bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, NoBaseFee, &noBaseFee, simState.Rand,
+		func(r *rand.Rand) { noBaseFee = GenNoBaseFee(r) },
+	)
+
+	var baseFeeChangeDenom uint32
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, BaseFeeChangeDenominator, &baseFeeChangeDenom, simState.Rand,
+		func(r *rand.Rand) { baseFeeChangeDenom = GenBaseFeeChangeDenominator(r) },
+	)
+
+	var elasticityMultiplier uint32
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, ElasticityMultiplier, &elasticityMultiplier, simState.Rand,
+		func(r *rand.Rand) { elasticityMultiplier = GenElasticityMultiplier(r) },
+	)
+
+	var initialBaseFee int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, InitialBaseFee, &initialBaseFee, simState.Rand,
+		func(r *rand.Rand) { initialBaseFee = GenInitialBaseFee(r) },
+	)
+
+	var enableHeight int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, EnableHeight, &enableHeight, simState.Rand,
+		func(r *rand.Rand) { enableHeight = GenEnableHeight(r) },
+	)
+
+	params := types.NewParams(noBaseFee, baseFeeChangeDenom, elasticityMultiplier, initialBaseFee, enableHeight)
+	feemarketGenesis := types.NewGenesisState(params, sdk.ZeroInt(), 0)
+
+	bz, err := json.MarshalIndent(feemarketGenesis, "", " ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Selected randomly generated %s parameters:\n%s\n", types.ModuleName, bz)
+
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(feemarketGenesis)
+}
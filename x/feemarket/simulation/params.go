@@ -0,0 +1,35 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/feemarket/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simtypes.ParamChange {
+	return []simtypes.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyNoBaseFee),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenNoBaseFee(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyBaseFeeChangeDenominator),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", GenBaseFeeChangeDenominator(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyElasticityMultiplier),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", GenElasticityMultiplier(r))
+			},
+		),
+	}
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// Flags for the scheduler transaction commands
+const (
+	FlagGasLimit    = "gas-limit"
+	FlagGasPrice    = "gas-price"
+	FlagStartHeight = "start-height"
+	FlagInterval    = "interval"
+)
+
+// GetTxCmd returns the parent command for all x/scheduler CLI transaction commands.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Scheduler transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		NewCreateScheduleCmd(),
+		NewDepositScheduleCmd(),
+		NewCancelScheduleCmd(),
+	)
+	return cmd
+}
+
+// NewCreateScheduleCmd registers a scheduled contract call
+func NewCreateScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [contract] [calldata] [deposit]",
+		Short: "Schedule calls of a contract",
+		Long: `Schedule calls of a contract with the given hex encoded calldata. The calls are sent from the
+signer address, starting at the start height and then every interval blocks. A zero interval schedules a
+single call. The deposit is escrowed to pay the fees of the calls and the remainder is refunded when the
+schedule ends.`,
+		Example: fmt.Sprintf(
+			"$ %s tx %s create 0x5FbDB2315678afecb367f032d93F642f64180aa3 0xd09de08a 1000000000aphoton --gas-limit 50000 --gas-price 1 --start-height 100 --interval 10 --from mykey",
+			version.AppName, types.ModuleName,
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			if !common.IsHexAddress(args[0]) {
+				return fmt.Errorf("invalid contract address: %s", args[0])
+			}
+
+			data, err := hexutil.Decode(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid calldata: %w", err)
+			}
+
+			deposit, err := sdk.ParseCoinNormalized(args[2])
+			if err != nil {
+				return err
+			}
+
+			gasLimit, err := cmd.Flags().GetUint64(FlagGasLimit)
+			if err != nil {
+				return err
+			}
+
+			gasPriceStr, err := cmd.Flags().GetString(FlagGasPrice)
+			if err != nil {
+				return err
+			}
+
+			gasPrice, ok := sdk.NewIntFromString(gasPriceStr)
+			if !ok {
+				return fmt.Errorf("invalid gas price: %s", gasPriceStr)
+			}
+
+			startHeight, err := cmd.Flags().GetInt64(FlagStartHeight)
+			if err != nil {
+				return err
+			}
+
+			interval, err := cmd.Flags().GetUint64(FlagInterval)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateSchedule(
+				clientCtx.GetFromAddress(), common.HexToAddress(args[0]), data, gasLimit, gasPrice,
+				startHeight, interval, deposit,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Uint64(FlagGasLimit, 100000, "Gas limit of each call")
+	cmd.Flags().String(FlagGasPrice, "0", "Price paid for each unit of gas used by the calls, in the evm denom")
+	cmd.Flags().Int64(FlagStartHeight, 0, "Height of the first call")
+	cmd.Flags().Uint64(FlagInterval, 0, "Number of blocks between two calls, zero schedules a single call")
+	_ = cmd.MarkFlagRequired(FlagStartHeight)
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewDepositScheduleCmd adds funds to the escrow of a schedule
+func NewDepositScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit [id] [amount]",
+		Short: "Add funds to the escrow of a schedule",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid schedule id: %w", err)
+			}
+
+			amount, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDepositSchedule(clientCtx.GetFromAddress(), id, amount)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCancelScheduleCmd cancels a schedule and refunds its remaining escrow
+func NewCancelScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel [id]",
+		Short: "Cancel a schedule and refund its remaining escrow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid schedule id: %w", err)
+			}
+
+			msg := types.NewMsgCancelSchedule(clientCtx.GetFromAddress(), id)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
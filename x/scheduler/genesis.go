@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/keeper"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// InitGenesis initializes genesis state based on exported genesis
+func InitGenesis(
+	ctx sdk.Context,
+	k keeper.Keeper,
+	accountKeeper types.AccountKeeper,
+	data types.GenesisState,
+) []abci.ValidatorUpdate {
+	k.SetParams(ctx, data.Params)
+
+	// ensure the scheduler module account, which holds the escrows, is set
+	if acc := accountKeeper.GetModuleAccount(ctx, types.ModuleName); acc == nil {
+		panic("the scheduler module account has not been set")
+	}
+
+	for _, schedule := range data.Schedules {
+		k.SetSchedule(ctx, schedule)
+	}
+
+	k.SetNextScheduleID(ctx, data.NextScheduleID)
+
+	return []abci.ValidatorUpdate{}
+}
+
+// ExportGenesis exports genesis state of the scheduler module
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
+	schedules := make([]types.Schedule, 0)
+	k.IterateSchedules(ctx, func(schedule types.Schedule) bool {
+		schedules = append(schedules, schedule)
+		return false
+	})
+
+	return &types.GenesisState{
+		Params:         k.GetParams(ctx),
+		Schedules:      schedules,
+		NextScheduleID: k.GetNextScheduleID(ctx),
+	}
+}
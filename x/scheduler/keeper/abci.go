@@ -0,0 +1,199 @@
+package keeper
+
+import (
+	"math/big"
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethparams "github.com/ethereum/go-ethereum/params"
+
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// EndBlock executes the scheduled calls that are due at the current height, ordered by the height of
+// their next call and then by identifier. A call is executed only if its gas limit fits in what's left
+// of the block gas budget, otherwise it's delayed to the next blocks. The schedules whose gas limit
+// exceeds the whole budget, which governance may lower after their creation, could never be executed
+// and are ended like the schedules whose escrow doesn't cover the fee of a call: their remaining
+// escrow is refunded.
+//
+// As every call uses at least the intrinsic gas of a transaction, at most BlockGasBudget / TxGas due
+// schedules are read on a block, and the execution stops once what's left of the budget can't fit
+// any call.
+//
+// The logs of the calls are emitted as EndBlock events only: they're not part of a transaction result,
+// so eth_getLogs and the transaction receipts don't return them. They can be read from the EndBlock
+// events of the block results, where their transaction hash is the one of the scheduled_call event.
+// Their log indices follow the ones of the block transactions, but all the calls share the transaction
+// index following the last ethereum transaction of the block.
+func (k Keeper) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) {
+	// the gas of the calls is bounded by the block gas budget instead of the gas meter
+	infCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	blockGasBudget := k.GetParams(infCtx).BlockGasBudget
+	budget := blockGasBudget
+
+	maxSchedules := int(budget / ethparams.TxGas)
+
+	for _, id := range k.GetDueScheduleIDs(infCtx, ctx.BlockHeight(), maxSchedules) {
+		if budget < ethparams.TxGas {
+			break
+		}
+
+		schedule, found := k.GetSchedule(infCtx, id)
+		if !found {
+			continue
+		}
+
+		if schedule.GasLimit > blockGasBudget {
+			k.endSchedule(infCtx, schedule, types.AttributeValueGasLimitExceeded)
+			continue
+		}
+
+		if schedule.GasLimit > budget {
+			continue
+		}
+
+		if schedule.Escrow.LT(schedule.MaxFee()) {
+			k.endSchedule(infCtx, schedule, types.AttributeValueInsufficientFunds)
+			continue
+		}
+
+		// the call is discarded if its fee can't be charged
+		callCtx, writeCall := infCtx.CacheContext()
+		callCtx = callCtx.WithEventManager(sdk.NewEventManager())
+
+		gasUsed := k.executeCall(callCtx, schedule)
+		budget -= gasUsed
+
+		fee := schedule.Fee(gasUsed)
+		if err := k.chargeFee(callCtx, fee); err != nil {
+			k.Logger(ctx).Error("failed to charge scheduled call fee", "schedule", id, "error", err.Error())
+			k.endSchedule(infCtx, schedule, types.AttributeValueInsufficientFunds)
+			continue
+		}
+
+		writeCall()
+		infCtx.EventManager().EmitEvents(callCtx.EventManager().Events())
+
+		schedule.Escrow = schedule.Escrow.Sub(fee)
+		schedule.Calls++
+
+		if schedule.Interval == 0 {
+			k.endSchedule(infCtx, schedule, types.AttributeValueCompleted)
+			continue
+		}
+
+		schedule.NextHeight = ctx.BlockHeight() + int64(schedule.Interval)
+		k.SetSchedule(infCtx, schedule)
+	}
+
+	k.evmKeeper.WithContext(ctx)
+}
+
+// executeCall runs the call of the schedule through the EVM keeper on a branch of the context. The
+// branch is written if the EVM executed the message, even if the execution reverted, and discarded
+// otherwise. It returns the gas used by the call, which is the whole gas limit when the message
+// couldn't be executed.
+func (k Keeper) executeCall(ctx sdk.Context, schedule types.Schedule) uint64 {
+	height := ctx.BlockHeight()
+	txHash := types.CallHash(schedule.ID, height)
+	sender := common.BytesToAddress(schedule.OwnerAddress())
+	contract := common.HexToAddress(schedule.Contract)
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+
+	k.evmKeeper.WithContext(cacheCtx)
+	// the hash identifies the call on the logs emitted by the EVM
+	k.evmKeeper.SetTxHashTransient(txHash)
+
+	msg := ethtypes.NewMessage(
+		sender, &contract, 0, big.NewInt(0), schedule.GasLimit, schedule.GasPrice.BigInt(), schedule.Data, nil, false,
+	)
+
+	attrs := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyOwner, schedule.Owner),
+		sdk.NewAttribute(types.AttributeKeyContract, schedule.Contract),
+		sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatInt(height, 10)),
+		sdk.NewAttribute(types.AttributeKeyEthereumTxHash, txHash.Hex()),
+	}
+
+	res, err := k.evmKeeper.ApplyNativeMessage(msg)
+	if err != nil {
+		k.Logger(ctx).Error("failed to execute scheduled call", "schedule", schedule.ID, "error", err.Error())
+
+		attrs = append(attrs,
+			sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(schedule.GasLimit, 10)),
+			sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+		)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeScheduledCall, attrs...))
+		return schedule.GasLimit
+	}
+
+	attrs = append(attrs,
+		sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(res.GasUsed, 10)),
+		sdk.NewAttribute(types.AttributeKeyReturnData, hexutil.Encode(res.Ret)),
+	)
+	if res.Failed() {
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyEthereumTxFailed, res.VmError))
+	}
+
+	events := sdk.Events{sdk.NewEvent(types.EventTypeScheduledCall, attrs...)}
+
+	if len(res.Logs) > 0 {
+		// include the logs in the block bloom emitted by the EVM module
+		bloom := k.evmKeeper.GetBlockBloomTransient()
+		bloom.Or(bloom, new(big.Int).SetBytes(ethtypes.LogsBloom(evmtypes.LogsToEthereum(res.Logs))))
+		k.evmKeeper.SetBlockBloomTransient(bloom)
+
+		txLogAttrs := make([]sdk.Attribute, len(res.Logs))
+		for i, log := range res.Logs {
+			txLogAttrs[i] = sdk.NewAttribute(evmtypes.AttributeKeyTxLog, string(k.cdc.MustMarshal(log)))
+		}
+		events = append(events, sdk.NewEvent(evmtypes.EventTypeTxLog, txLogAttrs...))
+	}
+
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	ctx.EventManager().EmitEvents(events)
+
+	return res.GasUsed
+}
+
+// chargeFee transfers the fee of a call from the escrow to the fee collector.
+func (k Keeper) chargeFee(ctx sdk.Context, fee sdk.Int) error {
+	if !fee.IsPositive() {
+		return nil
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(k.evmKeeper.GetParams(ctx).EvmDenom, fee))
+	return k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, coins)
+}
+
+// endSchedule removes the schedule and refunds its remaining escrow to the owner. The schedule is
+// removed even if the escrow can't be refunded, so that it isn't executed again.
+func (k Keeper) endSchedule(ctx sdk.Context, schedule types.Schedule, reason string) {
+	if err := k.removeSchedule(ctx, schedule); err != nil {
+		k.Logger(ctx).Error("failed to refund schedule escrow", "schedule", schedule.ID, "error", err.Error())
+
+		k.DeleteSchedule(ctx, schedule)
+		schedule.Escrow = sdk.ZeroInt()
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeScheduleEnded,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyReason, reason),
+			sdk.NewAttribute(types.AttributeKeyRefund, schedule.Escrow.String()),
+		),
+	)
+}
@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// Keeper grants access to the scheduler module state and executes the scheduled calls.
+type Keeper struct {
+	// Protobuf codec
+	cdc codec.BinaryCodec
+	// Store key required for the scheduler Prefix KVStore.
+	storeKey sdk.StoreKey
+	// module specific parameter space that can be configured through governance
+	paramSpace paramtypes.Subspace
+
+	accountKeeper types.AccountKeeper
+	bankKeeper    types.BankKeeper
+	// EVM keeper used to execute the scheduled calls
+	evmKeeper types.EVMKeeper
+}
+
+// NewKeeper generates new scheduler module keeper
+func NewKeeper(
+	cdc codec.BinaryCodec, storeKey sdk.StoreKey, paramSpace paramtypes.Subspace,
+	ak types.AccountKeeper, bankKeeper types.BankKeeper, evmKeeper types.EVMKeeper,
+) Keeper {
+	// ensure scheduler module account is set
+	if addr := ak.GetModuleAddress(types.ModuleName); addr == nil {
+		panic("the scheduler module account has not been set")
+	}
+
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		cdc:           cdc,
+		storeKey:      storeKey,
+		paramSpace:    paramSpace,
+		accountKeeper: ak,
+		bankKeeper:    bankKeeper,
+		evmKeeper:     evmKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// ----------------------------------------------------------------------------
+// Schedules
+// ----------------------------------------------------------------------------
+
+// GetNextScheduleID returns the identifier assigned to the next registered schedule.
+func (k Keeper) GetNextScheduleID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.KeyNextScheduleID)
+	if len(bz) == 0 {
+		return 1
+	}
+
+	return sdk.BigEndianToUint64(bz)
+}
+
+// SetNextScheduleID sets the identifier assigned to the next registered schedule.
+func (k Keeper) SetNextScheduleID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Set(types.KeyNextScheduleID, sdk.Uint64ToBigEndian(id))
+}
+
+// GetSchedule returns the schedule with the given identifier.
+func (k Keeper) GetSchedule(ctx sdk.Context, id uint64) (types.Schedule, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSchedule)
+	bz := store.Get(types.ScheduleKey(id))
+	if len(bz) == 0 {
+		return types.Schedule{}, false
+	}
+
+	var schedule types.Schedule
+	k.cdc.MustUnmarshal(bz, &schedule)
+	return schedule, true
+}
+
+// SetSchedule stores the schedule and queues it at the height of its next call. The queue entry of
+// the previous version of the schedule, if any, is removed.
+func (k Keeper) SetSchedule(ctx sdk.Context, schedule types.Schedule) {
+	if prev, found := k.GetSchedule(ctx, schedule.ID); found {
+		k.dequeue(ctx, prev)
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSchedule)
+	store.Set(types.ScheduleKey(schedule.ID), k.cdc.MustMarshal(&schedule))
+
+	queue := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixQueue)
+	queue.Set(types.QueueKey(schedule.NextHeight, schedule.ID), sdk.Uint64ToBigEndian(schedule.ID))
+}
+
+// DeleteSchedule removes the schedule and its queue entry.
+func (k Keeper) DeleteSchedule(ctx sdk.Context, schedule types.Schedule) {
+	k.dequeue(ctx, schedule)
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSchedule)
+	store.Delete(types.ScheduleKey(schedule.ID))
+}
+
+func (k Keeper) dequeue(ctx sdk.Context, schedule types.Schedule) {
+	queue := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixQueue)
+	queue.Delete(types.QueueKey(schedule.NextHeight, schedule.ID))
+}
+
+// IterateSchedules iterates over the schedules in identifier order until the callback returns true.
+func (k Keeper) IterateSchedules(ctx sdk.Context, cb func(schedule types.Schedule) (stop bool)) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSchedule)
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var schedule types.Schedule
+		k.cdc.MustUnmarshal(iterator.Value(), &schedule)
+
+		if cb(schedule) {
+			break
+		}
+	}
+}
+
+// GetDueScheduleIDs returns the identifiers of the schedules whose next call is at or before the
+// given height, ordered by height of the next call and then by identifier. If the limit is positive,
+// at most limit identifiers are returned.
+func (k Keeper) GetDueScheduleIDs(ctx sdk.Context, height int64, limit int) []uint64 {
+	queue := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixQueue)
+	iterator := queue.Iterator(nil, types.QueueHeightPrefixEnd(height))
+	defer iterator.Close()
+
+	var ids []uint64
+	for ; iterator.Valid() && (limit <= 0 || len(ids) < limit); iterator.Next() {
+		ids = append(ids, sdk.BigEndianToUint64(iterator.Value()))
+	}
+
+	return ids
+}
@@ -0,0 +1,513 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/app"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+var (
+	// counterCode increments the storage slot 0 and logs its new value
+	counterCode = hexutil.MustDecode("0x6000546001018060005560005260206000a000")
+	// revertCode reverts every call
+	revertCode = hexutil.MustDecode("0x60006000fd")
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	app         *app.EthermintApp
+	ctx         sdk.Context
+	queryClient types.QueryClient
+
+	owner    sdk.AccAddress
+	counter  common.Address
+	reverter common.Address
+	denom    string
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	suite.owner = sdk.AccAddress(priv.PubKey().Address())
+
+	consPriv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	consAddress := sdk.ConsAddress(consPriv.PubKey().Address())
+
+	suite.app = app.Setup(false)
+	suite.ctx = suite.app.BaseApp.NewContext(false, tmproto.Header{
+		Height:          1,
+		ChainID:         "ethermint_9000-1",
+		Time:            time.Now().UTC(),
+		ProposerAddress: consAddress.Bytes(),
+	})
+	suite.app.EvmKeeper.WithContext(suite.ctx)
+
+	validator, err := stakingtypes.NewValidator(sdk.ValAddress(suite.owner), consPriv.PubKey(), stakingtypes.Description{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.app.StakingKeeper.SetValidatorByConsAddr(suite.ctx, validator))
+	suite.app.StakingKeeper.SetValidator(suite.ctx, validator)
+
+	queryHelper := baseapp.NewQueryServerTestHelper(suite.ctx, suite.app.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, suite.app.SchedulerKeeper)
+	suite.queryClient = types.NewQueryClient(queryHelper)
+
+	suite.denom = suite.app.EvmKeeper.GetParams(suite.ctx).EvmDenom
+
+	coins := sdk.NewCoins(sdk.NewCoin(suite.denom, sdk.NewInt(1_000_000_000)))
+	suite.Require().NoError(suite.app.BankKeeper.MintCoins(suite.ctx, evmtypes.ModuleName, coins))
+	suite.Require().NoError(suite.app.BankKeeper.SendCoinsFromModuleToAccount(suite.ctx, evmtypes.ModuleName, suite.owner, coins))
+
+	suite.counter = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	suite.reverter = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	suite.app.EvmKeeper.SetCode(suite.counter, counterCode)
+	suite.app.EvmKeeper.SetCode(suite.reverter, revertCode)
+}
+
+func (suite *KeeperTestSuite) createSchedule(contract common.Address, gasLimit uint64, gasPrice int64, startHeight int64, interval uint64, deposit int64) uint64 {
+	msg := types.NewMsgCreateSchedule(
+		suite.owner, contract, nil, gasLimit, sdk.NewInt(gasPrice), startHeight, interval,
+		sdk.NewCoin(suite.denom, sdk.NewInt(deposit)),
+	)
+	res, err := suite.app.SchedulerKeeper.CreateSchedule(sdk.WrapSDKContext(suite.ctx), msg)
+	suite.Require().NoError(err)
+	return res.ID
+}
+
+// endBlock runs the scheduler end block at the given height on a fresh event manager.
+func (suite *KeeperTestSuite) endBlock(height int64) sdk.Events {
+	suite.ctx = suite.ctx.WithBlockHeight(height).WithEventManager(sdk.NewEventManager())
+	suite.app.SchedulerKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: height})
+	return suite.ctx.EventManager().Events()
+}
+
+func (suite *KeeperTestSuite) counterValue() int64 {
+	suite.app.EvmKeeper.WithContext(suite.ctx)
+	return suite.app.EvmKeeper.GetState(suite.counter, common.Hash{}).Big().Int64()
+}
+
+func (suite *KeeperTestSuite) balance(addr sdk.AccAddress) sdk.Int {
+	return suite.app.BankKeeper.GetBalance(suite.ctx, addr, suite.denom).Amount
+}
+
+func filterEvents(events sdk.Events, eventType string) sdk.Events {
+	var filtered sdk.Events
+	for _, event := range events {
+		if event.Type == eventType {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func eventAttribute(event sdk.Event, key string) (string, bool) {
+	for _, attr := range event.Attributes {
+		if string(attr.Key) == key {
+			return string(attr.Value), true
+		}
+	}
+	return "", false
+}
+
+func (suite *KeeperTestSuite) TestCreateSchedule() {
+	testCases := []struct {
+		msg      string
+		malleate func(msg *types.MsgCreateSchedule)
+		expPass  bool
+	}{
+		{
+			"pass",
+			func(*types.MsgCreateSchedule) {},
+			true,
+		},
+		{
+			"gas limit exceeds the max call gas",
+			func(msg *types.MsgCreateSchedule) {
+				msg.GasLimit = types.DefaultMaxCallGas + 1
+			},
+			false,
+		},
+		{
+			"gas price lower than the min gas price",
+			func(msg *types.MsgCreateSchedule) {
+				params := types.DefaultParams()
+				params.MinGasPrice = sdk.NewInt(2)
+				suite.app.SchedulerKeeper.SetParams(suite.ctx, params)
+			},
+			false,
+		},
+		{
+			"start height before the current height",
+			func(msg *types.MsgCreateSchedule) {
+				suite.ctx = suite.ctx.WithBlockHeight(10)
+			},
+			false,
+		},
+		{
+			"deposit not in the evm denom",
+			func(msg *types.MsgCreateSchedule) {
+				msg.Deposit = sdk.NewCoin("stake", msg.Deposit.Amount)
+			},
+			false,
+		},
+		{
+			"deposit doesn't cover the fee of a call",
+			func(msg *types.MsgCreateSchedule) {
+				msg.Deposit = sdk.NewCoin(suite.denom, sdk.NewInt(49_999))
+			},
+			false,
+		},
+		{
+			"insufficient balance",
+			func(msg *types.MsgCreateSchedule) {
+				msg.Deposit = sdk.NewCoin(suite.denom, sdk.NewInt(2_000_000_000))
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+
+			msg := types.NewMsgCreateSchedule(
+				suite.owner, suite.counter, nil, 50_000, sdk.NewInt(1), 5, 10,
+				sdk.NewCoin(suite.denom, sdk.NewInt(1_000_000)),
+			)
+			tc.malleate(msg)
+			suite.Require().NoError(msg.ValidateBasic())
+
+			res, err := suite.app.SchedulerKeeper.CreateSchedule(sdk.WrapSDKContext(suite.ctx), msg)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(uint64(1), res.ID)
+			suite.Require().Equal(uint64(2), suite.app.SchedulerKeeper.GetNextScheduleID(suite.ctx))
+
+			schedule, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, res.ID)
+			suite.Require().True(found)
+			suite.Require().Equal(int64(5), schedule.NextHeight)
+			suite.Require().Equal(sdk.NewInt(1_000_000), schedule.Escrow)
+
+			moduleAddr := suite.app.AccountKeeper.GetModuleAddress(types.ModuleName)
+			suite.Require().Equal(sdk.NewInt(1_000_000), suite.balance(moduleAddr))
+			suite.Require().Equal([]uint64{res.ID}, suite.app.SchedulerKeeper.GetDueScheduleIDs(suite.ctx, 5, 0))
+			suite.Require().Empty(suite.app.SchedulerKeeper.GetDueScheduleIDs(suite.ctx, 4, 0))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestDepositAndCancelSchedule() {
+	suite.SetupTest()
+	goCtx := sdk.WrapSDKContext(suite.ctx)
+
+	id := suite.createSchedule(suite.counter, 50_000, 1, 5, 10, 100_000)
+	initialBalance := suite.balance(suite.owner)
+
+	_, err := suite.app.SchedulerKeeper.DepositSchedule(goCtx, types.NewMsgDepositSchedule(suite.owner, id+1, sdk.NewCoin(suite.denom, sdk.NewInt(1))))
+	suite.Require().ErrorIs(err, types.ErrScheduleNotFound)
+
+	_, err = suite.app.SchedulerKeeper.DepositSchedule(goCtx, types.NewMsgDepositSchedule(suite.owner, id, sdk.NewCoin(suite.denom, sdk.NewInt(50_000))))
+	suite.Require().NoError(err)
+
+	schedule, _ := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().Equal(sdk.NewInt(150_000), schedule.Escrow)
+
+	other := sdk.AccAddress(common.BigToAddress(common.Big3).Bytes())
+	_, err = suite.app.SchedulerKeeper.CancelSchedule(goCtx, types.NewMsgCancelSchedule(other, id))
+	suite.Require().ErrorIs(err, types.ErrUnauthorized)
+
+	_, err = suite.app.SchedulerKeeper.CancelSchedule(goCtx, types.NewMsgCancelSchedule(suite.owner, id))
+	suite.Require().NoError(err)
+
+	_, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().False(found)
+	suite.Require().Empty(suite.app.SchedulerKeeper.GetDueScheduleIDs(suite.ctx, 5, 0))
+	suite.Require().Equal(initialBalance.AddRaw(100_000), suite.balance(suite.owner))
+}
+
+func (suite *KeeperTestSuite) TestEndBlockRecurringSchedule() {
+	suite.SetupTest()
+
+	feeCollector := suite.app.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+	id := suite.createSchedule(suite.counter, 100_000, 2, 2, 3, 1_000_000)
+
+	events := suite.endBlock(1)
+	suite.Require().Empty(filterEvents(events, types.EventTypeScheduledCall))
+	suite.Require().Equal(int64(0), suite.counterValue())
+
+	events = suite.endBlock(2)
+	calls := filterEvents(events, types.EventTypeScheduledCall)
+	suite.Require().Len(calls, 1)
+	suite.Require().Equal(int64(1), suite.counterValue())
+
+	_, failed := eventAttribute(calls[0], types.AttributeKeyEthereumTxFailed)
+	suite.Require().False(failed)
+
+	txHash, _ := eventAttribute(calls[0], types.AttributeKeyEthereumTxHash)
+	suite.Require().Equal(types.CallHash(id, 2).Hex(), txHash)
+
+	txLogs := filterEvents(events, evmtypes.EventTypeTxLog)
+	suite.Require().Len(txLogs, 1)
+	suite.Require().Len(txLogs[0].Attributes, 1)
+
+	var log evmtypes.Log
+	suite.Require().NoError(suite.app.AppCodec().Unmarshal(txLogs[0].Attributes[0].Value, &log))
+	suite.Require().Equal(suite.counter.Hex(), log.Address)
+	suite.Require().Equal(txHash, log.TxHash)
+	suite.Require().NotZero(suite.app.EvmKeeper.GetBlockBloomTransient().Sign())
+
+	schedule, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().True(found)
+	suite.Require().Equal(uint64(1), schedule.Calls)
+	suite.Require().Equal(int64(5), schedule.NextHeight)
+
+	gasUsed, _ := eventAttribute(calls[0], types.AttributeKeyGasUsed)
+	fee := schedule.Fee(mustParseUint(suite, gasUsed))
+	suite.Require().True(fee.IsPositive())
+	suite.Require().Equal(sdk.NewInt(1_000_000).Sub(fee), schedule.Escrow)
+	suite.Require().Equal(fee, suite.balance(feeCollector))
+
+	for height := int64(3); height <= 8; height++ {
+		suite.endBlock(height)
+	}
+
+	suite.Require().Equal(int64(3), suite.counterValue())
+	schedule, _ = suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().Equal(uint64(3), schedule.Calls)
+	suite.Require().Equal(int64(11), schedule.NextHeight)
+}
+
+func (suite *KeeperTestSuite) TestEndBlockSingleCall() {
+	suite.SetupTest()
+
+	id := suite.createSchedule(suite.counter, 100_000, 1, 2, 0, 1_000_000)
+	initialBalance := suite.balance(suite.owner)
+
+	events := suite.endBlock(2)
+	suite.Require().Equal(int64(1), suite.counterValue())
+
+	ended := filterEvents(events, types.EventTypeScheduleEnded)
+	suite.Require().Len(ended, 1)
+	reason, _ := eventAttribute(ended[0], types.AttributeKeyReason)
+	suite.Require().Equal(types.AttributeValueCompleted, reason)
+
+	_, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().False(found)
+
+	// the escrow left after the fee is refunded
+	gasUsed, _ := eventAttribute(filterEvents(events, types.EventTypeScheduledCall)[0], types.AttributeKeyGasUsed)
+	suite.Require().Equal(initialBalance.AddRaw(1_000_000).SubRaw(int64(mustParseUint(suite, gasUsed))), suite.balance(suite.owner))
+
+	suite.endBlock(3)
+	suite.Require().Equal(int64(1), suite.counterValue())
+}
+
+func (suite *KeeperTestSuite) TestEndBlockRevertedCall() {
+	suite.SetupTest()
+
+	id := suite.createSchedule(suite.reverter, 100_000, 1, 2, 1, 1_000_000)
+
+	events := suite.endBlock(2)
+	calls := filterEvents(events, types.EventTypeScheduledCall)
+	suite.Require().Len(calls, 1)
+
+	vmErr, failed := eventAttribute(calls[0], types.AttributeKeyEthereumTxFailed)
+	suite.Require().True(failed)
+	suite.Require().Contains(vmErr, "revert")
+	suite.Require().Empty(filterEvents(events, evmtypes.EventTypeTxLog))
+
+	// the reverted call is charged and the schedule goes on
+	schedule, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().True(found)
+	suite.Require().Equal(uint64(1), schedule.Calls)
+	suite.Require().True(schedule.Escrow.LT(sdk.NewInt(1_000_000)))
+}
+
+func (suite *KeeperTestSuite) TestEndBlockInsufficientEscrow() {
+	suite.SetupTest()
+
+	// the deposit covers a single call that uses the whole gas limit
+	id := suite.createSchedule(suite.counter, 100_000, 1, 2, 1, 100_000)
+	initialBalance := suite.balance(suite.owner)
+
+	suite.endBlock(2)
+	suite.Require().Equal(int64(1), suite.counterValue())
+
+	schedule, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().True(found)
+	escrow := schedule.Escrow
+
+	events := suite.endBlock(3)
+	suite.Require().Empty(filterEvents(events, types.EventTypeScheduledCall))
+	suite.Require().Equal(int64(1), suite.counterValue())
+
+	ended := filterEvents(events, types.EventTypeScheduleEnded)
+	suite.Require().Len(ended, 1)
+	reason, _ := eventAttribute(ended[0], types.AttributeKeyReason)
+	suite.Require().Equal(types.AttributeValueInsufficientFunds, reason)
+
+	_, found = suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().False(found)
+	suite.Require().Equal(initialBalance.Add(escrow), suite.balance(suite.owner))
+}
+
+func (suite *KeeperTestSuite) TestEndBlockGasBudget() {
+	suite.SetupTest()
+
+	params := types.DefaultParams()
+	params.BlockGasBudget = 120_000
+	params.MaxCallGas = 100_000
+	suite.app.SchedulerKeeper.SetParams(suite.ctx, params)
+
+	first := suite.createSchedule(suite.counter, 100_000, 1, 2, 0, 1_000_000)
+	second := suite.createSchedule(suite.counter, 100_000, 1, 2, 0, 1_000_000)
+
+	// the gas limit of the second call doesn't fit in the budget left by the gas used by the first one
+	events := suite.endBlock(2)
+	suite.Require().Len(filterEvents(events, types.EventTypeScheduledCall), 1)
+	suite.Require().Equal(int64(1), suite.counterValue())
+
+	_, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, first)
+	suite.Require().False(found)
+	suite.Require().Equal([]uint64{second}, suite.app.SchedulerKeeper.GetDueScheduleIDs(suite.ctx, 3, 0))
+
+	// the delayed call is executed on the next block
+	events = suite.endBlock(3)
+	calls := filterEvents(events, types.EventTypeScheduledCall)
+	suite.Require().Len(calls, 1)
+	scheduleID, _ := eventAttribute(calls[0], types.AttributeKeyScheduleID)
+	suite.Require().Equal("2", scheduleID)
+	suite.Require().Equal(int64(2), suite.counterValue())
+}
+
+func (suite *KeeperTestSuite) TestEndBlockGasLimitExceedsBudget() {
+	suite.SetupTest()
+
+	id := suite.createSchedule(suite.counter, 100_000, 1, 2, 0, 1_000_000)
+	suite.createSchedule(suite.counter, 50_000, 1, 2, 0, 1_000_000)
+	initialBalance := suite.balance(suite.owner)
+
+	// the budget is lowered by governance below the gas limit of the first schedule
+	params := types.DefaultParams()
+	params.BlockGasBudget = 50_000
+	params.MaxCallGas = 50_000
+	suite.app.SchedulerKeeper.SetParams(suite.ctx, params)
+
+	events := suite.endBlock(2)
+
+	// the schedule that can't be executed anymore is ended and refunded
+	ended := filterEvents(events, types.EventTypeScheduleEnded)
+	suite.Require().Len(ended, 2)
+	scheduleID, _ := eventAttribute(ended[0], types.AttributeKeyScheduleID)
+	suite.Require().Equal("1", scheduleID)
+	reason, _ := eventAttribute(ended[0], types.AttributeKeyReason)
+	suite.Require().Equal(types.AttributeValueGasLimitExceeded, reason)
+
+	_, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().False(found)
+	refund, _ := eventAttribute(ended[0], types.AttributeKeyRefund)
+	suite.Require().Equal("1000000", refund)
+	suite.Require().True(suite.balance(suite.owner).GT(initialBalance.Add(sdk.NewInt(1_000_000))))
+
+	// the other schedule is executed on the same block
+	calls := filterEvents(events, types.EventTypeScheduledCall)
+	suite.Require().Len(calls, 1)
+	scheduleID, _ = eventAttribute(calls[0], types.AttributeKeyScheduleID)
+	suite.Require().Equal("2", scheduleID)
+	suite.Require().Equal(int64(1), suite.counterValue())
+}
+
+func (suite *KeeperTestSuite) TestEndBlockMaxSchedules() {
+	suite.SetupTest()
+
+	params := types.DefaultParams()
+	params.BlockGasBudget = 100_000
+	params.MaxCallGas = 100_000
+	suite.app.SchedulerKeeper.SetParams(suite.ctx, params)
+
+	// the calls with the intrinsic gas only run out of gas, so at most 4 of them fit in the budget
+	ids := make([]uint64, 5)
+	for i := range ids {
+		ids[i] = suite.createSchedule(suite.counter, ethparams.TxGas, 1, 2, 0, 1_000_000)
+	}
+
+	events := suite.endBlock(2)
+	suite.Require().Len(filterEvents(events, types.EventTypeScheduledCall), 4)
+	suite.Require().Equal([]uint64{ids[4]}, suite.app.SchedulerKeeper.GetDueScheduleIDs(suite.ctx, 3, 0))
+}
+
+func (suite *KeeperTestSuite) TestEndBlockChargeFeeFailure() {
+	suite.SetupTest()
+
+	id := suite.createSchedule(suite.counter, 100_000, 1, 2, 1, 1_000_000)
+
+	// the module account doesn't hold the escrow anymore
+	moduleAddr := suite.app.AccountKeeper.GetModuleAddress(types.ModuleName)
+	coins := suite.app.BankKeeper.GetAllBalances(suite.ctx, moduleAddr)
+	err := suite.app.BankKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, suite.owner, coins)
+	suite.Require().NoError(err)
+
+	// the call is discarded and the schedule is ended
+	events := suite.endBlock(2)
+	suite.Require().Empty(filterEvents(events, types.EventTypeScheduledCall))
+	suite.Require().Equal(int64(0), suite.counterValue())
+
+	ended := filterEvents(events, types.EventTypeScheduleEnded)
+	suite.Require().Len(ended, 1)
+	reason, _ := eventAttribute(ended[0], types.AttributeKeyReason)
+	suite.Require().Equal(types.AttributeValueInsufficientFunds, reason)
+
+	_, found := suite.app.SchedulerKeeper.GetSchedule(suite.ctx, id)
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestQuerySchedules() {
+	suite.SetupTest()
+	goCtx := sdk.WrapSDKContext(suite.ctx)
+
+	suite.createSchedule(suite.counter, 50_000, 1, 2, 0, 100_000)
+	id := suite.createSchedule(suite.reverter, 50_000, 1, 3, 0, 100_000)
+
+	res, err := suite.queryClient.Schedule(goCtx, &types.QueryScheduleRequest{Id: id})
+	suite.Require().NoError(err)
+	suite.Require().Equal(suite.reverter.Hex(), res.Schedule.Contract)
+
+	_, err = suite.queryClient.Schedule(goCtx, &types.QueryScheduleRequest{Id: 3})
+	suite.Require().Error(err)
+
+	schedules, err := suite.queryClient.Schedules(goCtx, &types.QuerySchedulesRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Len(schedules.Schedules, 2)
+	suite.Require().Equal(uint64(1), schedules.Schedules[0].ID)
+}
+
+func mustParseUint(suite *KeeperTestSuite, s string) uint64 {
+	value, err := sdk.ParseUint(s)
+	suite.Require().NoError(err)
+	return value.Uint64()
+}
@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+var _ types.MsgServer = Keeper{}
+
+// CreateSchedule implements the Msg/CreateSchedule gRPC method. It registers the schedule and
+// escrows the deposit, which must cover the fee of at least one call, on the module account.
+func (k Keeper) CreateSchedule(goCtx context.Context, msg *types.MsgCreateSchedule) (*types.MsgCreateScheduleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := k.GetParams(ctx)
+
+	if msg.GasLimit > params.MaxCallGas {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidSchedule, "gas limit %d exceeds the max call gas %d", msg.GasLimit, params.MaxCallGas)
+	}
+
+	if msg.GasPrice.LT(params.MinGasPrice) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidSchedule, "gas price %s is lower than the min gas price %s", msg.GasPrice, params.MinGasPrice)
+	}
+
+	if msg.StartHeight < ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidSchedule, "start height %d is before the current height %d", msg.StartHeight, ctx.BlockHeight())
+	}
+
+	owner, err := sdk.AccAddressFromBech32(msg.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	id := k.GetNextScheduleID(ctx)
+	schedule := types.NewSchedule(
+		id, owner, common.HexToAddress(msg.Contract), msg.Data, msg.GasLimit, msg.GasPrice,
+		msg.StartHeight, msg.Interval, msg.Deposit.Amount,
+	)
+
+	if schedule.Escrow.LT(schedule.MaxFee()) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidDeposit, "deposit %s doesn't cover the fee of a call %s", schedule.Escrow, schedule.MaxFee())
+	}
+
+	if err := k.escrow(ctx, msg.Owner, msg.Deposit); err != nil {
+		return nil, err
+	}
+
+	k.SetSchedule(ctx, schedule)
+	k.SetNextScheduleID(ctx, id+1)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCreateSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(id, 10)),
+			sdk.NewAttribute(types.AttributeKeyOwner, schedule.Owner),
+			sdk.NewAttribute(types.AttributeKeyContract, schedule.Contract),
+			sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatInt(schedule.NextHeight, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Deposit.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner),
+		),
+	})
+
+	return &types.MsgCreateScheduleResponse{ID: id}, nil
+}
+
+// DepositSchedule implements the Msg/DepositSchedule gRPC method. Any account can fund a schedule.
+func (k Keeper) DepositSchedule(goCtx context.Context, msg *types.MsgDepositSchedule) (*types.MsgDepositScheduleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	schedule, found := k.GetSchedule(ctx, msg.ID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrScheduleNotFound, "schedule %d", msg.ID)
+	}
+
+	if err := k.escrow(ctx, msg.Depositor, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	schedule.Escrow = schedule.Escrow.Add(msg.Amount.Amount)
+	k.SetSchedule(ctx, schedule)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDepositSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(msg.ID, 10)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Depositor),
+		),
+	})
+
+	return &types.MsgDepositScheduleResponse{}, nil
+}
+
+// CancelSchedule implements the Msg/CancelSchedule gRPC method. The remaining escrow is refunded
+// to the owner.
+func (k Keeper) CancelSchedule(goCtx context.Context, msg *types.MsgCancelSchedule) (*types.MsgCancelScheduleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	schedule, found := k.GetSchedule(ctx, msg.ID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrScheduleNotFound, "schedule %d", msg.ID)
+	}
+
+	if schedule.Owner != msg.Owner {
+		return nil, sdkerrors.Wrapf(types.ErrUnauthorized, "schedule %d is owned by %s", msg.ID, schedule.Owner)
+	}
+
+	refund := schedule.Escrow
+	if err := k.removeSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(msg.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyRefund, refund.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner),
+		),
+	})
+
+	return &types.MsgCancelScheduleResponse{}, nil
+}
+
+// escrow transfers the deposit, which must be in the evm denom, from the depositor to the module
+// account.
+func (k Keeper) escrow(ctx sdk.Context, depositor string, deposit sdk.Coin) error {
+	evmDenom := k.evmKeeper.GetParams(ctx).EvmDenom
+	if deposit.Denom != evmDenom {
+		return sdkerrors.Wrapf(types.ErrInvalidDeposit, "deposit denom %s must be the evm denom %s", deposit.Denom, evmDenom)
+	}
+
+	depositorAddr, err := sdk.AccAddressFromBech32(depositor)
+	if err != nil {
+		return err
+	}
+
+	return k.bankKeeper.SendCoinsFromAccountToModule(ctx, depositorAddr, types.ModuleName, sdk.NewCoins(deposit))
+}
+
+// removeSchedule deletes the schedule and refunds its remaining escrow to the owner.
+func (k Keeper) removeSchedule(ctx sdk.Context, schedule types.Schedule) error {
+	if schedule.Escrow.IsPositive() {
+		refund := sdk.NewCoins(sdk.NewCoin(k.evmKeeper.GetParams(ctx).EvmDenom, schedule.Escrow))
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, schedule.OwnerAddress(), refund); err != nil {
+			return err
+		}
+	}
+
+	k.DeleteSchedule(ctx, schedule)
+	return nil
+}
@@ -0,0 +1,33 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// NewDecodeStore returns a decoder function closure that unmarshals the KVPair's
+// Value to the corresponding scheduler type.
+func NewDecodeStore(cdc codec.BinaryCodec) func(kvA, kvB kv.Pair) string {
+	return func(kvA, kvB kv.Pair) string {
+		switch {
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixSchedule):
+			var scheduleA, scheduleB types.Schedule
+			cdc.MustUnmarshal(kvA.Value, &scheduleA)
+			cdc.MustUnmarshal(kvB.Value, &scheduleB)
+			return fmt.Sprintf("%v\n%v", scheduleA, scheduleB)
+
+		case bytes.Equal(kvA.Key[:1], types.KeyPrefixQueue),
+			bytes.Equal(kvA.Key[:1], types.KeyNextScheduleID):
+			return fmt.Sprintf("%d\n%d", sdk.BigEndianToUint64(kvA.Value), sdk.BigEndianToUint64(kvB.Value))
+
+		default:
+			panic(fmt.Sprintf("invalid scheduler key prefix %X", kvA.Key[:1]))
+		}
+	}
+}
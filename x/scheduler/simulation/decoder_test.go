@@ -0,0 +1,52 @@
+package simulation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/simulation"
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+func TestDecodeStore(t *testing.T) {
+	dec := simulation.NewDecodeStore(types.ModuleCdc)
+
+	owner := sdk.AccAddress(common.BytesToAddress([]byte("owner")).Bytes())
+	schedule := types.NewSchedule(1, owner, common.BytesToAddress([]byte("contract")), nil, 50_000, sdk.OneInt(), 10, 5, sdk.NewInt(100_000))
+
+	kvPairs := kv.Pairs{
+		Pairs: []kv.Pair{
+			{Key: append(types.KeyPrefixSchedule, types.ScheduleKey(1)...), Value: types.ModuleCdc.MustMarshal(&schedule)},
+			{Key: append(types.KeyPrefixQueue, types.QueueKey(10, 1)...), Value: sdk.Uint64ToBigEndian(1)},
+			{Key: types.KeyNextScheduleID, Value: sdk.Uint64ToBigEndian(2)},
+			{Key: []byte{0x99}, Value: []byte{0x99}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		expectedLog string
+	}{
+		{"Schedule", fmt.Sprintf("%v\n%v", schedule, schedule)},
+		{"Queue", "1\n1"},
+		{"NextScheduleID", "2\n2"},
+		{"other", ""},
+	}
+	for i, tt := range testCases {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			switch i {
+			case len(testCases) - 1:
+				require.Panics(t, func() { dec(kvPairs.Pairs[i], kvPairs.Pairs[i]) }, tt.name)
+			default:
+				require.Equal(t, tt.expectedLog, dec(kvPairs.Pairs[i], kvPairs.Pairs[i]), tt.name)
+			}
+		})
+	}
+}
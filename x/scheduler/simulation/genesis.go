@@ -0,0 +1,69 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// Simulation parameter constants
+const (
+	BlockGasBudget = "block_gas_budget"
+	MaxCallGas     = "max_call_gas"
+	MinGasPrice    = "min_gas_price"
+)
+
+// GenBlockGasBudget randomized BlockGasBudget, which is never lower than the max call gas
+func GenBlockGasBudget(r *rand.Rand) uint64 {
+	return uint64(r.Int63n(19_000_000) + 1_000_000)
+}
+
+// GenMaxCallGas randomized MaxCallGas
+func GenMaxCallGas(r *rand.Rand) uint64 {
+	return uint64(r.Int63n(900_000) + 100_000)
+}
+
+// GenMinGasPrice randomized MinGasPrice. It is kept low, as the simulation accounts
+// balances are small compared to the ones of Ethereum accounts.
+func GenMinGasPrice(r *rand.Rand) sdk.Int {
+	return sdk.NewInt(r.Int63n(10))
+}
+
+// RandomizedGenState generates a random GenesisState for the scheduler module
+func RandomizedGenState(simState *module.SimulationState) {
+	var blockGasBudget uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, BlockGasBudget, &blockGasBudget, simState.Rand,
+		func(r *rand.Rand) { blockGasBudget = GenBlockGasBudget(r) },
+	)
+
+	var maxCallGas uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxCallGas, &maxCallGas, simState.Rand,
+		func(r *rand.Rand) { maxCallGas = GenMaxCallGas(r) },
+	)
+
+	var minGasPrice sdk.Int
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MinGasPrice, &minGasPrice, simState.Rand,
+		func(r *rand.Rand) { minGasPrice = GenMinGasPrice(r) },
+	)
+
+	params := types.NewParams(blockGasBudget, maxCallGas, minGasPrice)
+	schedulerGenesis := types.NewGenesisState(params, []types.Schedule{}, 1)
+
+	bz, err := json.MarshalIndent(schedulerGenesis, "", " ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Selected randomly generated %s parameters:\n%s\n", types.ModuleName, bz)
+
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(schedulerGenesis)
+}
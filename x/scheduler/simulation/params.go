@@ -0,0 +1,30 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/x/scheduler/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simtypes.ParamChange {
+	return []simtypes.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyBlockGasBudget),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenBlockGasBudget(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMinGasPrice),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenMinGasPrice(r))
+			},
+		),
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	codeErrScheduleNotFound = uint32(iota) + 2 // NOTE: code 1 is reserved for internal errors
+	codeErrInvalidSchedule
+	codeErrInvalidDeposit
+	codeErrUnauthorized
+)
+
+var (
+	// ErrScheduleNotFound returns an error if the schedule doesn't exist.
+	ErrScheduleNotFound = sdkerrors.Register(ModuleName, codeErrScheduleNotFound, "schedule not found")
+
+	// ErrInvalidSchedule returns an error if the schedule fields are invalid.
+	ErrInvalidSchedule = sdkerrors.Register(ModuleName, codeErrInvalidSchedule, "invalid schedule")
+
+	// ErrInvalidDeposit returns an error if the deposit denomination or amount is invalid.
+	ErrInvalidDeposit = sdkerrors.Register(ModuleName, codeErrInvalidDeposit, "invalid deposit")
+
+	// ErrUnauthorized returns an error if the signer isn't the owner of the schedule.
+	ErrUnauthorized = sdkerrors.Register(ModuleName, codeErrUnauthorized, "signer is not the schedule owner")
+)
@@ -0,0 +1,28 @@
+package types
+
+// Scheduler module events
+const (
+	EventTypeCreateSchedule  = "create_schedule"
+	EventTypeDepositSchedule = "deposit_schedule"
+	EventTypeCancelSchedule  = "cancel_schedule"
+	EventTypeScheduleEnded   = "schedule_ended"
+	EventTypeScheduledCall   = "scheduled_call"
+
+	AttributeKeyScheduleID       = "schedule_id"
+	AttributeKeyOwner            = "owner"
+	AttributeKeyContract         = "contract"
+	AttributeKeyHeight           = "height"
+	AttributeKeyGasUsed          = "gas_used"
+	AttributeKeyFee              = "fee"
+	AttributeKeyRefund           = "refund"
+	AttributeKeyEthereumTxHash   = "ethereumTxHash"
+	AttributeKeyEthereumTxFailed = "ethereumTxFailed"
+	AttributeKeyReturnData       = "ret"
+	AttributeKeyError            = "error"
+	AttributeKeyReason           = "reason"
+
+	AttributeValueCategory          = ModuleName
+	AttributeValueCompleted         = "completed"
+	AttributeValueInsufficientFunds = "insufficient_escrow"
+	AttributeValueGasLimitExceeded  = "gas_limit_exceeds_budget"
+)
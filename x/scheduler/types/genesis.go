@@ -0,0 +1,46 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DefaultGenesisState sets default scheduler genesis state.
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
+		Params:         DefaultParams(),
+		Schedules:      []Schedule{},
+		NextScheduleID: 1,
+	}
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(params Params, schedules []Schedule, nextScheduleID uint64) *GenesisState {
+	return &GenesisState{
+		Params:         params,
+		Schedules:      schedules,
+		NextScheduleID: nextScheduleID,
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	seenIDs := make(map[uint64]bool)
+	for _, schedule := range gs.Schedules {
+		if seenIDs[schedule.ID] {
+			return fmt.Errorf("duplicated schedule id %d", schedule.ID)
+		}
+
+		if schedule.ID >= gs.NextScheduleID {
+			return fmt.Errorf("schedule id %d must be lower than the next schedule id %d", schedule.ID, gs.NextScheduleID)
+		}
+
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("invalid schedule %d: %w", schedule.ID, err)
+		}
+
+		seenIDs[schedule.ID] = true
+	}
+
+	return gs.Params.Validate()
+}
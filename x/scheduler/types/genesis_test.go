@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/crypto/ethsecp256k1"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+
+	owner := sdk.AccAddress(priv.PubKey().Address())
+	contract := common.BytesToAddress([]byte("contract"))
+	schedule := NewSchedule(1, owner, contract, nil, 50_000, sdk.OneInt(), 10, 5, sdk.NewInt(100_000))
+
+	testCases := []struct {
+		name     string
+		malleate func(gs *GenesisState)
+		expPass  bool
+	}{
+		{"default", func(gs *GenesisState) {}, true},
+		{"valid schedules", func(gs *GenesisState) {
+			second := schedule
+			second.ID = 2
+			second.NextHeight = 20
+			gs.Schedules = []Schedule{schedule, second}
+			gs.NextScheduleID = 3
+		}, true},
+		{"duplicated schedule id", func(gs *GenesisState) {
+			gs.Schedules = []Schedule{schedule, schedule}
+			gs.NextScheduleID = 2
+		}, false},
+		{"schedule id not lower than the next id", func(gs *GenesisState) {
+			gs.Schedules = []Schedule{schedule}
+		}, false},
+		{"invalid owner", func(gs *GenesisState) {
+			invalid := schedule
+			invalid.Owner = "owner"
+			gs.Schedules = []Schedule{invalid}
+			gs.NextScheduleID = 2
+		}, false},
+		{"next height before start height", func(gs *GenesisState) {
+			invalid := schedule
+			invalid.NextHeight = 9
+			gs.Schedules = []Schedule{invalid}
+			gs.NextScheduleID = 2
+		}, false},
+		{"gas limit lower than intrinsic gas", func(gs *GenesisState) {
+			invalid := schedule
+			invalid.GasLimit = 20_000
+			gs.Schedules = []Schedule{invalid}
+			gs.NextScheduleID = 2
+		}, false},
+		{"negative escrow", func(gs *GenesisState) {
+			invalid := schedule
+			invalid.Escrow = sdk.NewInt(-1)
+			gs.Schedules = []Schedule{invalid}
+			gs.NextScheduleID = 2
+		}, false},
+		{"block gas budget lower than max call gas", func(gs *GenesisState) {
+			gs.Params.BlockGasBudget = gs.Params.MaxCallGas - 1
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := DefaultGenesisState()
+			tc.malleate(gs)
+
+			err := gs.Validate()
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
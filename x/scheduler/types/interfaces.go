@@ -0,0 +1,35 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+
+	evmtypes "github.com/Electronic-Signatures-Industries/ancon-evm/x/evm/types"
+)
+
+// AccountKeeper defines the expected account keeper interface
+type AccountKeeper interface {
+	GetModuleAddress(moduleName string) sdk.AccAddress
+	GetModuleAccount(ctx sdk.Context, moduleName string) authtypes.ModuleAccountI
+}
+
+// BankKeeper defines the expected interface needed to escrow the schedule deposits and pay the fees.
+type BankKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+}
+
+// EVMKeeper defines the expected EVM keeper interface used to execute the scheduled calls.
+type EVMKeeper interface {
+	GetParams(ctx sdk.Context) evmtypes.Params
+	WithContext(ctx sdk.Context)
+	ApplyNativeMessage(msg core.Message) (*evmtypes.MsgEthereumTxResponse, error)
+	SetTxHashTransient(hash common.Hash)
+	GetBlockBloomTransient() *big.Int
+	SetBlockBloomTransient(bloom *big.Int)
+}
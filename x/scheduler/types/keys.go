@@ -0,0 +1,46 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName string name of module
+	ModuleName = "scheduler"
+
+	// StoreKey key for the scheduler module store
+	StoreKey = ModuleName
+
+	// RouterKey uses module name for routing
+	RouterKey = ModuleName
+)
+
+// prefix bytes for the scheduler persistent store
+const (
+	prefixSchedule = iota + 1
+	prefixQueue
+	prefixNextScheduleID
+)
+
+// KVStore key prefixes
+var (
+	KeyPrefixSchedule = []byte{prefixSchedule}
+	KeyPrefixQueue    = []byte{prefixQueue}
+	KeyNextScheduleID = []byte{prefixNextScheduleID}
+)
+
+// ScheduleKey returns the key of a schedule on the schedule prefix store.
+func ScheduleKey(id uint64) []byte {
+	return sdk.Uint64ToBigEndian(id)
+}
+
+// QueueKey returns the key of a schedule on the queue prefix store. The schedules are ordered by the
+// height of their next call and then by identifier.
+func QueueKey(height int64, id uint64) []byte {
+	return append(sdk.Uint64ToBigEndian(uint64(height)), sdk.Uint64ToBigEndian(id)...)
+}
+
+// QueueHeightPrefixEnd returns the exclusive end key of the schedules queued up to the given height.
+func QueueHeightPrefixEnd(height int64) []byte {
+	return sdk.Uint64ToBigEndian(uint64(height) + 1)
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+var (
+	_ sdk.Msg = &MsgCreateSchedule{}
+	_ sdk.Msg = &MsgDepositSchedule{}
+	_ sdk.Msg = &MsgCancelSchedule{}
+
+	_ legacytx.LegacyMsg = &MsgCreateSchedule{}
+	_ legacytx.LegacyMsg = &MsgDepositSchedule{}
+	_ legacytx.LegacyMsg = &MsgCancelSchedule{}
+)
+
+// message type and route constants
+const (
+	// TypeMsgCreateSchedule defines the type string of a schedule registration
+	TypeMsgCreateSchedule = "create_schedule"
+	// TypeMsgDepositSchedule defines the type string of a schedule deposit
+	TypeMsgDepositSchedule = "deposit_schedule"
+	// TypeMsgCancelSchedule defines the type string of a schedule cancellation
+	TypeMsgCancelSchedule = "cancel_schedule"
+)
+
+// NewMsgCreateSchedule returns a new MsgCreateSchedule.
+func NewMsgCreateSchedule(
+	owner sdk.AccAddress, contract common.Address, data []byte, gasLimit uint64, gasPrice sdk.Int,
+	startHeight int64, interval uint64, deposit sdk.Coin,
+) *MsgCreateSchedule {
+	return &MsgCreateSchedule{
+		Owner:       owner.String(),
+		Contract:    contract.Hex(),
+		Data:        data,
+		GasLimit:    gasLimit,
+		GasPrice:    gasPrice,
+		StartHeight: startHeight,
+		Interval:    interval,
+		Deposit:     deposit,
+	}
+}
+
+// Route returns the message route key.
+func (msg MsgCreateSchedule) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCreateSchedule) Type() string { return TypeMsgCreateSchedule }
+
+// ValidateBasic performs a stateless validation of the message fields.
+func (msg MsgCreateSchedule) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Owner); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid owner address %s: %s", msg.Owner, err)
+	}
+
+	if err := types.ValidateAddress(msg.Contract); err != nil {
+		return sdkerrors.Wrapf(err, "invalid contract address %s", msg.Contract)
+	}
+
+	if err := validateCall(msg.GasLimit, msg.GasPrice, msg.StartHeight); err != nil {
+		return err
+	}
+
+	return validateDeposit(msg.Deposit)
+}
+
+// GetSigners returns the owner address.
+func (msg MsgCreateSchedule) GetSigners() []sdk.AccAddress {
+	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
+	return []sdk.AccAddress{owner}
+}
+
+// GetSignBytes returns the bytes of the message to sign with amino JSON.
+func (msg MsgCreateSchedule) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// NewMsgDepositSchedule returns a new MsgDepositSchedule.
+func NewMsgDepositSchedule(depositor sdk.AccAddress, id uint64, amount sdk.Coin) *MsgDepositSchedule {
+	return &MsgDepositSchedule{
+		Depositor: depositor.String(),
+		ID:        id,
+		Amount:    amount,
+	}
+}
+
+// Route returns the message route key.
+func (msg MsgDepositSchedule) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgDepositSchedule) Type() string { return TypeMsgDepositSchedule }
+
+// ValidateBasic performs a stateless validation of the message fields.
+func (msg MsgDepositSchedule) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Depositor); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid depositor address %s: %s", msg.Depositor, err)
+	}
+
+	return validateDeposit(msg.Amount)
+}
+
+// GetSigners returns the depositor address.
+func (msg MsgDepositSchedule) GetSigners() []sdk.AccAddress {
+	depositor, _ := sdk.AccAddressFromBech32(msg.Depositor)
+	return []sdk.AccAddress{depositor}
+}
+
+// GetSignBytes returns the bytes of the message to sign with amino JSON.
+func (msg MsgDepositSchedule) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// NewMsgCancelSchedule returns a new MsgCancelSchedule.
+func NewMsgCancelSchedule(owner sdk.AccAddress, id uint64) *MsgCancelSchedule {
+	return &MsgCancelSchedule{
+		Owner: owner.String(),
+		ID:    id,
+	}
+}
+
+// Route returns the message route key.
+func (msg MsgCancelSchedule) Route() string { return RouterKey }
+
+// Type returns the message type.
+func (msg MsgCancelSchedule) Type() string { return TypeMsgCancelSchedule }
+
+// ValidateBasic performs a stateless validation of the message fields.
+func (msg MsgCancelSchedule) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Owner); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid owner address %s: %s", msg.Owner, err)
+	}
+
+	return nil
+}
+
+// GetSigners returns the owner address.
+func (msg MsgCancelSchedule) GetSigners() []sdk.AccAddress {
+	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
+	return []sdk.AccAddress{owner}
+}
+
+// GetSignBytes returns the bytes of the message to sign with amino JSON.
+func (msg MsgCancelSchedule) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func validateDeposit(coin sdk.Coin) error {
+	if !coin.IsValid() || !coin.IsPositive() {
+		return sdkerrors.Wrapf(ErrInvalidDeposit, "deposit must be a valid positive amount: %s", coin)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// DefaultBlockGasBudget is the default maximum total gas limit of the scheduled calls executed in
+	// a block
+	DefaultBlockGasBudget = 10_000_000
+	// DefaultMaxCallGas is the default maximum gas limit of a scheduled call
+	DefaultMaxCallGas = 1_000_000
+)
+
+var _ paramtypes.ParamSet = &Params{}
+
+// Parameter keys
+var (
+	ParamStoreKeyBlockGasBudget = []byte("BlockGasBudget")
+	ParamStoreKeyMaxCallGas     = []byte("MaxCallGas")
+	ParamStoreKeyMinGasPrice    = []byte("MinGasPrice")
+)
+
+// ParamKeyTable returns the parameter key table.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params instance
+func NewParams(blockGasBudget, maxCallGas uint64, minGasPrice sdk.Int) Params {
+	return Params{
+		BlockGasBudget: blockGasBudget,
+		MaxCallGas:     maxCallGas,
+		MinGasPrice:    minGasPrice,
+	}
+}
+
+// DefaultParams returns default scheduler parameters
+func DefaultParams() Params {
+	return Params{
+		BlockGasBudget: DefaultBlockGasBudget,
+		MaxCallGas:     DefaultMaxCallGas,
+		MinGasPrice:    sdk.ZeroInt(),
+	}
+}
+
+// ParamSetPairs returns the parameter set pairs.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(ParamStoreKeyBlockGasBudget, &p.BlockGasBudget, validateGas),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxCallGas, &p.MaxCallGas, validateGas),
+		paramtypes.NewParamSetPair(ParamStoreKeyMinGasPrice, &p.MinGasPrice, validateMinGasPrice),
+	}
+}
+
+// Validate performs basic validation on scheduler parameters.
+func (p Params) Validate() error {
+	if p.MaxCallGas < ethparams.TxGas {
+		return fmt.Errorf("max call gas cannot be lower than the intrinsic gas of a call: %d < %d", p.MaxCallGas, ethparams.TxGas)
+	}
+
+	if p.BlockGasBudget < p.MaxCallGas {
+		return fmt.Errorf("block gas budget cannot be lower than the max call gas: %d < %d", p.BlockGasBudget, p.MaxCallGas)
+	}
+
+	return validateMinGasPrice(p.MinGasPrice)
+}
+
+func validateGas(i interface{}) error {
+	value, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if value < ethparams.TxGas {
+		return fmt.Errorf("gas cannot be lower than the intrinsic gas of a call: %d < %d", value, ethparams.TxGas)
+	}
+
+	return nil
+}
+
+func validateMinGasPrice(i interface{}) error {
+	value, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if value.IsNil() || value.IsNegative() {
+		return fmt.Errorf("min gas price cannot be nil or negative: %s", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+
+	"github.com/Electronic-Signatures-Industries/ancon-evm/types"
+)
+
+// NewSchedule returns a schedule whose first call is at the start height.
+func NewSchedule(
+	id uint64, owner sdk.AccAddress, contract common.Address, data []byte,
+	gasLimit uint64, gasPrice sdk.Int, startHeight int64, interval uint64, escrow sdk.Int,
+) Schedule {
+	return Schedule{
+		ID:          id,
+		Owner:       owner.String(),
+		Contract:    contract.Hex(),
+		Data:        data,
+		GasLimit:    gasLimit,
+		GasPrice:    gasPrice,
+		StartHeight: startHeight,
+		Interval:    interval,
+		NextHeight:  startHeight,
+		Escrow:      escrow,
+	}
+}
+
+// Validate performs a stateless validation of the schedule fields.
+func (s Schedule) Validate() error {
+	if _, err := sdk.AccAddressFromBech32(s.Owner); err != nil {
+		return sdkerrors.Wrapf(err, "invalid owner address %s", s.Owner)
+	}
+
+	if err := types.ValidateAddress(s.Contract); err != nil {
+		return sdkerrors.Wrapf(err, "invalid contract address %s", s.Contract)
+	}
+
+	if err := validateCall(s.GasLimit, s.GasPrice, s.StartHeight); err != nil {
+		return err
+	}
+
+	if s.NextHeight < s.StartHeight {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "next height %d is before the start height %d", s.NextHeight, s.StartHeight)
+	}
+
+	if s.Escrow.IsNil() || s.Escrow.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "escrow cannot be nil or negative: %s", s.Escrow)
+	}
+
+	return nil
+}
+
+// OwnerAddress returns the owner account address. It panics if the address is invalid.
+func (s Schedule) OwnerAddress() sdk.AccAddress {
+	owner, err := sdk.AccAddressFromBech32(s.Owner)
+	if err != nil {
+		panic(err)
+	}
+	return owner
+}
+
+// MaxFee returns the fee of a call that uses its whole gas limit.
+func (s Schedule) MaxFee() sdk.Int {
+	return s.Fee(s.GasLimit)
+}
+
+// Fee returns the fee of a call that used the given amount of gas.
+func (s Schedule) Fee(gasUsed uint64) sdk.Int {
+	return s.GasPrice.Mul(sdk.NewIntFromUint64(gasUsed))
+}
+
+// CallHash returns the hash that identifies the call of a schedule at the given height. It's used as
+// the transaction hash of the EVM logs emitted by the call.
+func CallHash(id uint64, height int64) common.Hash {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz, id)
+	binary.BigEndian.PutUint64(bz[8:], uint64(height))
+	return crypto.Keccak256Hash([]byte(ModuleName), bz)
+}
+
+func validateCall(gasLimit uint64, gasPrice sdk.Int, startHeight int64) error {
+	if gasLimit < ethparams.TxGas {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "gas limit cannot be lower than the intrinsic gas of a call: %d < %d", gasLimit, ethparams.TxGas)
+	}
+
+	if gasPrice.IsNil() || gasPrice.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "gas price cannot be nil or negative: %s", gasPrice)
+	}
+
+	if startHeight <= 0 {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "start height must be positive: %d", startHeight)
+	}
+
+	return nil
+}
@@ -0,0 +1,939 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ethermint/scheduler/v1/scheduler.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Params defines the scheduler module parameters
+type Params struct {
+	// block gas budget is the maximum total gas limit of the scheduled calls executed in a block.
+	BlockGasBudget uint64 `protobuf:"varint,1,opt,name=block_gas_budget,json=blockGasBudget,proto3" json:"block_gas_budget,omitempty"`
+	// max call gas is the maximum gas limit of a scheduled call.
+	MaxCallGas uint64 `protobuf:"varint,2,opt,name=max_call_gas,json=maxCallGas,proto3" json:"max_call_gas,omitempty"`
+	// min gas price is the minimum price per unit of gas paid by the scheduled calls, in the evm denom.
+	MinGasPrice github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,3,opt,name=min_gas_price,json=minGasPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"min_gas_price"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_516f47c47353e9d1, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetBlockGasBudget() uint64 {
+	if m != nil {
+		return m.BlockGasBudget
+	}
+	return 0
+}
+
+func (m *Params) GetMaxCallGas() uint64 {
+	if m != nil {
+		return m.MaxCallGas
+	}
+	return 0
+}
+
+// Schedule defines a recurring or one-off call of a contract executed by the scheduler module at the
+// end of the blocks.
+type Schedule struct {
+	// id is the unique identifier of the schedule.
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// owner is the bech32 address of the account that registered the schedule. It's the sender of the
+	// calls and receives the remaining escrow when the schedule ends.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// contract is the hex address of the called contract.
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3" json:"contract,omitempty"`
+	// data is the calldata of the calls.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// gas limit is the gas limit of each call.
+	GasLimit uint64 `protobuf:"varint,5,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// gas price is the price paid for each unit of gas used by the calls, in the evm denom.
+	GasPrice github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,6,opt,name=gas_price,json=gasPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"gas_price"`
+	// start height is the height of the first call.
+	StartHeight int64 `protobuf:"varint,7,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// interval is the number of blocks between two calls. The schedule ends after its first call if
+	// the interval is zero.
+	Interval uint64 `protobuf:"varint,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	// next height is the height of the next call. It's later than the scheduled height when the call
+	// was delayed by the block gas budget.
+	NextHeight int64 `protobuf:"varint,9,opt,name=next_height,json=nextHeight,proto3" json:"next_height,omitempty"`
+	// escrow is the amount of evm denom prepaid for the fees of the calls.
+	Escrow github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,10,opt,name=escrow,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"escrow"`
+	// calls is the number of executed calls.
+	Calls uint64 `protobuf:"varint,11,opt,name=calls,proto3" json:"calls,omitempty"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_516f47c47353e9d1, []int{1}
+}
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Params)(nil), "ethermint.scheduler.v1.Params")
+	proto.RegisterType((*Schedule)(nil), "ethermint.scheduler.v1.Schedule")
+}
+
+func init() {
+	proto.RegisterFile("ethermint/scheduler/v1/scheduler.proto", fileDescriptor_516f47c47353e9d1)
+}
+
+var fileDescriptor_516f47c47353e9d1 = []byte{
+	// 479 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xbd, 0x6e, 0xdb, 0x30,
+	0x1c, 0xc4, 0x25, 0xdb, 0x51, 0x25, 0xda, 0x2d, 0x0a, 0x22, 0x08, 0x84, 0x14, 0x90, 0xd4, 0x0c,
+	0x81, 0x16, 0x4b, 0x08, 0xba, 0x75, 0x74, 0x3f, 0x5c, 0xa3, 0x1d, 0x02, 0x65, 0xeb, 0x62, 0xd0,
+	0x14, 0x21, 0x11, 0x11, 0x49, 0x83, 0xa4, 0x1c, 0xf7, 0x0d, 0x3a, 0x76, 0xea, 0xdc, 0xb1, 0x8f,
+	0x92, 0x31, 0x63, 0xd1, 0xc1, 0x28, 0xec, 0x17, 0x29, 0x44, 0x29, 0x76, 0x66, 0x4f, 0xe2, 0x9d,
+	0xee, 0x4f, 0xfd, 0x4e, 0x24, 0xb8, 0x24, 0xba, 0x24, 0x92, 0x51, 0xae, 0x53, 0x85, 0x4b, 0x92,
+	0xd7, 0x15, 0x91, 0xe9, 0xea, 0xea, 0x20, 0x92, 0xa5, 0x14, 0x5a, 0xc0, 0xb3, 0x7d, 0x2e, 0x39,
+	0xbc, 0x5a, 0x5d, 0x9d, 0x9f, 0x16, 0xa2, 0x10, 0x26, 0x92, 0x36, 0xab, 0x36, 0x7d, 0xf1, 0xdb,
+	0x06, 0xce, 0x35, 0x92, 0x88, 0x29, 0x18, 0x83, 0x97, 0x8b, 0x4a, 0xe0, 0xdb, 0x79, 0x81, 0xd4,
+	0x7c, 0x51, 0xe7, 0x05, 0xd1, 0xbe, 0x1d, 0xd9, 0xf1, 0x20, 0x7b, 0x61, 0xfc, 0x29, 0x52, 0x13,
+	0xe3, 0xc2, 0x08, 0x8c, 0x18, 0x5a, 0xcf, 0x31, 0xaa, 0xaa, 0x26, 0xec, 0xf7, 0x4c, 0x0a, 0x30,
+	0xb4, 0x7e, 0x87, 0xaa, 0x6a, 0x8a, 0x14, 0xcc, 0xc0, 0x73, 0x46, 0xb9, 0xd9, 0x69, 0x29, 0x29,
+	0x26, 0x7e, 0x3f, 0xb2, 0x63, 0x6f, 0x92, 0xdc, 0x6f, 0x42, 0xeb, 0xef, 0x26, 0xbc, 0x2c, 0xa8,
+	0x2e, 0xeb, 0x45, 0x82, 0x05, 0x4b, 0xb1, 0x50, 0x4c, 0xa8, 0xee, 0x31, 0x56, 0xf9, 0x6d, 0xaa,
+	0xbf, 0x2d, 0x89, 0x4a, 0x66, 0x5c, 0x67, 0x43, 0x46, 0xf9, 0x14, 0xa9, 0xeb, 0x66, 0x8b, 0x8b,
+	0x9f, 0x7d, 0xe0, 0xde, 0x74, 0x8d, 0xe0, 0x19, 0xe8, 0xd1, 0xbc, 0xc5, 0x9b, 0x38, 0xdb, 0x4d,
+	0xd8, 0x9b, 0xbd, 0xcf, 0x7a, 0x34, 0x87, 0xa7, 0xe0, 0x44, 0xdc, 0x71, 0x22, 0x0d, 0x93, 0x97,
+	0xb5, 0x02, 0x9e, 0x03, 0x17, 0x0b, 0xae, 0x25, 0xc2, 0xba, 0x25, 0xc9, 0xf6, 0x1a, 0x42, 0x30,
+	0xc8, 0x91, 0x46, 0xfe, 0x20, 0xb2, 0xe3, 0x51, 0x66, 0xd6, 0xf0, 0x15, 0xf0, 0x1a, 0xf4, 0x8a,
+	0x32, 0xaa, 0xfd, 0x13, 0xd3, 0xce, 0x2d, 0x90, 0xfa, 0xd2, 0x68, 0xf8, 0x19, 0x78, 0x87, 0x5e,
+	0xce, 0x51, 0xbd, 0xdc, 0xa2, 0x2b, 0x05, 0x5f, 0x83, 0x91, 0xd2, 0x48, 0xea, 0x79, 0x49, 0x68,
+	0x51, 0x6a, 0xff, 0x59, 0x64, 0xc7, 0xfd, 0x6c, 0x68, 0xbc, 0x4f, 0xc6, 0x6a, 0xe0, 0x29, 0xd7,
+	0x44, 0xae, 0x50, 0xe5, 0xbb, 0x2d, 0xcb, 0xa3, 0x86, 0x21, 0x18, 0x72, 0xb2, 0xde, 0x4f, 0x7b,
+	0x66, 0x1a, 0x34, 0x56, 0x37, 0xfc, 0x11, 0x38, 0x44, 0x61, 0x29, 0xee, 0x7c, 0x70, 0x14, 0x69,
+	0x37, 0xdd, 0xfc, 0xd7, 0xe6, 0xb8, 0x95, 0x3f, 0x34, 0x04, 0xad, 0x78, 0x3b, 0xf8, 0xfe, 0x2b,
+	0xb4, 0x26, 0xe8, 0x7e, 0x1b, 0xd8, 0x0f, 0xdb, 0xc0, 0xfe, 0xb7, 0x0d, 0xec, 0x1f, 0xbb, 0xc0,
+	0x7a, 0xd8, 0x05, 0xd6, 0x9f, 0x5d, 0x60, 0x7d, 0x9d, 0x3e, 0xf9, 0xca, 0x87, 0x8a, 0x60, 0x2d,
+	0x05, 0xa7, 0x78, 0x7c, 0x43, 0x0b, 0x8e, 0x74, 0x2d, 0x89, 0x1a, 0xcf, 0x78, 0x5e, 0x2b, 0x2d,
+	0x29, 0x51, 0x29, 0xe2, 0x58, 0xf0, 0x31, 0x59, 0xb1, 0x74, 0xfd, 0xe4, 0x86, 0x1b, 0x94, 0x85,
+	0x63, 0x6e, 0xeb, 0x9b, 0xff, 0x03, 0x00, 0xb2, 0x11, 0x13, 0xbf, 0x05, 0x03, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.MinGasPrice.Size()
+		i -= size
+		if _, err := m.MinGasPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintScheduler(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxCallGas != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.MaxCallGas))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BlockGasBudget != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.BlockGasBudget))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Schedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Schedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Schedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Calls != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Calls))
+		i--
+		dAtA[i] = 0x58
+	}
+	{
+		size := m.Escrow.Size()
+		i -= size
+		if _, err := m.Escrow.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintScheduler(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x52
+	if m.NextHeight != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.NextHeight))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Interval != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Interval))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.StartHeight != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.StartHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	{
+		size := m.GasPrice.Size()
+		i -= size
+		if _, err := m.GasPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintScheduler(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if m.GasLimit != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.GasLimit))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintScheduler(dAtA []byte, offset int, v uint64) int {
+	offset -= sovScheduler(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockGasBudget != 0 {
+		n += 1 + sovScheduler(uint64(m.BlockGasBudget))
+	}
+	if m.MaxCallGas != 0 {
+		n += 1 + sovScheduler(uint64(m.MaxCallGas))
+	}
+	l = m.MinGasPrice.Size()
+	n += 1 + l + sovScheduler(uint64(l))
+	return n
+}
+
+func (m *Schedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovScheduler(uint64(m.ID))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	if m.GasLimit != 0 {
+		n += 1 + sovScheduler(uint64(m.GasLimit))
+	}
+	l = m.GasPrice.Size()
+	n += 1 + l + sovScheduler(uint64(l))
+	if m.StartHeight != 0 {
+		n += 1 + sovScheduler(uint64(m.StartHeight))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovScheduler(uint64(m.Interval))
+	}
+	if m.NextHeight != 0 {
+		n += 1 + sovScheduler(uint64(m.NextHeight))
+	}
+	l = m.Escrow.Size()
+	n += 1 + l + sovScheduler(uint64(l))
+	if m.Calls != 0 {
+		n += 1 + sovScheduler(uint64(m.Calls))
+	}
+	return n
+}
+
+func sovScheduler(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozScheduler(x uint64) (n int) {
+	return sovScheduler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockGasBudget", wireType)
+			}
+			m.BlockGasBudget = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockGasBudget |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCallGas", wireType)
+			}
+			m.MaxCallGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCallGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinGasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Schedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Schedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Schedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimit", wireType)
+			}
+			m.GasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
+			}
+			m.StartHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextHeight", wireType)
+			}
+			m.NextHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Escrow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Escrow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Calls", wireType)
+			}
+			m.Calls = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Calls |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipScheduler(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthScheduler
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupScheduler
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthScheduler
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthScheduler        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowScheduler          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupScheduler = fmt.Errorf("proto: unexpected end of group")
+)
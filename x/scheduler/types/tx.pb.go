@@ -0,0 +1,1606 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ethermint/scheduler/v1/tx.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// MsgCreateSchedule registers a scheduled call of a contract.
+type MsgCreateSchedule struct {
+	// owner is the bech32 address of the account registering the schedule.
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// contract is the hex address of the called contract.
+	Contract string `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	// data is the calldata of the calls.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// gas limit is the gas limit of each call.
+	GasLimit uint64 `protobuf:"varint,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// gas price is the price paid for each unit of gas used by the calls, in the evm denom.
+	GasPrice github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,5,opt,name=gas_price,json=gasPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"gas_price"`
+	// start height is the height of the first call.
+	StartHeight int64 `protobuf:"varint,6,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// interval is the number of blocks between two calls. Zero schedules a single call.
+	Interval uint64 `protobuf:"varint,7,opt,name=interval,proto3" json:"interval,omitempty"`
+	// deposit is the amount of evm denom escrowed for the fees of the calls.
+	Deposit types.Coin `protobuf:"bytes,8,opt,name=deposit,proto3" json:"deposit"`
+}
+
+func (m *MsgCreateSchedule) Reset()         { *m = MsgCreateSchedule{} }
+func (m *MsgCreateSchedule) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateSchedule) ProtoMessage()    {}
+func (*MsgCreateSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{0}
+}
+func (m *MsgCreateSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgCreateSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgCreateSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgCreateSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgCreateSchedule.Merge(m, src)
+}
+func (m *MsgCreateSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgCreateSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgCreateSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgCreateSchedule proto.InternalMessageInfo
+
+// MsgCreateScheduleResponse defines the Msg/CreateSchedule response type.
+type MsgCreateScheduleResponse struct {
+	// id is the identifier of the registered schedule.
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *MsgCreateScheduleResponse) Reset()         { *m = MsgCreateScheduleResponse{} }
+func (m *MsgCreateScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgCreateScheduleResponse) ProtoMessage()    {}
+func (*MsgCreateScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{1}
+}
+func (m *MsgCreateScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgCreateScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgCreateScheduleResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgCreateScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgCreateScheduleResponse.Merge(m, src)
+}
+func (m *MsgCreateScheduleResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgCreateScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgCreateScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgCreateScheduleResponse proto.InternalMessageInfo
+
+func (m *MsgCreateScheduleResponse) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+// MsgDepositSchedule adds funds to the escrow of a schedule.
+type MsgDepositSchedule struct {
+	// depositor is the bech32 address of the account funding the schedule.
+	Depositor string `protobuf:"bytes,1,opt,name=depositor,proto3" json:"depositor,omitempty"`
+	// id is the identifier of the schedule.
+	ID uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// amount is the amount of evm denom added to the escrow.
+	Amount types.Coin `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount"`
+}
+
+func (m *MsgDepositSchedule) Reset()         { *m = MsgDepositSchedule{} }
+func (m *MsgDepositSchedule) String() string { return proto.CompactTextString(m) }
+func (*MsgDepositSchedule) ProtoMessage()    {}
+func (*MsgDepositSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{2}
+}
+func (m *MsgDepositSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgDepositSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgDepositSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgDepositSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgDepositSchedule.Merge(m, src)
+}
+func (m *MsgDepositSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgDepositSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgDepositSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgDepositSchedule proto.InternalMessageInfo
+
+// MsgDepositScheduleResponse defines the Msg/DepositSchedule response type.
+type MsgDepositScheduleResponse struct {
+}
+
+func (m *MsgDepositScheduleResponse) Reset()         { *m = MsgDepositScheduleResponse{} }
+func (m *MsgDepositScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgDepositScheduleResponse) ProtoMessage()    {}
+func (*MsgDepositScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{3}
+}
+func (m *MsgDepositScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgDepositScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgDepositScheduleResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgDepositScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgDepositScheduleResponse.Merge(m, src)
+}
+func (m *MsgDepositScheduleResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgDepositScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgDepositScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgDepositScheduleResponse proto.InternalMessageInfo
+
+// MsgCancelSchedule removes a schedule.
+type MsgCancelSchedule struct {
+	// owner is the bech32 address of the owner of the schedule.
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// id is the identifier of the schedule.
+	ID uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *MsgCancelSchedule) Reset()         { *m = MsgCancelSchedule{} }
+func (m *MsgCancelSchedule) String() string { return proto.CompactTextString(m) }
+func (*MsgCancelSchedule) ProtoMessage()    {}
+func (*MsgCancelSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{4}
+}
+func (m *MsgCancelSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgCancelSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgCancelSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgCancelSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgCancelSchedule.Merge(m, src)
+}
+func (m *MsgCancelSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgCancelSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgCancelSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgCancelSchedule proto.InternalMessageInfo
+
+// MsgCancelScheduleResponse defines the Msg/CancelSchedule response type.
+type MsgCancelScheduleResponse struct {
+}
+
+func (m *MsgCancelScheduleResponse) Reset()         { *m = MsgCancelScheduleResponse{} }
+func (m *MsgCancelScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgCancelScheduleResponse) ProtoMessage()    {}
+func (*MsgCancelScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_309d7cbacf5cde31, []int{5}
+}
+func (m *MsgCancelScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgCancelScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgCancelScheduleResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgCancelScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgCancelScheduleResponse.Merge(m, src)
+}
+func (m *MsgCancelScheduleResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgCancelScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgCancelScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgCancelScheduleResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*MsgCreateSchedule)(nil), "ethermint.scheduler.v1.MsgCreateSchedule")
+	proto.RegisterType((*MsgCreateScheduleResponse)(nil), "ethermint.scheduler.v1.MsgCreateScheduleResponse")
+	proto.RegisterType((*MsgDepositSchedule)(nil), "ethermint.scheduler.v1.MsgDepositSchedule")
+	proto.RegisterType((*MsgDepositScheduleResponse)(nil), "ethermint.scheduler.v1.MsgDepositScheduleResponse")
+	proto.RegisterType((*MsgCancelSchedule)(nil), "ethermint.scheduler.v1.MsgCancelSchedule")
+	proto.RegisterType((*MsgCancelScheduleResponse)(nil), "ethermint.scheduler.v1.MsgCancelScheduleResponse")
+}
+
+func init() { proto.RegisterFile("ethermint/scheduler/v1/tx.proto", fileDescriptor_309d7cbacf5cde31) }
+
+var fileDescriptor_309d7cbacf5cde31 = []byte{
+	// 575 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4f, 0x6f, 0xd3, 0x30,
+	0x14, 0x6f, 0xd2, 0xac, 0xdb, 0xbc, 0x09, 0x84, 0x35, 0x4d, 0x59, 0x36, 0xa5, 0xa5, 0x07, 0x54,
+	0x90, 0xea, 0x28, 0xdd, 0x01, 0xc1, 0xb1, 0x1b, 0x1a, 0x15, 0x54, 0x42, 0xd9, 0x8d, 0xcb, 0xe4,
+	0x3a, 0x56, 0x6a, 0xd1, 0xd8, 0xc5, 0x76, 0xcb, 0xf8, 0x06, 0x70, 0xe3, 0x23, 0xf0, 0x5d, 0xb8,
+	0xec, 0xb8, 0xe3, 0xc4, 0xa1, 0x42, 0xed, 0x17, 0x41, 0xf9, 0xcb, 0xd6, 0xc2, 0xe8, 0x29, 0x7e,
+	0x7e, 0xbf, 0xfc, 0xde, 0xef, 0xfd, 0xfc, 0x6c, 0x50, 0xa7, 0x7a, 0x48, 0x65, 0xcc, 0xb8, 0xf6,
+	0x14, 0x19, 0xd2, 0x70, 0x32, 0xa2, 0xd2, 0x9b, 0xfa, 0x9e, 0xbe, 0x44, 0x63, 0x29, 0xb4, 0x80,
+	0xfb, 0x25, 0x00, 0x95, 0x00, 0x34, 0xf5, 0x9d, 0xbd, 0x48, 0x44, 0x22, 0x85, 0x78, 0xc9, 0x2a,
+	0x43, 0x3b, 0x2e, 0x11, 0x2a, 0x16, 0xca, 0x1b, 0x60, 0x45, 0xbd, 0xa9, 0x3f, 0xa0, 0x1a, 0xfb,
+	0x1e, 0x11, 0x8c, 0x67, 0xf9, 0xe6, 0x0f, 0x13, 0x3c, 0xea, 0xab, 0xe8, 0x44, 0x52, 0xac, 0xe9,
+	0x79, 0xce, 0x07, 0xf7, 0xc0, 0x86, 0xf8, 0xc4, 0xa9, 0xb4, 0x8d, 0x86, 0xd1, 0xda, 0x0e, 0xb2,
+	0x00, 0x3a, 0x60, 0x8b, 0x08, 0xae, 0x25, 0x26, 0xda, 0x36, 0xd3, 0x44, 0x19, 0x43, 0x08, 0xac,
+	0x10, 0x6b, 0x6c, 0x57, 0x1b, 0x46, 0x6b, 0x37, 0x48, 0xd7, 0xf0, 0x10, 0x6c, 0x47, 0x58, 0x5d,
+	0x8c, 0x58, 0xcc, 0xb4, 0x6d, 0x35, 0x8c, 0x96, 0x15, 0x6c, 0x45, 0x58, 0xbd, 0x4d, 0x62, 0xf8,
+	0x26, 0x4b, 0x8e, 0x25, 0x23, 0xd4, 0xde, 0x48, 0xd8, 0xba, 0xe8, 0x6a, 0x56, 0xaf, 0xfc, 0x9c,
+	0xd5, 0x9f, 0x44, 0x4c, 0x0f, 0x27, 0x03, 0x44, 0x44, 0xec, 0xe5, 0xf2, 0xb3, 0x4f, 0x5b, 0x85,
+	0x1f, 0x3c, 0xfd, 0x79, 0x4c, 0x15, 0xea, 0x71, 0x9d, 0x92, 0xbd, 0x4b, 0xfe, 0x87, 0x8f, 0xc1,
+	0xae, 0xd2, 0x58, 0xea, 0x8b, 0x21, 0x65, 0xd1, 0x50, 0xdb, 0xb5, 0x86, 0xd1, 0xaa, 0x06, 0x3b,
+	0xe9, 0xde, 0xeb, 0x74, 0x2b, 0x11, 0xcf, 0xb8, 0xa6, 0x72, 0x8a, 0x47, 0xf6, 0x66, 0xa6, 0xa5,
+	0x88, 0xe1, 0x0b, 0xb0, 0x19, 0xd2, 0xb1, 0x50, 0x4c, 0xdb, 0x5b, 0x0d, 0xa3, 0xb5, 0xd3, 0x39,
+	0x40, 0x59, 0x41, 0x94, 0xd8, 0x86, 0x72, 0xdb, 0xd0, 0x89, 0x60, 0xbc, 0x6b, 0x25, 0x22, 0x83,
+	0x02, 0xff, 0xd2, 0xfa, 0xf2, 0xbd, 0x5e, 0x69, 0x1e, 0x83, 0x83, 0x15, 0x13, 0x03, 0xaa, 0xc6,
+	0x82, 0x2b, 0x0a, 0xf7, 0x81, 0xc9, 0xc2, 0xd4, 0x49, 0xab, 0x5b, 0x9b, 0xcf, 0xea, 0x66, 0xef,
+	0x34, 0x30, 0x59, 0xd8, 0xfc, 0x6a, 0x00, 0xd8, 0x57, 0xd1, 0x69, 0xc6, 0x54, 0x7a, 0x7f, 0x04,
+	0xb6, 0x73, 0x72, 0x51, 0xf8, 0xff, 0x67, 0x23, 0x27, 0x33, 0x97, 0xc9, 0xe0, 0x73, 0x50, 0xc3,
+	0xb1, 0x98, 0x70, 0x6d, 0x57, 0xd7, 0xeb, 0x20, 0x87, 0xe7, 0x0d, 0x1c, 0x01, 0x67, 0x55, 0x4a,
+	0xd1, 0x41, 0xf3, 0x2c, 0x9b, 0x11, 0xcc, 0x09, 0x1d, 0xfd, 0x67, 0x46, 0xfe, 0xa1, 0x2f, 0x2f,
+	0x73, 0x08, 0x0e, 0x56, 0x88, 0x8a, 0x2a, 0x9d, 0x1b, 0x13, 0x54, 0xfb, 0x2a, 0x82, 0x1c, 0x3c,
+	0x58, 0x1a, 0xc7, 0xa7, 0xe8, 0xef, 0x33, 0x8f, 0x56, 0x4c, 0x77, 0xfc, 0xb5, 0xa1, 0xe5, 0xf9,
+	0x7c, 0x04, 0x0f, 0x97, 0xcf, 0xe0, 0xd9, 0x3d, 0x2c, 0x4b, 0x58, 0xa7, 0xb3, 0x3e, 0xb6, 0x2c,
+	0x99, 0xb4, 0x78, 0xd7, 0xcd, 0x7b, 0x5b, 0xbc, 0x03, 0x75, 0xfc, 0xb5, 0xa1, 0x45, 0xbd, 0x2e,
+	0xbe, 0x9a, 0xbb, 0xc6, 0xf5, 0xdc, 0x35, 0x7e, 0xcd, 0x5d, 0xe3, 0xdb, 0xc2, 0xad, 0x5c, 0x2f,
+	0xdc, 0xca, 0xcd, 0xc2, 0xad, 0xbc, 0x3f, 0xbb, 0x75, 0xd7, 0x5e, 0x8d, 0x28, 0xd1, 0x52, 0x70,
+	0x46, 0xda, 0xe7, 0x2c, 0xe2, 0x58, 0x4f, 0x24, 0x55, 0xed, 0x1e, 0x0f, 0x27, 0x4a, 0x4b, 0x46,
+	0x95, 0x87, 0x39, 0x11, 0xbc, 0x4d, 0xa7, 0xb1, 0x77, 0x79, 0xeb, 0x71, 0x4a, 0x2f, 0xe4, 0xa0,
+	0x96, 0xbe, 0x27, 0xc7, 0xbf, 0x07, 0x00, 0x64, 0x23, 0xaa, 0x28, 0xc0, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MsgClient is the client API for Msg service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MsgClient interface {
+	// CreateSchedule registers a scheduled contract call and escrows its deposit.
+	CreateSchedule(ctx context.Context, in *MsgCreateSchedule, opts ...grpc.CallOption) (*MsgCreateScheduleResponse, error)
+	// DepositSchedule adds funds to the escrow of a schedule.
+	DepositSchedule(ctx context.Context, in *MsgDepositSchedule, opts ...grpc.CallOption) (*MsgDepositScheduleResponse, error)
+	// CancelSchedule removes a schedule and refunds its remaining escrow to the owner.
+	CancelSchedule(ctx context.Context, in *MsgCancelSchedule, opts ...grpc.CallOption) (*MsgCancelScheduleResponse, error)
+}
+
+type msgClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewMsgClient(cc grpc1.ClientConn) MsgClient {
+	return &msgClient{cc}
+}
+
+func (c *msgClient) CreateSchedule(ctx context.Context, in *MsgCreateSchedule, opts ...grpc.CallOption) (*MsgCreateScheduleResponse, error) {
+	out := new(MsgCreateScheduleResponse)
+	err := c.cc.Invoke(ctx, "/ethermint.scheduler.v1.Msg/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) DepositSchedule(ctx context.Context, in *MsgDepositSchedule, opts ...grpc.CallOption) (*MsgDepositScheduleResponse, error) {
+	out := new(MsgDepositScheduleResponse)
+	err := c.cc.Invoke(ctx, "/ethermint.scheduler.v1.Msg/DepositSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *msgClient) CancelSchedule(ctx context.Context, in *MsgCancelSchedule, opts ...grpc.CallOption) (*MsgCancelScheduleResponse, error) {
+	out := new(MsgCancelScheduleResponse)
+	err := c.cc.Invoke(ctx, "/ethermint.scheduler.v1.Msg/CancelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MsgServer is the server API for Msg service.
+type MsgServer interface {
+	// CreateSchedule registers a scheduled contract call and escrows its deposit.
+	CreateSchedule(context.Context, *MsgCreateSchedule) (*MsgCreateScheduleResponse, error)
+	// DepositSchedule adds funds to the escrow of a schedule.
+	DepositSchedule(context.Context, *MsgDepositSchedule) (*MsgDepositScheduleResponse, error)
+	// CancelSchedule removes a schedule and refunds its remaining escrow to the owner.
+	CancelSchedule(context.Context, *MsgCancelSchedule) (*MsgCancelScheduleResponse, error)
+}
+
+// UnimplementedMsgServer can be embedded to have forward compatible implementations.
+type UnimplementedMsgServer struct {
+}
+
+func (*UnimplementedMsgServer) CreateSchedule(ctx context.Context, req *MsgCreateSchedule) (*MsgCreateScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (*UnimplementedMsgServer) DepositSchedule(ctx context.Context, req *MsgDepositSchedule) (*MsgDepositScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DepositSchedule not implemented")
+}
+func (*UnimplementedMsgServer) CancelSchedule(ctx context.Context, req *MsgCancelSchedule) (*MsgCancelScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+
+func RegisterMsgServer(s grpc1.Server, srv MsgServer) {
+	s.RegisterService(&_Msg_serviceDesc, srv)
+}
+
+func _Msg_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethermint.scheduler.v1.Msg/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateSchedule(ctx, req.(*MsgCreateSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DepositSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDepositSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DepositSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethermint.scheduler.v1.Msg/DepositSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DepositSchedule(ctx, req.(*MsgDepositSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCancelSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ethermint.scheduler.v1.Msg/CancelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CancelSchedule(ctx, req.(*MsgCancelSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Msg_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ethermint.scheduler.v1.Msg",
+	HandlerType: (*MsgServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _Msg_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "DepositSchedule",
+			Handler:    _Msg_DepositSchedule_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _Msg_CancelSchedule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ethermint/scheduler/v1/tx.proto",
+}
+
+func (m *MsgCreateSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgCreateSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgCreateSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Deposit.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTx(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.Interval != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.Interval))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.StartHeight != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.StartHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	{
+		size := m.GasPrice.Size()
+		i -= size
+		if _, err := m.GasPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTx(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if m.GasLimit != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.GasLimit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgCreateScheduleResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgCreateScheduleResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgCreateScheduleResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgDepositSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgDepositSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgDepositSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Amount.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTx(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.ID != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Depositor) > 0 {
+		i -= len(m.Depositor)
+		copy(dAtA[i:], m.Depositor)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Depositor)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgDepositScheduleResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgDepositScheduleResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgDepositScheduleResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgCancelSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgCancelSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgCancelSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgCancelScheduleResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgCancelScheduleResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgCancelScheduleResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTx(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTx(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *MsgCreateSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	if m.GasLimit != 0 {
+		n += 1 + sovTx(uint64(m.GasLimit))
+	}
+	l = m.GasPrice.Size()
+	n += 1 + l + sovTx(uint64(l))
+	if m.StartHeight != 0 {
+		n += 1 + sovTx(uint64(m.StartHeight))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovTx(uint64(m.Interval))
+	}
+	l = m.Deposit.Size()
+	n += 1 + l + sovTx(uint64(l))
+	return n
+}
+
+func (m *MsgCreateScheduleResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovTx(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *MsgDepositSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Depositor)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	if m.ID != 0 {
+		n += 1 + sovTx(uint64(m.ID))
+	}
+	l = m.Amount.Size()
+	n += 1 + l + sovTx(uint64(l))
+	return n
+}
+
+func (m *MsgDepositScheduleResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *MsgCancelSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	if m.ID != 0 {
+		n += 1 + sovTx(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *MsgCancelScheduleResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovTx(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTx(x uint64) (n int) {
+	return sovTx(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *MsgCreateSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgCreateSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgCreateSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimit", wireType)
+			}
+			m.GasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
+			}
+			m.StartHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deposit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Deposit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgCreateScheduleResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgCreateScheduleResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgCreateScheduleResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgDepositSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgDepositSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgDepositSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depositor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Depositor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Amount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgDepositScheduleResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgDepositScheduleResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgDepositScheduleResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgCancelSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgCancelSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgCancelSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgCancelScheduleResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgCancelScheduleResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgCancelScheduleResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTx(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTx
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTx
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTx
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTx        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTx          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTx = fmt.Errorf("proto: unexpected end of group")
+)